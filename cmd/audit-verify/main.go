@@ -0,0 +1,53 @@
+package main
+
+import (
+	"asset-service/config"
+	repository "asset-service/internal/repository/assets"
+	services "asset-service/internal/services/assets"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// audit-verify walks the audit chains and reports the first broken link of each, it exits with status 1 when
+// any chain is broken
+func main() {
+	tenantID := flag.String("tenant", "", "client id of the chain to verify, every chain when empty")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	db := config.InitDatabase(cfg)
+
+	service := services.NewAssetAuditLogService(nil, nil, repository.NewAssetAuditLogRepository(*db), nil, cfg.AuditCheckpointKey)
+	results, err := service.VerifyAuditChains(*tenantID)
+	config.CloseDatabase(db)
+	if err != nil {
+		log.Fatalf("❌ Failed to verify audit chains: %v", err)
+	}
+
+	broken := false
+	for _, result := range results {
+		if result.Valid {
+			fmt.Printf("✅ %s: %d entries, %d checkpoints\n", result.TenantID, result.EntriesChecked, result.CheckpointsChecked)
+			continue
+		}
+
+		broken = true
+		link := result.BrokenLink
+		fmt.Printf("❌ %s: broken at log %d after %d entries: %s\n", result.TenantID, link.LogID, result.EntriesChecked, link.Reason)
+		if link.CheckpointID != nil {
+			fmt.Printf("   checkpoint: %d\n", *link.CheckpointID)
+		}
+		if link.Expected != nil {
+			fmt.Printf("   expected:   %s\n", *link.Expected)
+		}
+		if link.Actual != nil {
+			fmt.Printf("   actual:     %s\n", *link.Actual)
+		}
+	}
+
+	if broken {
+		os.Exit(1)
+	}
+}
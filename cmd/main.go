@@ -35,6 +35,21 @@ func main() {
 	assets.AssetMaintenanceTypeRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetMaintenanceType)
 	assets.AssetGroupRoutes(engine, serverConfig.Middleware, serverConfig.Controller)
 	assets.AssetMaintenanceRecordRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetMaintenanceRecord)
+	assets.AssetTagRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetTag)
+	assets.AssetLabelRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetLabel)
+	assets.AssetImportRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetImport)
+	assets.AssetExportRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetExport)
+	assets.AssetExpiryRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetExpiry)
+	assets.AssetTrashRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetTrash)
+	assets.AssetDepreciationRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetDepreciation)
+	assets.AssetTransferRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetTransfer)
+	assets.AssetLocationRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetLocation)
+	assets.AssetComponentRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetComponent)
+	assets.AssetMaintenanceAttachmentRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetMaintenanceAttachment)
+	assets.AssetMaintenanceCostRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetMaintenanceCost)
+	assets.AssetDashboardRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetDashboard)
+	assets.AssetAuditLogRoutes(engine, serverConfig.Middleware, serverConfig.Controller.AssetAuditLog)
+	assets.CronJobRoutes(engine, serverConfig.Middleware, serverConfig.Cron.CronController)
 
 	// Run server
 	log.Println("Starting server on :8081")
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils/audit"
 	"context"
 	"fmt"
 	"log"
@@ -32,6 +34,18 @@ type Config struct {
 	DBSSLMode  string `envconfig:"DB_SSLMODE" default:"disable"`
 	CdnUrl     string `envconfig:"CDN_URL"  default:"http://localhost:8181"`
 	NatsUrl    string `envconfig:"NATS_URL" default:"nats://localhost:4222"`
+	AssetUrl   string `envconfig:"ASSET_URL" default:"http://localhost:8081/v1/asset"`
+
+	MaintenanceDueWithinDays int `envconfig:"MAINTENANCE_DUE_WITHIN_DAYS" default:"7"`
+	AssetExpiryWithinDays    int `envconfig:"ASSET_EXPIRY_WITHIN_DAYS" default:"30"`
+
+	// TrashRetentionDays is how long deleted assets stay restorable before they are purged, 0 keeps them forever
+	TrashRetentionDays int `envconfig:"TRASH_RETENTION_DAYS" default:"30"`
+
+	CronInstanceID string `envconfig:"CRON_INSTANCE_ID" default:""`
+
+	// AuditCheckpointKey signs the audit chain checkpoints, they are not taken while it is empty
+	AuditCheckpointKey string `envconfig:"AUDIT_CHECKPOINT_KEY" default:""`
 }
 
 // LoadConfig loads environment variables into the Config struct
@@ -84,9 +98,41 @@ func InitDatabase(cfg *Config) *gorm.DB {
 	}
 
 	logrus.Info("✅ Connected to PostgreSQL")
+
+	if err = audit.Register(db, auditedModels...); err != nil {
+		logrus.WithError(err).Fatal("❌ Failed to register audit callbacks")
+	}
 	return db
 }
 
+// auditedModels are the models whose every create, update and delete is written to the audit log, the
+// notification and expiry alert bookkeeping of the cron jobs is left out
+var auditedModels = []audit.Auditable{
+	&assets.Asset{},
+	&assets.AssetCategory{},
+	&assets.AssetStatus{},
+	&assets.AssetStock{},
+	&assets.AssetStockHistory{},
+	&assets.AssetImage{},
+	&assets.AssetTag{},
+	&assets.AssetTagMap{},
+	&assets.AssetLocation{},
+	&assets.AssetLocationHistory{},
+	&assets.AssetTransfer{},
+	&assets.AssetWishlist{},
+	&assets.AssetMaintenance{},
+	&assets.AssetMaintenanceRecord{},
+	&assets.AssetMaintenanceType{},
+	&assets.AssetMaintenanceAttachment{},
+	&assets.AssetGroup{},
+	&assets.AssetGroupAsset{},
+	&assets.AssetGroupAssetLoan{},
+	&assets.AssetGroupInvitation{},
+	&assets.AssetGroupMember{},
+	&assets.AssetGroupMemberPermission{},
+	&assets.AssetGroupPermission{},
+}
+
 // CloseDatabase closes the database connection properly
 func CloseDatabase(db *gorm.DB) {
 	sqlDB, err := db.DB()
@@ -97,21 +97,32 @@ func (s *ServerConfig) initRepository() {
 		UserRepository:                       users.NewUserRepository(*s.DB),
 		UserSettingRepository:                users.NewUserSettingRepository(*s.DB),
 		AssetAuditLog:                        repository.NewAssetAuditLogRepository(*s.DB),
-		AssetCategory:                        repository.NewAssetCategoryRepository(*s.DB, s.Repository.AssetAuditLog),
+		AssetCategory:                        repository.NewAssetCategoryRepository(*s.DB),
 		AssetMaintenanceType:                 repository.NewAssetMaintenanceTypeRepository(*s.DB),
-		AssetRepository:                      repository.NewAssetRepository(*s.DB, s.Repository.AssetAuditLog),
+		AssetRepository:                      repository.NewAssetRepository(*s.DB),
 		AssetStatusRepository:                repository.NewAssetStatusRepository(*s.DB),
-		AssetWishlistRepository:              repository.NewAssetWishlistRepository(*s.DB, s.Repository.AssetAuditLog),
+		AssetWishlistRepository:              repository.NewAssetWishlistRepository(*s.DB),
 		AssetMaintenance:                     repository.NewAssetMaintenanceRepository(*s.DB),
 		AssetMaintenanceRecord:               repository.NewAssetMaintenanceRecordRepository(*s.DB),
 		AssetImageRepository:                 repository.NewAssetImageRepository(*s.DB),
 		AssetStockRepository:                 repository.NewAssetStockRepository(*s.DB),
-		AssetGroupRepository:                 repository.NewAssetGroupRepository(*s.DB, s.Repository.AssetAuditLog),
-		AssetGroupAssetRepository:            repository.NewAssetGroupAssetRepository(*s.DB, s.Repository.AssetAuditLog),
-		AssetGroupMemberRepository:           repository.NewAssetGroupMemberRepository(*s.DB, s.Repository.AssetAuditLog),
-		AssetGroupMemberPermissionRepository: repository.NewAssetGroupMemberPermissionRepository(*s.DB, repository.NewAssetAuditLogRepository(*s.DB)),
-		AssetGroupPermissionRepository:       repository.NewAssetGroupPermissionRepository(*s.DB, repository.NewAssetAuditLogRepository(*s.DB)),
+		AssetGroupRepository:                 repository.NewAssetGroupRepository(*s.DB),
+		AssetGroupAssetRepository:            repository.NewAssetGroupAssetRepository(*s.DB),
+		AssetGroupMemberRepository:           repository.NewAssetGroupMemberRepository(*s.DB),
+		AssetGroupMemberPermissionRepository: repository.NewAssetGroupMemberPermissionRepository(*s.DB),
+		AssetGroupPermissionRepository:       repository.NewAssetGroupPermissionRepository(*s.DB),
 		AssetGroupInvitation:                 repository.NewAssetGroupInvitationRepository(*s.DB),
+		AssetTag:                             repository.NewAssetTagRepository(*s.DB),
+		AssetExport:                          repository.NewAssetExportRepository(*s.DB),
+		AssetMaintenanceNotification:         repository.NewAssetMaintenanceNotificationRepository(*s.DB),
+		AssetExpiry:                          repository.NewAssetExpiryRepository(*s.DB),
+		AssetTrash:                           repository.NewAssetTrashRepository(*s.DB),
+		AssetGroupAssetLoanRepository:        repository.NewAssetGroupAssetLoanRepository(*s.DB),
+		AssetTransfer:                        repository.NewAssetTransferRepository(*s.DB),
+		AssetLocation:                        repository.NewAssetLocationRepository(*s.DB),
+		AssetComponent:                       repository.NewAssetComponentRepository(*s.DB),
+		AssetMaintenanceAttachment:           repository.NewAssetMaintenanceAttachmentRepository(*s.DB),
+		AssetMaintenanceCost:                 repository.NewAssetMaintenanceCostRepository(*s.DB),
 	}
 }
 
@@ -121,14 +132,15 @@ func (s *ServerConfig) initServices() {
 		AssetCategory: services.NewAssetCategoryService(
 			s.Repository.AssetCategory,
 			s.Repository.AssetRepository,
-			s.Repository.AssetAuditLog,
 			s.Redis),
 		AssetMaintenance: services.NewAssetMaintenanceService(
 			s.Repository.AssetMaintenance,
 			s.Repository.AssetRepository,
 			s.Repository.AssetMaintenanceRecord,
-			s.Repository.AssetAuditLog,
-			s.Redis),
+			s.Repository.AssetMaintenanceNotification,
+			s.Redis,
+			s.Nats.NatsService,
+			s.Config.MaintenanceDueWithinDays),
 		AssetMaintenanceType: services.NewAssetMaintenanceTypeService(
 			s.Repository.AssetMaintenanceType,
 			s.Repository.AssetMaintenance,
@@ -137,7 +149,6 @@ func (s *ServerConfig) initServices() {
 			s.Repository.AssetMaintenance,
 			s.Repository.AssetRepository,
 			s.Repository.AssetMaintenanceRecord,
-			s.Repository.AssetAuditLog,
 			s.Redis),
 		Asset: services.NewAssetService(
 			s.Repository.UserRepository,
@@ -145,15 +156,14 @@ func (s *ServerConfig) initServices() {
 			s.Repository.AssetCategory,
 			s.Repository.AssetStatusRepository,
 			s.Repository.AssetImageRepository,
-			s.Repository.AssetAuditLog,
 			s.Repository.AssetGroupMemberRepository,
 			s.Repository.AssetGroupAssetRepository,
 			s.Redis,
 			s.Transaction.AssetTransactionRepository,
-			s.Repository.AssetStockRepository),
+			s.Repository.AssetStockRepository,
+			s.Repository.AssetAuditLog),
 		AssetStatus: services.NewAssetStatusService(
 			s.Repository.AssetStatusRepository,
-			s.Repository.AssetAuditLog,
 			s.Redis),
 		AssetWishlist: services.NewAssetWishlistService(
 			s.Repository.UserRepository,
@@ -162,25 +172,22 @@ func (s *ServerConfig) initServices() {
 			s.Repository.AssetStatusRepository,
 			s.Repository.AssetImageRepository,
 			s.Repository.AssetRepository,
-			s.Repository.AssetAuditLog,
 			s.Repository.AssetGroupMemberRepository,
 			s.Repository.AssetGroupAssetRepository,
 			s.Redis),
 		AssetImage: services.NewAssetImageService(
 			s.Repository.AssetImageRepository,
-			s.Repository.AssetRepository,
+			s.Repository.AssetMaintenanceAttachment,
 			s.Redis,
 			s.Nats.NatsService),
 		AssetGroupAssetService: services.NewAssetGroupAssetService(
 			s.Repository.AssetGroupAssetRepository,
 			s.Repository.AssetRepository,
-			s.Repository.AssetAuditLog,
 			s.Redis,
 		),
 		AssetGroupPermissionService: services.NewAssetGroupPermissionService(
 			s.Repository.AssetGroupPermissionRepository,
 			s.Repository.AssetRepository,
-			s.Repository.AssetAuditLog,
 			s.Redis),
 		AssetGroupMemberService: services.NewAssetGroupMemberService(
 			s.Repository.UserRepository,
@@ -190,7 +197,6 @@ func (s *ServerConfig) initServices() {
 			s.Repository.AssetGroupMemberRepository,
 			s.Repository.AssetRepository,
 			s.Repository.AssetGroupInvitation,
-			s.Repository.AssetAuditLog,
 			s.Redis),
 		AssetGroupService: services.NewAssetGroupService(
 			s.Repository.UserRepository,
@@ -201,8 +207,97 @@ func (s *ServerConfig) initServices() {
 			s.Repository.AssetGroupAssetRepository,
 			s.Repository.AssetRepository,
 			s.Repository.AssetStockRepository,
-			s.Repository.AssetAuditLog,
 			s.Redis),
+		AssetTag: services.NewAssetTagService(
+			s.Repository.AssetTag,
+			s.Repository.AssetRepository,
+			s.Redis),
+		AssetLabel: services.NewAssetLabelService(
+			s.Repository.AssetRepository,
+			s.Redis,
+			s.Config.AssetUrl),
+		AssetImport: services.NewAssetImportService(
+			s.Repository.UserRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetCategory,
+			s.Repository.AssetStatusRepository,
+			s.Repository.AssetGroupMemberRepository,
+			s.Redis),
+		AssetExport: services.NewAssetExportService(
+			s.Repository.UserRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetExport,
+			s.Redis),
+		AssetExpiry: services.NewAssetExpiryService(
+			s.Repository.AssetExpiry,
+			s.Redis,
+			s.Nats.NatsService,
+			s.Config.AssetExpiryWithinDays),
+		AssetTrash: services.NewAssetTrashService(
+			s.Repository.AssetTrash,
+			s.Repository.AssetRepository,
+			s.Repository.AssetCategory,
+			s.Repository.AssetStatusRepository,
+			s.Repository.AssetImageRepository,
+			s.Redis,
+			s.Nats.NatsService,
+			s.Config.TrashRetentionDays),
+		AssetDepreciation: services.NewAssetDepreciationService(
+			s.Repository.AssetRepository,
+			s.Redis),
+		AssetGroupLoanService: services.NewAssetGroupLoanService(
+			s.Repository.UserRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetGroupMemberRepository,
+			s.Repository.AssetGroupMemberPermissionRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetGroupAssetLoanRepository,
+			s.Redis),
+		AssetTransfer: services.NewAssetTransferService(
+			s.Repository.UserRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetTransfer,
+			s.Redis),
+		AssetLocation: services.NewAssetLocationService(
+			s.Repository.UserRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetGroupMemberRepository,
+			s.Repository.AssetGroupMemberPermissionRepository,
+			s.Repository.AssetLocation,
+			s.Redis),
+		AssetComponent: services.NewAssetComponentService(
+			s.Repository.AssetRepository,
+			s.Repository.AssetComponent,
+			s.Redis),
+		AssetMaintenanceAttachment: services.NewAssetMaintenanceAttachmentService(
+			s.Repository.AssetMaintenanceAttachment,
+			s.Repository.AssetMaintenance,
+			s.Repository.AssetMaintenanceRecord,
+			s.Redis,
+			s.Nats.NatsService),
+		AssetMaintenanceCost: services.NewAssetMaintenanceCostService(
+			s.Repository.UserRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetGroupMemberRepository,
+			s.Repository.AssetMaintenanceCost,
+			s.Redis),
+		AssetDashboard: services.NewAssetDashboardService(
+			s.Repository.UserRepository,
+			s.Repository.AssetGroupRepository,
+			s.Repository.AssetGroupMemberRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetStockRepository,
+			s.Repository.AssetMaintenance,
+			s.Repository.AssetWishlistRepository,
+			s.Redis),
+		AssetAuditLog: services.NewAssetAuditLogService(
+			s.Repository.UserRepository,
+			s.Repository.AssetRepository,
+			s.Repository.AssetAuditLog,
+			s.Redis,
+			s.Config.AuditCheckpointKey),
 	}
 }
 
@@ -231,6 +326,21 @@ func (s *ServerConfig) initController() {
 		AssetGroupController:           controller.NewAssetGroupController(s.Services.AssetGroupService, s.JWTService),
 		AssetGroupMemberController:     controller.NewAssetGroupMemberController(s.Services.AssetGroupMemberService, s.JWTService),
 		AssetGroupPermissionController: controller.NewAssetGroupPermissionController(s.Services.AssetGroupPermissionService, s.JWTService),
+		AssetTag:                       controller.NewAssetTagController(s.Services.AssetTag, s.JWTService),
+		AssetLabel:                     controller.NewAssetLabelController(s.Services.AssetLabel, s.JWTService),
+		AssetImport:                    controller.NewAssetImportController(s.Services.AssetImport, s.JWTService),
+		AssetExport:                    controller.NewAssetExportController(s.Services.AssetExport, s.JWTService),
+		AssetExpiry:                    controller.NewAssetExpiryController(s.Services.AssetExpiry, s.JWTService),
+		AssetTrash:                     controller.NewAssetTrashController(s.Services.AssetTrash, s.JWTService),
+		AssetDepreciation:              controller.NewAssetDepreciationController(s.Services.AssetDepreciation, s.JWTService),
+		AssetGroupLoanController:       controller.NewAssetGroupLoanController(s.Services.AssetGroupLoanService, s.JWTService),
+		AssetTransfer:                  controller.NewAssetTransferController(s.Services.AssetTransfer, s.JWTService),
+		AssetLocation:                  controller.NewAssetLocationController(s.Services.AssetLocation, s.JWTService),
+		AssetComponent:                 controller.NewAssetComponentController(s.Services.AssetComponent, s.JWTService),
+		AssetMaintenanceAttachment:     controller.NewAssetMaintenanceAttachmentController(s.Services.AssetMaintenanceAttachment, s.JWTService, s.Config.CdnUrl),
+		AssetMaintenanceCost:           controller.NewAssetMaintenanceCostController(s.Services.AssetMaintenanceCost, s.JWTService),
+		AssetDashboard:                 controller.NewAssetDashboardController(s.Services.AssetDashboard, s.JWTService),
+		AssetAuditLog:                  controller.NewAssetAuditLogController(s.Services.AssetAuditLog, s.JWTService),
 	}
 }
 
@@ -249,15 +359,18 @@ func (s *ServerConfig) initTransaction() {
 			s.Repository.AssetStatusRepository,
 			s.Repository.AssetMaintenance,
 			s.Repository.AssetMaintenanceRecord,
-			s.Repository.AssetAuditLog),
+			s.Repository.AssetComponent,
+			s.Repository.AssetMaintenanceAttachment),
 	}
 }
 
 func (s *ServerConfig) initCron() {
+	cronRepository := repositorycron.NewCronRepository(*s.DB)
+	cronService := service.NewCronService(*s.DB, cronRepository, s.Services.AssetMaintenance, s.Services.AssetImage, s.Services.AssetMaintenanceAttachment, s.Services.AssetExpiry, s.Services.AssetAuditLog, s.Services.AssetTrash, s.Redis, s.Config.CronInstanceID)
 	s.Cron = Cron{
-		CronRepository: repositorycron.NewCronRepository(*s.DB),
-		CronService:    service.NewCronService(*s.DB, repositorycron.NewCronRepository(*s.DB), s.Services.AssetMaintenance, s.Services.AssetImage),
-		CronController: controllercron.NewCronJobController(service.NewCronService(*s.DB, repositorycron.NewCronRepository(*s.DB), s.Services.AssetMaintenance, nil)),
+		CronRepository: cronRepository,
+		CronService:    cronService,
+		CronController: controllercron.NewCronJobController(cronService),
 	}
 	s.Cron.CronService.Start()
 }
@@ -47,6 +47,21 @@ type Services struct {
 	AssetGroupMemberService     services.AssetGroupMemberService
 	AssetGroupPermissionService services.AssetGroupPermissionService
 	AssetGroupService           services.AssetGroupService
+	AssetTag                    services.AssetTagService
+	AssetLabel                  services.AssetLabelService
+	AssetImport                 services.AssetImportService
+	AssetExport                 services.AssetExportService
+	AssetExpiry                 services.AssetExpiryService
+	AssetTrash                  services.AssetTrashService
+	AssetDepreciation           services.AssetDepreciationService
+	AssetGroupLoanService       services.AssetGroupLoanService
+	AssetTransfer               services.AssetTransferService
+	AssetLocation               services.AssetLocationService
+	AssetComponent              services.AssetComponentService
+	AssetMaintenanceAttachment  services.AssetMaintenanceAttachmentService
+	AssetMaintenanceCost        services.AssetMaintenanceCostService
+	AssetDashboard              services.AssetDashboardService
+	AssetAuditLog               services.AssetAuditLogService
 }
 
 // Repository contains repository (database access objects)
@@ -69,6 +84,17 @@ type Repository struct {
 	AssetGroupMemberPermissionRepository repository.AssetGroupMemberPermissionRepository
 	AssetGroupPermissionRepository       repository.AssetGroupPermissionRepository
 	AssetGroupInvitation                 repository.AssetGroupInvitationRepository
+	AssetTag                             repository.AssetTagRepository
+	AssetExport                          repository.AssetExportRepository
+	AssetMaintenanceNotification         repository.AssetMaintenanceNotificationRepository
+	AssetExpiry                          repository.AssetExpiryRepository
+	AssetTrash                           repository.AssetTrashRepository
+	AssetGroupAssetLoanRepository        repository.AssetGroupAssetLoanRepository
+	AssetTransfer                        repository.AssetTransferRepository
+	AssetLocation                        repository.AssetLocationRepository
+	AssetComponent                       repository.AssetComponentRepository
+	AssetMaintenanceAttachment           repository.AssetMaintenanceAttachmentRepository
+	AssetMaintenanceCost                 repository.AssetMaintenanceCostRepository
 }
 
 type Controller struct {
@@ -82,6 +108,21 @@ type Controller struct {
 	AssetGroupController           controller.AssetGroupController
 	AssetGroupMemberController     controller.AssetGroupMemberController
 	AssetGroupPermissionController controller.AssetGroupPermissionController
+	AssetTag                       controller.AssetTagController
+	AssetLabel                     controller.AssetLabelController
+	AssetImport                    controller.AssetImportController
+	AssetExport                    controller.AssetExportController
+	AssetExpiry                    controller.AssetExpiryController
+	AssetTrash                     controller.AssetTrashController
+	AssetDepreciation              controller.AssetDepreciationController
+	AssetGroupLoanController       controller.AssetGroupLoanController
+	AssetTransfer                  controller.AssetTransferController
+	AssetLocation                  controller.AssetLocationController
+	AssetComponent                 controller.AssetComponentController
+	AssetMaintenanceAttachment     controller.AssetMaintenanceAttachmentController
+	AssetMaintenanceCost           controller.AssetMaintenanceCostController
+	AssetDashboard                 controller.AssetDashboardController
+	AssetAuditLog                  controller.AssetAuditLogController
 }
 
 type Middleware struct {
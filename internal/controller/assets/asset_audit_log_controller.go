@@ -0,0 +1,112 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type AssetAuditLogController interface {
+	GetListAuditLog(context *gin.Context)
+	GetListAssetAuditLog(context *gin.Context)
+	VerifyAuditChain(context *gin.Context)
+	VerifyAuditChains(context *gin.Context)
+}
+
+type assetAuditLogController struct {
+	AssetAuditLogService assets.AssetAuditLogService
+	JWTService           jwt.Service
+}
+
+func NewAssetAuditLogController(assetAuditLogService assets.AssetAuditLogService, jwtService jwt.Service) AssetAuditLogController {
+	return assetAuditLogController{AssetAuditLogService: assetAuditLogService, JWTService: jwtService}
+}
+
+func (h assetAuditLogController) GetListAuditLog(context *gin.Context) {
+	h.list(context, h.AssetAuditLogService.GetListAuditLog)
+}
+
+func (h assetAuditLogController) GetListAssetAuditLog(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	h.list(context, func(auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+		return h.AssetAuditLogService.GetListAssetAuditLog(assetID, auditQuery, pageIndex, pageSize, clientID)
+	})
+}
+
+func (h assetAuditLogController) VerifyAuditChain(context *gin.Context) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	result, err := h.AssetAuditLogService.VerifyAuditChain(token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusInternalServerError, "Failed to verify audit chain", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Audit chain verified", result, nil)
+}
+
+// VerifyAuditChains verifies the chain of the tenant_id query parameter, or every chain without it
+func (h assetAuditLogController) VerifyAuditChains(context *gin.Context) {
+	results, err := h.AssetAuditLogService.VerifyAuditChains(strings.TrimSpace(context.Query("tenant_id")))
+	if err != nil {
+		response.SendResponse(context, http.StatusInternalServerError, "Failed to verify audit chains", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Audit chains verified", results, nil)
+}
+
+// list binds the paging and the filters shared by both audit endpoints and sends the page fetched by get
+func (h assetAuditLogController) list(context *gin.Context, get func(auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error)) {
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	var auditQuery request.AuditLogQueryRequest
+	if err := context.ShouldBindQuery(&auditQuery); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid query", nil, err.Error())
+		return
+	}
+	if err := auditQuery.Validate(); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid query", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	logs, total, err := get(auditQuery, pageIndex, pageSize, token.ClientID)
+	if err != nil {
+		response.SendResponseList(context, http.StatusInternalServerError, "Failed to get audit logs", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, http.StatusOK, "List of audit logs", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     logs,
+	}, nil)
+}
@@ -47,7 +47,7 @@ func (h assetCategoryController) AddAssetCategory(context *gin.Context) {
 		return
 	}
 
-	assetCategory, err := h.AssetCategoryService.AddAssetCategory(&req, credentialKey, token.ClientID)
+	assetCategory, err := h.AssetCategoryService.AddAssetCategory(context.Request.Context(), &req, credentialKey, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
 		return
@@ -80,7 +80,7 @@ func (h assetCategoryController) UpdateAssetCategory(context *gin.Context) {
 		return
 	}
 
-	assetCategory, err := h.AssetCategoryService.UpdateAssetCategory(assetCategoryID, &req, token.ClientID, credentialKey)
+	assetCategory, err := h.AssetCategoryService.UpdateAssetCategory(context.Request.Context(), assetCategoryID, &req, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
 		return
@@ -158,7 +158,7 @@ func (h assetCategoryController) DeleteAssetCategory(context *gin.Context) {
 		return
 	}
 
-	err = h.AssetCategoryService.DeleteAssetCategory(assetCategoryID, token.ClientID)
+	err = h.AssetCategoryService.DeleteAssetCategory(context.Request.Context(), assetCategoryID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
 		return
@@ -0,0 +1,101 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AssetComponentController interface {
+	AttachComponent(context *gin.Context)
+	DetachComponent(context *gin.Context)
+	GetComponents(context *gin.Context)
+}
+
+type assetComponentController struct {
+	AssetComponentService assets.AssetComponentService
+	JWTService            jwt.Service
+}
+
+func NewAssetComponentController(assetComponentService assets.AssetComponentService, jwtService jwt.Service) AssetComponentController {
+	return assetComponentController{AssetComponentService: assetComponentService, JWTService: jwtService}
+}
+
+func (h assetComponentController) AttachComponent(context *gin.Context) {
+	var req request.AssetComponentRequest
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	component, err := h.AssetComponentService.AttachComponent(context.Request.Context(), assetID, req.ComponentAssetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to attach component", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Component attached successfully", component, nil)
+}
+
+func (h assetComponentController) DetachComponent(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	componentAssetID, err := utils.ConvertToUint(context.Param("component_id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Component ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	component, err := h.AssetComponentService.DetachComponent(context.Request.Context(), assetID, componentAssetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to detach component", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Component detached successfully", component, nil)
+}
+
+func (h assetComponentController) GetComponents(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	components, err := h.AssetComponentService.GetComponents(assetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "List of asset components", components, nil)
+}
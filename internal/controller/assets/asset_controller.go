@@ -16,6 +16,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type AssetController interface {
@@ -28,7 +29,9 @@ type AssetController interface {
 	ReduceStockAsset(context *gin.Context)
 	GetListAsset(context *gin.Context)
 	GetAssetById(context *gin.Context)
+	LookupAsset(context *gin.Context)
 	DeleteAsset(context *gin.Context)
+	RevertAsset(context *gin.Context)
 }
 
 type assetController struct {
@@ -63,6 +66,13 @@ func (h assetController) AddAsset(context *gin.Context) {
 		Notes:          text.GetOptionalString(context, "notes"),
 	}
 
+	if value := context.PostForm("custom_fields"); value != "" {
+		if err := json.Unmarshal([]byte(value), &req.CustomFields); err != nil {
+			response.SendResponse(context, 400, "Invalid custom fields", nil, err.Error())
+			return
+		}
+	}
+
 	// Extract token
 	token, err := h.JWTService.ExtractClaims(context.GetHeader(utils.Authorization))
 	if err != nil {
@@ -87,7 +97,7 @@ func (h assetController) AddAsset(context *gin.Context) {
 		imageMetadata, err = uploadImagesToCDN(h.IpCDN, files, token.ClientID, context.GetHeader(utils.Authorization))
 	}
 
-	asset, err := h.AssetService.AddAsset(&req, imageMetadata, token.ClientID, credentialKey)
+	asset, err := h.AssetService.AddAsset(context.Request.Context(), &req, imageMetadata, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to add asset", nil, err.Error())
 		return
@@ -121,7 +131,7 @@ func (h assetController) UpdateAsset(context *gin.Context) {
 		return
 	}
 
-	asset, err := h.AssetService.UpdateAsset(uint(assetID), req, token.ClientID, credentialKey)
+	asset, err := h.AssetService.UpdateAsset(context.Request.Context(), uint(assetID), req, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update assets", nil, err.Error())
 		return
@@ -148,7 +158,7 @@ func (h assetController) UpdateAssetStatus(context *gin.Context) {
 		return
 	}
 
-	err = h.AssetService.UpdateAssetStatus(uint(assetID), req.StatusID, token.ClientID)
+	err = h.AssetService.UpdateAssetStatus(context.Request.Context(), uint(assetID), req.StatusID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update asset status", nil, err.Error())
 		return
@@ -176,7 +186,7 @@ func (h assetController) UpdateAssetCategory(context *gin.Context) {
 		return
 	}
 
-	err = h.AssetService.UpdateAssetCategory(uint(assetID), req.CategoryID, token.ClientID)
+	err = h.AssetService.UpdateAssetCategory(context.Request.Context(), uint(assetID), req.CategoryID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update asset category", nil, err.Error())
 		return
@@ -215,7 +225,7 @@ func (h assetController) UpdateImageAsset(context *gin.Context) {
 		imageMetadata, err = uploadImagesToCDN(h.IpCDN, files, token.ClientID, context.GetHeader(utils.Authorization))
 	}
 
-	err = h.AssetService.UpdateImageAsset(uint(assetID), token.ClientID, imageMetadata)
+	err = h.AssetService.UpdateImageAsset(context.Request.Context(), uint(assetID), token.ClientID, imageMetadata)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update asset images", nil, err.Error())
 		return
@@ -244,7 +254,7 @@ func (h assetController) AddStockAsset(context *gin.Context) {
 		return
 	}
 
-	data, err := h.AssetService.UpdateStockAsset(true, uint(assetID), req, token.ClientID)
+	data, err := h.AssetService.UpdateStockAsset(context.Request.Context(), true, uint(assetID), req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update stock asset", nil, err.Error())
 		return
@@ -273,7 +283,7 @@ func (h assetController) ReduceStockAsset(context *gin.Context) {
 		return
 	}
 
-	data, err := h.AssetService.UpdateStockAsset(false, uint(assetID), req, token.ClientID)
+	data, err := h.AssetService.UpdateStockAsset(context.Request.Context(), false, uint(assetID), req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update stock asset", nil, err.Error())
 		return
@@ -295,7 +305,13 @@ func (h assetController) GetListAsset(context *gin.Context) {
 		return
 	}
 
-	asset, total, err := h.AssetService.GetListAsset(token.ClientID, pageIndex, pageSize)
+	assetQuery, err := bindAssetQuery(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid asset query", nil, err.Error())
+		return
+	}
+
+	asset, total, err := h.AssetService.GetListAsset(token.ClientID, pageIndex, pageSize, assetQuery)
 	if err != nil {
 		response.SendResponseList(context, 500, "Failed to get list assets", response.PagedData{
 			Total:     total,
@@ -321,7 +337,23 @@ func (h assetController) GetAssetById(context *gin.Context) {
 		return
 	}
 
-	asset, err := h.AssetService.GetAssetByID(token.ClientID, assetID)
+	var query request.AssetAsOfRequest
+	if err := context.ShouldBindQuery(&query); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	asOf, err := query.Time()
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	var asset interface{}
+	if asOf != nil {
+		asset, err = h.AssetService.GetAssetAsOf(token.ClientID, assetID, *asOf)
+	} else {
+		asset, err = h.AssetService.GetAssetByID(token.ClientID, assetID)
+	}
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to get detail assets", nil, err.Error())
 		return
@@ -330,6 +362,28 @@ func (h assetController) GetAssetById(context *gin.Context) {
 
 }
 
+func (h assetController) LookupAsset(context *gin.Context) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	barcode := strings.TrimSpace(context.Query("barcode"))
+	serialNumber := strings.TrimSpace(context.Query("serial"))
+	if (barcode == "") == (serialNumber == "") {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "exactly one of barcode or serial is required")
+		return
+	}
+
+	asset, err := h.AssetService.LookupAsset(token.ClientID, barcode, serialNumber)
+	if err != nil {
+		response.SendResponse(context, http.StatusNotFound, "Asset not found", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Lookup asset successfully", asset, nil)
+}
+
 func (h assetController) DeleteAsset(context *gin.Context) {
 	assetID, err := utils.ConvertToUint(context.Param("id"))
 	token, err := h.JWTService.ExtractClaims(context.GetHeader(utils.Authorization))
@@ -337,7 +391,7 @@ func (h assetController) DeleteAsset(context *gin.Context) {
 		return
 	}
 
-	err = h.AssetService.DeleteAsset(assetID, token.ClientID)
+	err = h.AssetService.DeleteAsset(context.Request.Context(), assetID, token.ClientID, context.Query("components"))
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to delete asset", nil, err.Error())
 		return
@@ -346,6 +400,47 @@ func (h assetController) DeleteAsset(context *gin.Context) {
 	response.SendResponse(context, 200, "Asset deleted successfully", nil, nil)
 }
 
+func (h assetController) RevertAsset(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid asset ID", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	var req request.AssetAsOfRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	asOf, err := req.Time()
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	if asOf == nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "as_of is required")
+		return
+	}
+
+	token, err := h.JWTService.ExtractClaims(context.GetHeader(utils.Authorization))
+	if err != nil {
+		return
+	}
+
+	asset, err := h.AssetService.RevertAsset(context.Request.Context(), assetID, *asOf, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, 500, "Failed to revert asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, 201, "Asset reverted successfully", asset, nil)
+}
+
 func uploadImagesToCDN(ipCdn string, files []*multipart.FileHeader, clientID, authToken string) ([]responses.AssetImageResponse, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -409,3 +504,23 @@ func uploadImagesToCDN(ipCdn string, files []*multipart.FileHeader, clientID, au
 
 	return res.Data, nil
 }
+
+// bindAssetQuery reads the filter, search and sort parameters of the asset list endpoints
+func bindAssetQuery(context *gin.Context) (request.AssetQueryRequest, error) {
+	var assetQuery request.AssetQueryRequest
+	if err := context.ShouldBindQuery(&assetQuery); err != nil {
+		return assetQuery, err
+	}
+
+	tagIDs, err := utils.ParseUintList(context.Query("tag_ids"))
+	if err != nil {
+		return assetQuery, err
+	}
+	assetQuery.TagIDs = tagIDs
+	assetQuery.CustomFields = context.QueryMap("custom_fields")
+
+	if err := assetQuery.Validate(); err != nil {
+		return assetQuery, err
+	}
+	return assetQuery, nil
+}
@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type AssetDashboardController interface {
+	GetDashboard(context *gin.Context)
+	GetAssetGroupDashboard(context *gin.Context)
+}
+
+type assetDashboardController struct {
+	AssetDashboardService assets.AssetDashboardService
+	JWTService            jwt.Service
+}
+
+func NewAssetDashboardController(assetDashboardService assets.AssetDashboardService, jwtService jwt.Service) AssetDashboardController {
+	return assetDashboardController{AssetDashboardService: assetDashboardService, JWTService: jwtService}
+}
+
+func (h assetDashboardController) GetDashboard(context *gin.Context) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	days, err := strconv.Atoi(context.DefaultQuery("days", "0"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Days must be a number", nil, err.Error())
+		return
+	}
+
+	dashboard, err := h.AssetDashboardService.GetDashboard(days, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to get dashboard", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset dashboard", dashboard, nil)
+}
+
+func (h assetDashboardController) GetAssetGroupDashboard(context *gin.Context) {
+	assetGroupID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset group ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	days, err := strconv.Atoi(context.DefaultQuery("days", "0"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Days must be a number", nil, err.Error())
+		return
+	}
+
+	dashboard, err := h.AssetDashboardService.GetAssetGroupDashboard(assetGroupID, days, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to get asset group dashboard", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset group dashboard", dashboard, nil)
+}
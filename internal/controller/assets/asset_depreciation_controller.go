@@ -0,0 +1,44 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AssetDepreciationController interface {
+	GetAssetDepreciation(context *gin.Context)
+}
+
+type assetDepreciationController struct {
+	AssetDepreciationService assets.AssetDepreciationService
+	JWTService               jwt.Service
+}
+
+func NewAssetDepreciationController(assetDepreciationService assets.AssetDepreciationService, jwtService jwt.Service) AssetDepreciationController {
+	return assetDepreciationController{AssetDepreciationService: assetDepreciationService, JWTService: jwtService}
+}
+
+func (h assetDepreciationController) GetAssetDepreciation(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	result, err := h.AssetDepreciationService.GetAssetDepreciation(assetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to get asset depreciation", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset depreciation schedule", result, nil)
+}
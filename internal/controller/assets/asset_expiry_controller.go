@@ -0,0 +1,68 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// maxExpiringWithinDays bounds the look-ahead window of the expiring list
+const maxExpiringWithinDays = 365
+
+type AssetExpiryController interface {
+	GetExpiringAssets(context *gin.Context)
+}
+
+type assetExpiryController struct {
+	AssetExpiryService assets.AssetExpiryService
+	JWTService         jwt.Service
+}
+
+func NewAssetExpiryController(assetExpiryService assets.AssetExpiryService, jwtService jwt.Service) AssetExpiryController {
+	return assetExpiryController{AssetExpiryService: assetExpiryService, JWTService: jwtService}
+}
+
+func (h assetExpiryController) GetExpiringAssets(context *gin.Context) {
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	withinDays, err := utils.ParseDayWindow(context.DefaultQuery("within", "30d"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid within value", nil, err.Error())
+		return
+	}
+	if withinDays > maxExpiringWithinDays {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid within value", nil, "within must not exceed 365 days")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	expiring, total, err := h.AssetExpiryService.GetExpiringAssets(withinDays, pageIndex, pageSize, token.ClientID)
+	if err != nil {
+		response.SendResponseList(context, 500, "Failed to get expiring assets", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, 200, "List of expiring assets", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     expiring,
+	}, nil)
+}
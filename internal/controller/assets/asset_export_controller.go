@@ -0,0 +1,91 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/internal/utils/sheet"
+	"asset-service/package/response"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AssetExportController interface {
+	ExportAssets(context *gin.Context)
+	ExportMaintenances(context *gin.Context)
+	ExportMaintenanceRecords(context *gin.Context)
+}
+
+type assetExportController struct {
+	AssetExportService assets.AssetExportService
+	JWTService         jwt.Service
+}
+
+func NewAssetExportController(assetExportService assets.AssetExportService, jwtService jwt.Service) AssetExportController {
+	return assetExportController{AssetExportService: assetExportService, JWTService: jwtService}
+}
+
+func (h assetExportController) ExportAssets(context *gin.Context) {
+	h.export(context, "assets", h.AssetExportService.ExportAssets)
+}
+
+func (h assetExportController) ExportMaintenances(context *gin.Context) {
+	h.export(context, "asset-maintenances", h.AssetExportService.ExportMaintenances)
+}
+
+func (h assetExportController) ExportMaintenanceRecords(context *gin.Context) {
+	h.export(context, "asset-maintenance-records", h.AssetExportService.ExportMaintenanceRecords)
+}
+
+type prepareExport func(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (assets.ExportFunc, error)
+
+func (h assetExportController) export(context *gin.Context, name string, prepare prepareExport) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	format := strings.ToLower(context.DefaultQuery("format", sheet.FormatCSV))
+	if format != sheet.FormatCSV && format != sheet.FormatXLSX && format != sheet.FormatJSON {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "format must be csv, xlsx or json")
+		return
+	}
+
+	var assetGroupID uint
+	if value := context.Query("asset_group_id"); value != "" {
+		id, err := utils.ConvertToUint(value)
+		if err != nil {
+			response.SendResponse(context, http.StatusBadRequest, "Asset group ID must be a number", nil, err.Error())
+			return
+		}
+		assetGroupID = id
+	}
+
+	assetQuery, err := bindAssetQuery(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid query", nil, err.Error())
+		return
+	}
+
+	write, err := prepare(format, assetGroupID, assetQuery, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	context.Header("Content-Type", sheet.ContentType(format))
+	context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	context.Status(http.StatusOK)
+
+	// the status line is already sent, a failure midway can only be logged and ends the stream early
+	if err := write(context.Writer); err != nil {
+		log.Error().Str("clientID", token.ClientID).Str("export", name).Err(err).Msg("Export aborted")
+	}
+}
@@ -58,7 +58,7 @@ func (a assetGroupController) AddAssetGroup(context *gin.Context) {
 		return
 	}
 
-	data, err := a.AssetGroupService.AddAssetGroup(&req, token.ClientID, credentialKey)
+	data, err := a.AssetGroupService.AddAssetGroup(context.Request.Context(), &req, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
 		return
@@ -75,7 +75,7 @@ func (a assetGroupController) AddInvitationTokenAssetGroup(context *gin.Context)
 		return
 	}
 
-	data, err := a.AssetGroupService.AddInvitationAssetGroup(assetGroupID, token.ClientID)
+	data, err := a.AssetGroupService.AddInvitationAssetGroup(context.Request.Context(), assetGroupID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -92,7 +92,7 @@ func (a assetGroupController) RemoveInvitationTokenAssetGroup(context *gin.Conte
 		return
 	}
 
-	err = a.AssetGroupService.RemoveInvitationAssetGroup(assetGroupID, token.ClientID)
+	err = a.AssetGroupService.RemoveInvitationAssetGroup(context.Request.Context(), assetGroupID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -126,7 +126,7 @@ func (a assetGroupController) UpdateAssetGroup(context *gin.Context) {
 		return
 	}
 
-	data, err := a.AssetGroupService.UpdateAssetGroup(assetGroupID, &req, token.ClientID, credentialKey)
+	data, err := a.AssetGroupService.UpdateAssetGroup(context.Request.Context(), assetGroupID, &req, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -170,7 +170,7 @@ func (a assetGroupController) DeleteAssetGroup(context *gin.Context) {
 		return
 	}
 
-	err = a.AssetGroupService.DeleteAssetGroup(assetGroupID, token.ClientID, credentialKey)
+	err = a.AssetGroupService.DeleteAssetGroup(context.Request.Context(), assetGroupID, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -192,7 +192,7 @@ func (a assetGroupController) InviteMemberAssetGroup(context *gin.Context) {
 		return
 	}
 
-	err := a.AssetGroupService.InviteMemberAssetGroup(&req, token.ClientID)
+	err := a.AssetGroupService.InviteMemberAssetGroup(context.Request.Context(), &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -214,7 +214,7 @@ func (a assetGroupController) RemoveMemberAssetGroup(context *gin.Context) {
 		return
 	}
 
-	err := a.AssetGroupService.RemoveMemberAssetGroup(req, token.ClientID)
+	err := a.AssetGroupService.RemoveMemberAssetGroup(context.Request.Context(), req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -236,7 +236,7 @@ func (a assetGroupController) AddPermissionMemberAssetGroup(context *gin.Context
 		return
 	}
 
-	err := a.AssetGroupService.AddPermissionMemberAssetGroup(&req, token.ClientID)
+	err := a.AssetGroupService.AddPermissionMemberAssetGroup(context.Request.Context(), &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -258,7 +258,7 @@ func (a assetGroupController) RemovePermissionMemberAssetGroup(context *gin.Cont
 		return
 	}
 
-	err := a.AssetGroupService.RemovePermissionMemberAssetGroup(&req, token.ClientID)
+	err := a.AssetGroupService.RemovePermissionMemberAssetGroup(context.Request.Context(), &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -285,7 +285,13 @@ func (a assetGroupController) GetListAssetGroupAsset(context *gin.Context) {
 		return
 	}
 
-	data, total, err := a.AssetGroupService.GetListAssetGroupAsset(assetGroupID, pageIndex, pageSize, token.ClientID)
+	assetQuery, err := bindAssetQuery(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid asset query", nil, err.Error())
+		return
+	}
+
+	data, total, err := a.AssetGroupService.GetListAssetGroupAsset(assetGroupID, pageIndex, pageSize, assetQuery, token.ClientID)
 	if err != nil {
 		response.SendResponseList(context, 500, "Failed to get list assets", response.PagedData{
 			Total:     total,
@@ -315,7 +321,7 @@ func (a assetGroupController) AddStockAssetGroupAsset(context *gin.Context) {
 		return
 	}
 
-	data, err := a.AssetGroupService.UpdateStockAssetGroupAsset(true, req, token.ClientID)
+	data, err := a.AssetGroupService.UpdateStockAssetGroupAsset(context.Request.Context(), true, req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update stock asset", nil, err.Error())
 		return
@@ -337,7 +343,7 @@ func (a assetGroupController) ReduceStockAssetGroupAsset(context *gin.Context) {
 		return
 	}
 
-	data, err := a.AssetGroupService.UpdateStockAssetGroupAsset(false, req, token.ClientID)
+	data, err := a.AssetGroupService.UpdateStockAssetGroupAsset(context.Request.Context(), false, req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update stock asset", nil, err.Error())
 		return
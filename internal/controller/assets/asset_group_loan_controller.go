@@ -0,0 +1,129 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type AssetGroupLoanController interface {
+	CheckOutAsset(context *gin.Context)
+	ReturnAsset(context *gin.Context)
+	GetListLoan(context *gin.Context)
+	GetListOverdueLoan(context *gin.Context)
+}
+
+type assetGroupLoanController struct {
+	AssetGroupLoanService assets.AssetGroupLoanService
+	JWTService            jwt.Service
+}
+
+func NewAssetGroupLoanController(assetGroupLoanService assets.AssetGroupLoanService, jwtService jwt.Service) AssetGroupLoanController {
+	return assetGroupLoanController{AssetGroupLoanService: assetGroupLoanService, JWTService: jwtService}
+}
+
+func (h assetGroupLoanController) CheckOutAsset(context *gin.Context) {
+	var req request.AssetLoanCheckOutRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	data, err := h.AssetGroupLoanService.CheckOutAsset(context.Request.Context(), &req, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to check out asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset checked out successfully", data, nil)
+}
+
+func (h assetGroupLoanController) ReturnAsset(context *gin.Context) {
+	loanID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Loan ID must be a number", nil, err.Error())
+		return
+	}
+
+	var req request.AssetLoanReturnRequest
+	if context.Request.ContentLength > 0 {
+		if err := context.ShouldBindJSON(&req); err != nil {
+			response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+			return
+		}
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	data, err := h.AssetGroupLoanService.ReturnAsset(context.Request.Context(), loanID, &req, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to return asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset returned successfully", data, nil)
+}
+
+func (h assetGroupLoanController) GetListLoan(context *gin.Context) {
+	status := strings.ToLower(strings.TrimSpace(context.Query("status")))
+	if status != "" && status != utils.LoanStatusOpen && status != utils.LoanStatusReturned && status != utils.LoanStatusOverdue {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid status", nil, "status must be open, returned or overdue")
+		return
+	}
+	h.listLoan(context, status, "List of asset loans")
+}
+
+func (h assetGroupLoanController) GetListOverdueLoan(context *gin.Context) {
+	h.listLoan(context, utils.LoanStatusOverdue, "List of overdue asset loans")
+}
+
+func (h assetGroupLoanController) listLoan(context *gin.Context, status string, message string) {
+	assetGroupID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset group ID must be a number", nil, err.Error())
+		return
+	}
+
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	loans, total, err := h.AssetGroupLoanService.GetListLoan(assetGroupID, status, pageIndex, pageSize, token.ClientID)
+	if err != nil {
+		response.SendResponseList(context, http.StatusInternalServerError, "Failed to get asset loans", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, http.StatusOK, message, response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     loans,
+	}, nil)
+}
@@ -39,7 +39,7 @@ func (a assetGroupMemberController) InviteMemberAssetGroup(context *gin.Context)
 		return
 	}
 
-	err := a.AssetGroupMemberService.AddAssetGroupMember(&req, token.ClientID)
+	err := a.AssetGroupMemberService.AddAssetGroupMember(context.Request.Context(), &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -61,7 +61,7 @@ func (a assetGroupMemberController) RemoveMemberAssetGroup(context *gin.Context)
 		return
 	}
 
-	err := a.AssetGroupMemberService.RemoveMemberAssetGroup(req, token.ClientID)
+	err := a.AssetGroupMemberService.RemoveMemberAssetGroup(context.Request.Context(), req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -116,7 +116,7 @@ func (a assetGroupMemberController) LeaveMemberAssetGroup(context *gin.Context)
 		return
 	}
 
-	err = a.AssetGroupMemberService.LeaveMemberAssetGroup(assetGroupID, token.ClientID)
+	err = a.AssetGroupMemberService.LeaveMemberAssetGroup(context.Request.Context(), assetGroupID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -41,7 +41,7 @@ func (a assetGroupPermissionController) AddAssetGroupPermission(context *gin.Con
 		return
 	}
 
-	err := a.AssetGroupPermissionService.AddAssetGroupPermission(&req, token.ClientID)
+	err := a.AssetGroupPermissionService.AddAssetGroupPermission(context.Request.Context(), &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -68,7 +68,7 @@ func (a assetGroupPermissionController) UpdateAssetGroupPermission(context *gin.
 		return
 	}
 
-	err = a.AssetGroupPermissionService.UpdateAssetGroupPermission(id, &req, token.ClientID)
+	err = a.AssetGroupPermissionService.UpdateAssetGroupPermission(context.Request.Context(), id, &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
@@ -128,7 +128,7 @@ func (a assetGroupPermissionController) DeleteAssetGroupPermission(context *gin.
 		return
 	}
 
-	err = a.AssetGroupPermissionService.DeleteAssetGroupPermission(id, token.ClientID)
+	err = a.AssetGroupPermissionService.DeleteAssetGroupPermission(context.Request.Context(), id, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, http.StatusInternalServerError, "Error", err.Error(), err)
 		return
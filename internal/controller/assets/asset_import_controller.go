@@ -0,0 +1,93 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/internal/utils/sheet"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// maxImportFileSize limits the uploaded import file to 10 MB
+const maxImportFileSize = 10 << 20
+
+type AssetImportController interface {
+	ImportAssets(context *gin.Context)
+}
+
+type assetImportController struct {
+	AssetImportService assets.AssetImportService
+	JWTService         jwt.Service
+}
+
+func NewAssetImportController(assetImportService assets.AssetImportService, jwtService jwt.Service) AssetImportController {
+	return assetImportController{AssetImportService: assetImportService, JWTService: jwtService}
+}
+
+func (h assetImportController) ImportAssets(context *gin.Context) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	dryRun := false
+	if value := context.DefaultQuery("dry_run", context.PostForm("dry_run")); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.SendResponse(context, http.StatusBadRequest, "dry_run must be true or false", nil, err.Error())
+			return
+		}
+		dryRun = parsed
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" && !dryRun {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	fileHeader, err := context.FormFile("file")
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Import file is required", nil, err.Error())
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "import file must not exceed 10 MB")
+		return
+	}
+
+	format, err := sheet.FormatFromFilename(fileHeader.Filename)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to open import file", nil, err.Error())
+		return
+	}
+	defer file.Close()
+
+	result, err := h.AssetImportService.ImportAssets(context.Request.Context(), file, format, dryRun, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		response.SendResponse(context, http.StatusUnprocessableEntity, "Import file has invalid rows, nothing was imported", result, nil)
+		return
+	}
+
+	if dryRun {
+		response.SendResponse(context, http.StatusOK, "Import file is valid", result, nil)
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Assets imported successfully", result, nil)
+}
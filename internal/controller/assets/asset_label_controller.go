@@ -0,0 +1,91 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/internal/utils/label"
+	"asset-service/package/response"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type AssetLabelController interface {
+	GetAssetLabel(context *gin.Context)
+	GetAssetLabelSheet(context *gin.Context)
+}
+
+type assetLabelController struct {
+	AssetLabelService assets.AssetLabelService
+	JWTService        jwt.Service
+}
+
+func NewAssetLabelController(assetLabelService assets.AssetLabelService, jwtService jwt.Service) AssetLabelController {
+	return assetLabelController{AssetLabelService: assetLabelService, JWTService: jwtService}
+}
+
+func (h assetLabelController) GetAssetLabel(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	labelType := context.DefaultQuery("type", label.TypeQR)
+	if labelType != label.TypeQR && labelType != label.TypeBarcode {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "type must be qr or barcode")
+		return
+	}
+
+	format := context.DefaultQuery("format", label.FormatPNG)
+	if format != label.FormatPNG && format != label.FormatSVG {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "format must be png or svg")
+		return
+	}
+
+	size, err := strconv.Atoi(context.DefaultQuery("size", "256"))
+	if err != nil || size < 64 || size > 2048 {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "size must be between 64 and 2048")
+		return
+	}
+
+	image, contentType, err := h.AssetLabelService.GetAssetLabel(assetID, labelType, format, size, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	context.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"asset-%d-%s.%s\"", assetID, labelType, format))
+	context.Data(http.StatusOK, contentType, image)
+}
+
+func (h assetLabelController) GetAssetLabelSheet(context *gin.Context) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	assetQuery, err := bindAssetQuery(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid asset query", nil, err.Error())
+		return
+	}
+
+	sheet, err := h.AssetLabelService.GetAssetLabelSheet(assetQuery, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	context.Header("Content-Disposition", "attachment; filename=\"asset-labels.pdf\"")
+	context.Data(http.StatusOK, label.ContentTypePDF, sheet)
+}
@@ -0,0 +1,231 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AssetLocationController interface {
+	AddLocation(context *gin.Context)
+	UpdateLocation(context *gin.Context)
+	GetLocationTree(context *gin.Context)
+	GetLocationByID(context *gin.Context)
+	DeleteLocation(context *gin.Context)
+	MoveAsset(context *gin.Context)
+	GetListLocationHistory(context *gin.Context)
+}
+
+type assetLocationController struct {
+	AssetLocationService assets.AssetLocationService
+	JWTService           jwt.Service
+}
+
+func NewAssetLocationController(assetLocationService assets.AssetLocationService, jwtService jwt.Service) AssetLocationController {
+	return assetLocationController{AssetLocationService: assetLocationService, JWTService: jwtService}
+}
+
+func (h assetLocationController) AddLocation(context *gin.Context) {
+	var req request.AssetLocationRequest
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	location, err := h.AssetLocationService.AddLocation(context.Request.Context(), &req, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset location added successfully", location, nil)
+}
+
+func (h assetLocationController) UpdateLocation(context *gin.Context) {
+	var req request.AssetLocationRequest
+	locationID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Location ID must be a number", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	location, err := h.AssetLocationService.UpdateLocation(context.Request.Context(), locationID, &req, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset location updated successfully", location, nil)
+}
+
+func (h assetLocationController) GetLocationTree(context *gin.Context) {
+	assetGroupID, err := optionalAssetGroupID(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset group ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	locations, err := h.AssetLocationService.GetLocationTree(assetGroupID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "List of asset locations", locations, nil)
+}
+
+func (h assetLocationController) GetLocationByID(context *gin.Context) {
+	locationID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Location ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	location, err := h.AssetLocationService.GetLocationByID(locationID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset location", location, nil)
+}
+
+func (h assetLocationController) DeleteLocation(context *gin.Context) {
+	locationID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Location ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := h.AssetLocationService.DeleteLocation(context.Request.Context(), locationID, token.ClientID); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset location deleted successfully", nil, nil)
+}
+
+func (h assetLocationController) MoveAsset(context *gin.Context) {
+	var req request.AssetMoveRequest
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	history, err := h.AssetLocationService.MoveAsset(context.Request.Context(), assetID, &req, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to move asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset moved successfully", history, nil)
+}
+
+func (h assetLocationController) GetListLocationHistory(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	assetGroupID, err := optionalAssetGroupID(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset group ID must be a number", nil, err.Error())
+		return
+	}
+
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	history, total, err := h.AssetLocationService.GetListLocationHistory(assetID, assetGroupID, pageIndex, pageSize, token.ClientID)
+	if err != nil {
+		response.SendResponseList(context, http.StatusInternalServerError, "Failed to get asset location history", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, http.StatusOK, "Asset location history", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     history,
+	}, nil)
+}
+
+// optionalAssetGroupID reads the asset_group_id query parameter, 0 when it is missing
+func optionalAssetGroupID(context *gin.Context) (uint, error) {
+	if context.Query("asset_group_id") == "" {
+		return 0, nil
+	}
+	return utils.ConvertToUint(context.Query("asset_group_id"))
+}
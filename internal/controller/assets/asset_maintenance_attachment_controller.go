@@ -0,0 +1,154 @@
+package assets
+
+import (
+	responses "asset-service/internal/dto/out/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path/filepath"
+)
+
+type AssetMaintenanceAttachmentController interface {
+	AddMaintenanceAttachments(context *gin.Context)
+	AddRecordAttachments(context *gin.Context)
+	GetMaintenanceAttachments(context *gin.Context)
+	DeleteAttachment(context *gin.Context)
+}
+
+type assetMaintenanceAttachmentController struct {
+	AttachmentService assets.AssetMaintenanceAttachmentService
+	JWTService        jwt.Service
+	IpCDN             string
+}
+
+func NewAssetMaintenanceAttachmentController(attachmentService assets.AssetMaintenanceAttachmentService, jwtService jwt.Service, ipCDN string) AssetMaintenanceAttachmentController {
+	return assetMaintenanceAttachmentController{AttachmentService: attachmentService, JWTService: jwtService, IpCDN: ipCDN}
+}
+
+func (h assetMaintenanceAttachmentController) AddMaintenanceAttachments(context *gin.Context) {
+	maintenanceID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Maintenance ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	files, ok := h.upload(context, token.ClientID)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.AttachmentService.AddMaintenanceAttachments(context.Request.Context(), maintenanceID, files, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to add attachments", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusCreated, "Attachments added successfully", attachments, nil)
+}
+
+func (h assetMaintenanceAttachmentController) AddRecordAttachments(context *gin.Context) {
+	maintenanceRecordID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Maintenance record ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	files, ok := h.upload(context, token.ClientID)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.AttachmentService.AddRecordAttachments(context.Request.Context(), maintenanceRecordID, files, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to add attachments", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusCreated, "Attachments added successfully", attachments, nil)
+}
+
+func (h assetMaintenanceAttachmentController) GetMaintenanceAttachments(context *gin.Context) {
+	maintenanceID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Maintenance ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	attachments, err := h.AttachmentService.GetMaintenanceAttachments(maintenanceID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "List of maintenance attachments", attachments, nil)
+}
+
+func (h assetMaintenanceAttachmentController) DeleteAttachment(context *gin.Context) {
+	attachmentID, err := utils.ConvertToUint(context.Param("attachment_id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Attachment ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err = h.AttachmentService.DeleteAttachment(context.Request.Context(), attachmentID, token.ClientID); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to delete attachment", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Attachment deleted successfully", nil, nil)
+}
+
+// upload sends the "attachments" files of the multipart form to the CDN the same way asset images are,
+// the response has already been written when it reports false
+func (h assetMaintenanceAttachmentController) upload(context *gin.Context, clientID string) ([]responses.AssetMaintenanceAttachmentResponse, bool) {
+	if err := context.Request.ParseMultipartForm(10 << 20); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error parsing form", nil, err.Error())
+		return nil, false
+	}
+
+	files := context.Request.MultipartForm.File["attachments"]
+	if len(files) == 0 {
+		response.SendResponse(context, http.StatusBadRequest, "No files uploaded", nil, "At least one attachment is required")
+		return nil, false
+	}
+
+	uploaded, err := uploadImagesToCDN(h.IpCDN, files, clientID, context.GetHeader(utils.Authorization))
+	if err != nil {
+		response.SendResponse(context, http.StatusInternalServerError, "Failed to upload attachments", nil, err.Error())
+		return nil, false
+	}
+
+	// the CDN answers in upload order, its stored name is the fallback should the counts ever differ
+	attachments := make([]responses.AssetMaintenanceAttachmentResponse, 0, len(uploaded))
+	for i, file := range uploaded {
+		name := filepath.Base(file.ImageURL)
+		if len(uploaded) == len(files) {
+			name = files[i].Filename
+		}
+		attachments = append(attachments, responses.AssetMaintenanceAttachmentResponse{FileURL: file.ImageURL, FileName: name})
+	}
+	return attachments, true
+}
@@ -49,7 +49,7 @@ func (c assetMaintenanceController) AddAssetMaintenance(ctx *gin.Context) {
 		return
 	}
 
-	maintenances, err := c.Service.AddAssetMaintenance(maintenance, token.ClientID, credentialKey)
+	maintenances, err := c.Service.AddAssetMaintenance(ctx.Request.Context(), maintenance, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(ctx, http.StatusInternalServerError, "Failed to create maintenance record", nil, err.Error())
 		return
@@ -124,7 +124,7 @@ func (c assetMaintenanceController) PerformMaintenance(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.Service.PerformMaintenance(maintenance, token.ClientID)
+	result, err := c.Service.PerformMaintenance(ctx.Request.Context(), maintenance, token.ClientID)
 	if err != nil {
 		response.SendResponse(ctx, http.StatusInternalServerError, "Failed to perform maintenance", nil, err.Error())
 		return
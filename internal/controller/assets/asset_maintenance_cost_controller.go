@@ -0,0 +1,96 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	responses "asset-service/internal/dto/out/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils/jwt"
+	"asset-service/internal/utils/sheet"
+	"asset-service/package/response"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AssetMaintenanceCostController interface {
+	GetCostSummary(context *gin.Context)
+	GetCostSeries(context *gin.Context)
+}
+
+type assetMaintenanceCostController struct {
+	AssetMaintenanceCostService assets.AssetMaintenanceCostService
+	JWTService                  jwt.Service
+}
+
+func NewAssetMaintenanceCostController(assetMaintenanceCostService assets.AssetMaintenanceCostService, jwtService jwt.Service) AssetMaintenanceCostController {
+	return assetMaintenanceCostController{AssetMaintenanceCostService: assetMaintenanceCostService, JWTService: jwtService}
+}
+
+func (h assetMaintenanceCostController) GetCostSummary(context *gin.Context) {
+	h.report(context, "maintenance-cost-summary", h.AssetMaintenanceCostService.GetCostSummary)
+}
+
+func (h assetMaintenanceCostController) GetCostSeries(context *gin.Context) {
+	h.report(context, "maintenance-cost-series", h.AssetMaintenanceCostService.GetCostSeries)
+}
+
+type costReport func(costQuery request.MaintenanceCostQueryRequest, clientID string) (*responses.MaintenanceCostReportResponse, error)
+
+// report answers with the usual JSON envelope, or with a csv or xlsx file when format asks for one
+func (h assetMaintenanceCostController) report(context *gin.Context, name string, build costReport) {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	format := strings.ToLower(context.DefaultQuery("format", sheet.FormatJSON))
+	if format != sheet.FormatCSV && format != sheet.FormatXLSX && format != sheet.FormatJSON {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "format must be csv, xlsx or json")
+		return
+	}
+
+	var costQuery request.MaintenanceCostQueryRequest
+	if err := context.ShouldBindQuery(&costQuery); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid query", nil, err.Error())
+		return
+	}
+	if err := costQuery.Validate(); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid query", nil, err.Error())
+		return
+	}
+
+	result, err := build(costQuery, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to get maintenance costs", nil, err.Error())
+		return
+	}
+
+	if format == sheet.FormatJSON {
+		response.SendResponse(context, http.StatusOK, "Maintenance cost report", result, nil)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	context.Header("Content-Type", sheet.ContentType(format))
+	context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	context.Status(http.StatusOK)
+
+	writer, err := sheet.NewWriter(context.Writer, format, responses.MaintenanceCostHeader)
+	if err == nil {
+		for _, row := range result.Rows {
+			if err = writer.Write(row.Values(), row); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		log.Error().Str("clientID", token.ClientID).Str("report", name).Err(err).Msg("Report aborted")
+	}
+}
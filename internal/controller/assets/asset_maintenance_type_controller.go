@@ -6,6 +6,7 @@ import (
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/jwt"
 	"asset-service/package/response"
+	"context"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -14,6 +15,11 @@ type AssetMaintenanceTypeController interface {
 	CreateMaintenanceType(ctx *gin.Context)
 	GetMaintenanceByID(context *gin.Context)
 	GetListMaintenanceType(context *gin.Context)
+	UpdateMaintenanceType(context *gin.Context)
+	DeleteMaintenanceType(context *gin.Context)
+	CreateDefaultMaintenanceType(context *gin.Context)
+	UpdateDefaultMaintenanceType(context *gin.Context)
+	DeleteDefaultMaintenanceType(context *gin.Context)
 }
 
 type assetMaintenanceTypeController struct {
@@ -44,7 +50,7 @@ func (c assetMaintenanceTypeController) CreateMaintenanceType(ctx *gin.Context)
 		return
 	}
 
-	maintenances, err := c.Service.AddMaintenanceType(maintenance, token.ClientID, credentialKey)
+	maintenances, err := c.Service.AddMaintenanceType(ctx.Request.Context(), maintenance, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(ctx, http.StatusInternalServerError, "Failed to create maintenance record", nil, err.Error())
 		return
@@ -106,3 +112,104 @@ func (c assetMaintenanceTypeController) GetListMaintenanceType(context *gin.Cont
 	}
 	response.SendResponse(context, http.StatusOK, "Success", maintenanceTypes, nil)
 }
+
+func (c assetMaintenanceTypeController) UpdateMaintenanceType(context *gin.Context) {
+	c.update(context, c.Service.UpdateMaintenanceType)
+}
+
+func (c assetMaintenanceTypeController) DeleteMaintenanceType(context *gin.Context) {
+	c.remove(context, c.Service.DeleteMaintenanceType)
+}
+
+func (c assetMaintenanceTypeController) CreateDefaultMaintenanceType(context *gin.Context) {
+	var req request.AssetMaintenanceTypeRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	maintenanceType, err := c.Service.AddDefaultMaintenanceType(context.Request.Context(), &req, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to create default maintenance type", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusCreated, "Default maintenance type created successfully", maintenanceType, nil)
+}
+
+func (c assetMaintenanceTypeController) UpdateDefaultMaintenanceType(context *gin.Context) {
+	c.update(context, c.Service.UpdateDefaultMaintenanceType)
+}
+
+func (c assetMaintenanceTypeController) DeleteDefaultMaintenanceType(context *gin.Context) {
+	c.remove(context, c.Service.DeleteDefaultMaintenanceType)
+}
+
+func (c assetMaintenanceTypeController) update(context *gin.Context, update func(context.Context, uint, string, *request.AssetMaintenanceTypeRequest) (interface{}, error)) {
+	var req request.AssetMaintenanceTypeRequest
+	maintenanceTypeID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Maintenance type ID must be a number", nil, err.Error())
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	maintenanceType, err := update(context.Request.Context(), maintenanceTypeID, token.ClientID, &req)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to update maintenance type", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Maintenance type updated successfully", maintenanceType, nil)
+}
+
+// remove deletes a maintenance type, the reassign_to query parameter moves the maintenance schedules still using it
+func (c assetMaintenanceTypeController) remove(context *gin.Context, deleteType func(context.Context, uint, string, *uint) error) {
+	maintenanceTypeID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Maintenance type ID must be a number", nil, err.Error())
+		return
+	}
+
+	var reassignTo *uint
+	if context.Query("reassign_to") != "" {
+		id, err := utils.ConvertToUint(context.Query("reassign_to"))
+		if err != nil {
+			response.SendResponse(context, http.StatusBadRequest, "reassign_to must be a number", nil, err.Error())
+			return
+		}
+		reassignTo = &id
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err = deleteType(context.Request.Context(), maintenanceTypeID, token.ClientID, reassignTo); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to delete maintenance type", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Maintenance type deleted successfully", nil, nil)
+}
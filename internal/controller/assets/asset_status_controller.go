@@ -46,7 +46,7 @@ func (h assetStatusController) AddAssetStatus(context *gin.Context) {
 		return
 	}
 
-	assetStatus, err := h.AssetStatusService.AddAssetStatus(&req, token.ClientID, credentialKey)
+	assetStatus, err := h.AssetStatusService.AddAssetStatus(context.Request.Context(), &req, token.ClientID, credentialKey)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to add assets status", nil, err)
 		return
@@ -120,7 +120,7 @@ func (h assetStatusController) UpdateAssetStatus(context *gin.Context) {
 		return
 	}
 
-	assetStatus, err := h.AssetStatusService.UpdateAssetStatus(assetStatusID, &req, token.ClientID)
+	assetStatus, err := h.AssetStatusService.UpdateAssetStatus(context.Request.Context(), assetStatusID, &req, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to update assets status", nil, err)
 		return
@@ -141,7 +141,7 @@ func (h assetStatusController) DeleteAssetStatus(context *gin.Context) {
 		return
 	}
 
-	err = h.AssetStatusService.DeleteAssetStatus(assetStatusID, token.ClientID)
+	err = h.AssetStatusService.DeleteAssetStatus(context.Request.Context(), assetStatusID, token.ClientID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to delete assets status", nil, err)
 		return
@@ -0,0 +1,240 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AssetTagController interface {
+	AddAssetTag(context *gin.Context)
+	UpdateAssetTag(context *gin.Context)
+	GetListAssetTag(context *gin.Context)
+	GetAssetTagByID(context *gin.Context)
+	DeleteAssetTag(context *gin.Context)
+	GetAssetTags(context *gin.Context)
+	AttachAssetTags(context *gin.Context)
+	DetachAssetTag(context *gin.Context)
+}
+
+type assetTagController struct {
+	AssetTagService assets.AssetTagService
+	JWTService      jwt.Service
+}
+
+func NewAssetTagController(assetTagService assets.AssetTagService, jwtService jwt.Service) AssetTagController {
+	return assetTagController{AssetTagService: assetTagService, JWTService: jwtService}
+}
+
+func (h assetTagController) AddAssetTag(context *gin.Context) {
+	var req request.AssetTagRequest
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	assetTag, err := h.AssetTagService.AddAssetTag(context.Request.Context(), &req, credentialKey, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tag added successfully", assetTag, nil)
+}
+
+func (h assetTagController) UpdateAssetTag(context *gin.Context) {
+	var req request.AssetTagRequest
+	tagID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Tag ID must be a number", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	assetTag, err := h.AssetTagService.UpdateAssetTag(context.Request.Context(), tagID, &req, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tag updated successfully", assetTag, nil)
+}
+
+func (h assetTagController) GetListAssetTag(context *gin.Context) {
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	assetTags, total, err := h.AssetTagService.GetListAssetTag(token.ClientID, pageSize, pageIndex)
+	if err != nil {
+		response.SendResponseList(context, 500, "Failed to get list asset tags", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, 200, "List of asset tags", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     assetTags,
+	}, nil)
+}
+
+func (h assetTagController) GetAssetTagByID(context *gin.Context) {
+	tagID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Tag ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	assetTag, err := h.AssetTagService.GetAssetTagByID(tagID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tag retrieved successfully", assetTag, nil)
+}
+
+func (h assetTagController) DeleteAssetTag(context *gin.Context) {
+	tagID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Tag ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	err = h.AssetTagService.DeleteAssetTag(context.Request.Context(), tagID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tag deleted successfully", nil, nil)
+}
+
+func (h assetTagController) GetAssetTags(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	assetTags, err := h.AssetTagService.GetAssetTags(assetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tags retrieved successfully", assetTags, nil)
+}
+
+func (h assetTagController) AttachAssetTags(context *gin.Context) {
+	var req request.AssetTagMapRequest
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	assetTags, err := h.AssetTagService.AttachAssetTags(context.Request.Context(), assetID, &req, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tags attached successfully", assetTags, nil)
+}
+
+func (h assetTagController) DetachAssetTag(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Asset ID must be a number", nil, err.Error())
+		return
+	}
+
+	tagID, err := utils.ConvertToUint(context.Param("tag_id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Tag ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	err = h.AssetTagService.DetachAssetTag(context.Request.Context(), assetID, tagID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset tag detached successfully", nil, nil)
+}
@@ -0,0 +1,137 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type AssetTransferController interface {
+	InitiateTransfer(context *gin.Context)
+	AcceptTransfer(context *gin.Context)
+	DeclineTransfer(context *gin.Context)
+	CancelTransfer(context *gin.Context)
+	GetListTransfer(context *gin.Context)
+}
+
+type assetTransferController struct {
+	AssetTransferService assets.AssetTransferService
+	JWTService           jwt.Service
+}
+
+func NewAssetTransferController(assetTransferService assets.AssetTransferService, jwtService jwt.Service) AssetTransferController {
+	return assetTransferController{AssetTransferService: assetTransferService, JWTService: jwtService}
+}
+
+func (h assetTransferController) InitiateTransfer(context *gin.Context) {
+	var req request.AssetTransferRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	data, err := h.AssetTransferService.InitiateTransfer(context.Request.Context(), &req, token.ClientID, credentialKey)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to initiate asset transfer", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Asset transfer initiated successfully", data, nil)
+}
+
+func (h assetTransferController) AcceptTransfer(context *gin.Context) {
+	h.respond(context, h.AssetTransferService.AcceptTransfer, "Asset transfer accepted successfully")
+}
+
+func (h assetTransferController) DeclineTransfer(context *gin.Context) {
+	h.respond(context, h.AssetTransferService.DeclineTransfer, "Asset transfer declined successfully")
+}
+
+func (h assetTransferController) CancelTransfer(context *gin.Context) {
+	h.respond(context, h.AssetTransferService.CancelTransfer, "Asset transfer cancelled successfully")
+}
+
+func (h assetTransferController) GetListTransfer(context *gin.Context) {
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	direction := strings.ToLower(strings.TrimSpace(context.Query("direction")))
+	if direction != "" && direction != utils.TransferDirectionIncoming && direction != utils.TransferDirectionOutgoing {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid direction", nil, "direction must be incoming or outgoing")
+		return
+	}
+
+	status := strings.ToLower(strings.TrimSpace(context.Query("status")))
+	switch status {
+	case "", utils.TransferStatusPending, utils.TransferStatusAccepted, utils.TransferStatusDeclined, utils.TransferStatusCancelled:
+	default:
+		response.SendResponse(context, http.StatusBadRequest, "Invalid status", nil, "status must be pending, accepted, declined or cancelled")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	transfers, total, err := h.AssetTransferService.GetListTransfer(direction, status, pageIndex, pageSize, token.ClientID)
+	if err != nil {
+		response.SendResponseList(context, http.StatusInternalServerError, "Failed to get asset transfers", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, http.StatusOK, "List of asset transfers", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     transfers,
+	}, nil)
+}
+
+// respond runs one of the accept, decline or cancel actions on the transfer in the path
+func (h assetTransferController) respond(context *gin.Context, action func(ctx context.Context, transferID uint, clientID string) (interface{}, error), message string) {
+	transferID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Transfer ID must be a number", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	data, err := action(context.Request.Context(), transferID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Failed to update asset transfer", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, message, data, nil)
+}
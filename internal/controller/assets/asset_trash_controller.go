@@ -0,0 +1,104 @@
+package assets
+
+import (
+	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AssetTrashController interface {
+	GetListDeletedAsset(context *gin.Context)
+	RestoreAsset(context *gin.Context)
+	PurgeAsset(context *gin.Context)
+}
+
+type assetTrashController struct {
+	AssetTrashService assets.AssetTrashService
+	JWTService        jwt.Service
+}
+
+func NewAssetTrashController(assetTrashService assets.AssetTrashService, jwtService jwt.Service) AssetTrashController {
+	return assetTrashController{AssetTrashService: assetTrashService, JWTService: jwtService}
+}
+
+func (h assetTrashController) GetListDeletedAsset(context *gin.Context) {
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	deleted, total, err := h.AssetTrashService.GetListDeletedAsset(token.ClientID, pageIndex, pageSize)
+	if err != nil {
+		response.SendResponseList(context, 500, "Failed to get deleted assets", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, 200, "List of deleted assets", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     deleted,
+	}, nil)
+}
+
+func (h assetTrashController) RestoreAsset(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid asset ID", nil, err.Error())
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	asset, err := h.AssetTrashService.RestoreAsset(context.Request.Context(), assetID, token.ClientID)
+	if err != nil {
+		response.SendResponse(context, 500, "Failed to restore asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, 200, "Asset restored successfully", asset, nil)
+}
+
+func (h assetTrashController) PurgeAsset(context *gin.Context) {
+	assetID, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid asset ID", nil, err.Error())
+		return
+	}
+
+	credentialKey := context.GetHeader(utils.XCredentialKey)
+	if credentialKey == "" {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "credential key not found")
+		return
+	}
+
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, "Token not found")
+		return
+	}
+
+	if err = h.AssetTrashService.PurgeAsset(context.Request.Context(), assetID, token.ClientID, credentialKey); err != nil {
+		response.SendResponse(context, 500, "Failed to purge asset", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, 200, "Asset purged successfully", nil, nil)
+}
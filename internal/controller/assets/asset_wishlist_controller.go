@@ -44,7 +44,7 @@ func (h assetWishlistController) AddWishlistAsset(c *gin.Context) {
 		return
 	}
 
-	asset, err := h.AssetWishlistService.AddAssetWishlist(req, token.ClientID)
+	asset, err := h.AssetWishlistService.AddAssetWishlist(c.Request.Context(), req, token.ClientID)
 	if err != nil {
 		response.SendResponse(c, 500, "Failed to add assets", nil, err.Error())
 		return
@@ -129,7 +129,7 @@ func (h assetWishlistController) UpdateAssetWishlist(c *gin.Context) {
 		return
 	}
 
-	asset, err := h.AssetWishlistService.UpdateAssetWishlist(id, req, token.ClientID)
+	asset, err := h.AssetWishlistService.UpdateAssetWishlist(c.Request.Context(), id, req, token.ClientID)
 	if err != nil {
 		response.SendResponse(c, 500, "Failed to update assets", nil, err.Error())
 		return
@@ -150,7 +150,7 @@ func (h assetWishlistController) DeleteAssetWishlist(c *gin.Context) {
 		return
 	}
 
-	err = h.AssetWishlistService.DeleteAssetWishlist(id, token.ClientID)
+	err = h.AssetWishlistService.DeleteAssetWishlist(c.Request.Context(), id, token.ClientID)
 	if err != nil {
 		response.SendResponse(c, 500, "Failed to delete assets", nil, err.Error())
 		return
@@ -213,7 +213,7 @@ func (h assetWishlistController) AddAssetWishlistToAsset(context *gin.Context) {
 		return
 	}
 
-	result, err := h.AssetWishlistService.AddAssetWishlistToAsset(id, &req, imageMetadata, token.ClientID, requestHeaderID)
+	result, err := h.AssetWishlistService.AddAssetWishlistToAsset(context.Request.Context(), id, &req, imageMetadata, token.ClientID, requestHeaderID)
 	if err != nil {
 		response.SendResponse(context, 500, "Failed to add asset wishlist to asset", nil, err.Error())
 		return
@@ -0,0 +1,43 @@
+package assets
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// AuditLogActions lists the actions found in the audit log, MOVE and TRANSFER only on the older entries
+var AuditLogActions = []string{"CREATE", "UPDATE", "DELETE", "RESTORE", "MOVE", "TRANSFER"}
+
+// AuditLogQueryRequest describes the filters accepted by the audit log endpoints, date_to is inclusive
+type AuditLogQueryRequest struct {
+	TableName   string     `form:"table"`
+	Action      string     `form:"action"`
+	RecordID    string     `form:"record_id"` // primary key of the audited row, e.g. asset_id for the asset table
+	PerformedBy string     `form:"performed_by"`
+	DateFrom    *time.Time `form:"date_from" time_format:"2006-01-02"`
+	DateTo      *time.Time `form:"date_to" time_format:"2006-01-02"`
+}
+
+// Validate normalizes the filters and checks the action and the date range
+func (q *AuditLogQueryRequest) Validate() error {
+	q.TableName = strings.ToLower(strings.TrimSpace(q.TableName))
+	q.Action = strings.ToUpper(strings.TrimSpace(q.Action))
+	q.RecordID = strings.TrimSpace(q.RecordID)
+	q.PerformedBy = strings.TrimSpace(q.PerformedBy)
+
+	if q.Action != "" {
+		valid := false
+		for _, action := range AuditLogActions {
+			valid = valid || action == q.Action
+		}
+		if !valid {
+			return errors.New("action must be one of " + strings.Join(AuditLogActions, ", "))
+		}
+	}
+
+	if q.DateFrom != nil && q.DateTo != nil && q.DateFrom.After(*q.DateTo) {
+		return errors.New("date_from must not be after date_to")
+	}
+	return nil
+}
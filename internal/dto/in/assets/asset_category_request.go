@@ -1,6 +1,12 @@
 package assets
 
+import "asset-service/internal/utils/customfield"
+
 type AssetCategoryRequest struct {
-	CategoryName string `json:"category_name" validate:"required"`
-	Description  string `json:"description" validate:"optional"`
+	CategoryName       string               `json:"category_name" validate:"required"`
+	Description        string               `json:"description" validate:"optional"`
+	DepreciationMethod *string              `json:"depreciation_method" validate:"optional"`
+	UsefulLifeMonths   *int                 `json:"useful_life_months" validate:"optional"`
+	SalvageValue       *float64             `json:"salvage_value" validate:"optional"`
+	CustomFields       *[]customfield.Field `json:"custom_fields" validate:"optional"` // an update keeps the schema when unset
 }
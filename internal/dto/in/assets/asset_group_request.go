@@ -41,3 +41,15 @@ type AssetGroupMemberRequest struct {
 	UserID       uint `json:"user_id" validate:"required"`
 	AssetGroupID uint `json:"asset_group_id" validate:"required"`
 }
+
+type AssetLoanCheckOutRequest struct {
+	AssetGroupID      uint    `json:"asset_group_id" validate:"required"`
+	AssetID           uint    `json:"asset_id" validate:"required"`
+	BorrowerUserID    uint    `json:"borrower_user_id" validate:"optional"`
+	DueAt             string  `json:"due_at" validate:"required"`
+	CheckoutCondition *string `json:"checkout_condition" validate:"optional"`
+}
+
+type AssetLoanReturnRequest struct {
+	ReturnCondition *string `json:"return_condition" validate:"optional"`
+}
@@ -0,0 +1,15 @@
+package assets
+
+type AssetLocationRequest struct {
+	Name             string  `json:"name" binding:"required"`
+	LocationType     string  `json:"location_type" binding:"required"`
+	ParentLocationID *uint   `json:"parent_location_id"`
+	AssetGroupID     *uint   `json:"asset_group_id"` // set on create to add the location to an asset group, ignored on update
+	Description      *string `json:"description"`
+}
+
+// AssetMoveRequest moves an asset to a location, a missing location_id takes the asset out of its location
+type AssetMoveRequest struct {
+	LocationID *uint   `json:"location_id"`
+	Notes      *string `json:"notes"`
+}
@@ -0,0 +1,52 @@
+package assets
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	MaintenanceCostGroupByAsset    = "asset"
+	MaintenanceCostGroupByCategory = "category"
+	MaintenanceCostGroupByType     = "maintenance_type"
+
+	MaintenanceCostPeriodMonth   = "month"
+	MaintenanceCostPeriodQuarter = "quarter"
+)
+
+// MaintenanceCostQueryRequest describes the scope, filters and breakdown of the maintenance cost reports,
+// the costs come from the maintenance records and are bounded by their maintenance date
+type MaintenanceCostQueryRequest struct {
+	AssetGroupID      *uint      `form:"asset_group_id"`
+	AssetID           *uint      `form:"asset_id"`
+	CategoryID        *uint      `form:"category_id"`
+	MaintenanceTypeID *uint      `form:"maintenance_type_id"`
+	DateFrom          *time.Time `form:"date_from" time_format:"2006-01-02"`
+	DateTo            *time.Time `form:"date_to" time_format:"2006-01-02"`
+	GroupBy           string     `form:"group_by"` // asset, category or maintenance_type, empty for a single total
+	Period            string     `form:"period"`   // month or quarter, only used by the time series
+}
+
+// Validate normalizes the breakdown options and checks the date range
+func (q *MaintenanceCostQueryRequest) Validate() error {
+	q.GroupBy = strings.ToLower(strings.TrimSpace(q.GroupBy))
+	q.Period = strings.ToLower(strings.TrimSpace(q.Period))
+
+	switch q.GroupBy {
+	case "", MaintenanceCostGroupByAsset, MaintenanceCostGroupByCategory, MaintenanceCostGroupByType:
+	default:
+		return errors.New("group_by must be asset, category or maintenance_type")
+	}
+
+	switch q.Period {
+	case "", MaintenanceCostPeriodMonth, MaintenanceCostPeriodQuarter:
+	default:
+		return errors.New("period must be month or quarter")
+	}
+
+	if q.DateFrom != nil && q.DateTo != nil && q.DateFrom.After(*q.DateTo) {
+		return errors.New("date_from must not be after date_to")
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
 package assets
 
 type AssetMaintenanceTypeRequest struct {
-	MaintenanceTypeName string `json:"maintenance_type_name" gorm:"unique"`
+	MaintenanceTypeName string `json:"maintenance_type_name" binding:"required"`
 	Description         string `json:"description"`
 }
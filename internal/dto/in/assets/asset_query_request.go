@@ -0,0 +1,74 @@
+package assets
+
+import (
+	"asset-service/internal/utils/customfield"
+	"errors"
+	"strings"
+	"time"
+)
+
+// AssetQueryRequest describes the filters, search and sorting accepted by the asset list endpoints
+type AssetQueryRequest struct {
+	CategoryID         *uint             `form:"category_id"`
+	StatusID           *uint             `form:"status_id"`
+	LocationID         *uint             `form:"location_id"` // matches the location and every location below it
+	MinPrice           *float64          `form:"min_price"`
+	MaxPrice           *float64          `form:"max_price"`
+	PurchaseDateFrom   *time.Time        `form:"purchase_date_from" time_format:"2006-01-02"`
+	PurchaseDateTo     *time.Time        `form:"purchase_date_to" time_format:"2006-01-02"`
+	WarrantyExpiryFrom *time.Time        `form:"warranty_expiry_from" time_format:"2006-01-02"`
+	WarrantyExpiryTo   *time.Time        `form:"warranty_expiry_to" time_format:"2006-01-02"`
+	Search             string            `form:"search"`
+	SortBy             string            `form:"sort_by"`
+	SortOrder          string            `form:"sort_order"`
+	TagIDs             []uint            `form:"-"`
+	CustomFields       map[string]string `form:"-"` // custom_fields[key]=value, matched exactly
+}
+
+// AssetSortFields maps the accepted sort_by values to their columns
+var AssetSortFields = map[string]string{
+	"name":                 "name",
+	"price":                "price",
+	"purchase_date":        "purchase_date",
+	"expiry_date":          "expiry_date",
+	"warranty_expiry_date": "warranty_expiry_date",
+	"created_at":           "created_at",
+	"updated_at":           "updated_at",
+}
+
+// Validate normalizes the sort options and checks the ranges
+func (q *AssetQueryRequest) Validate() error {
+	q.Search = strings.TrimSpace(q.Search)
+	q.SortBy = strings.ToLower(strings.TrimSpace(q.SortBy))
+	q.SortOrder = strings.ToLower(strings.TrimSpace(q.SortOrder))
+
+	if q.SortBy != "" {
+		if _, ok := AssetSortFields[q.SortBy]; !ok {
+			return errors.New("invalid sort_by value: " + q.SortBy)
+		}
+	}
+
+	for key := range q.CustomFields {
+		if !customfield.ValidKey(key) {
+			return errors.New("invalid custom field filter: " + key)
+		}
+	}
+
+	if q.SortOrder != "" && q.SortOrder != "asc" && q.SortOrder != "desc" {
+		return errors.New("sort_order must be asc or desc")
+	}
+
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return errors.New("min_price must not be greater than max_price")
+	}
+
+	if q.PurchaseDateFrom != nil && q.PurchaseDateTo != nil && q.PurchaseDateFrom.After(*q.PurchaseDateTo) {
+		return errors.New("purchase_date_from must not be after purchase_date_to")
+	}
+
+	if q.WarrantyExpiryFrom != nil && q.WarrantyExpiryTo != nil && q.WarrantyExpiryFrom.After(*q.WarrantyExpiryTo) {
+		return errors.New("warranty_expiry_from must not be after warranty_expiry_to")
+	}
+
+	return nil
+}
@@ -1,6 +1,11 @@
 package assets
 
-import "mime/multipart"
+import (
+	"errors"
+	"mime/multipart"
+	"strings"
+	"time"
+)
 
 type AssetRequest struct {
 	SerialNumber   *string                 `json:"serial_number"`
@@ -16,6 +21,7 @@ type AssetRequest struct {
 	Price          float64                 `json:"price"`
 	Stock          int                     `json:"stock"`
 	Notes          *string                 `json:"notes"`
+	CustomFields   map[string]interface{}  `json:"custom_fields"`
 }
 
 func (a *AssetRequest) ConvertAssetRequestEmptyToNil() {
@@ -36,17 +42,18 @@ func checkEmptyString(field *string) *string {
 }
 
 type UpdateAssetRequest struct {
-	SerialNumber       *string `json:"serial_number,omitempty"`
-	Description        *string `json:"description,omitempty"`
-	Barcode            *string `json:"barcode,omitempty"`
-	CategoryID         uint    `json:"category_id,omitempty"`
-	StatusID           uint    `json:"status_id,omitempty"`
-	PurchaseDate       *string `json:"purchase_date,omitempty"`
-	ExpiryDate         *string `json:"expiry_date,omitempty"`
-	WarrantyExpiryDate *string `json:"warranty_expiry_date,omitempty"`
-	Price              float64 `json:"price,omitempty"`
-	Stock              int     `json:"stock,omitempty"`
-	Notes              *string `json:"notes"`
+	SerialNumber       *string                `json:"serial_number,omitempty"`
+	Description        *string                `json:"description,omitempty"`
+	Barcode            *string                `json:"barcode,omitempty"`
+	CategoryID         uint                   `json:"category_id,omitempty"`
+	StatusID           uint                   `json:"status_id,omitempty"`
+	PurchaseDate       *string                `json:"purchase_date,omitempty"`
+	ExpiryDate         *string                `json:"expiry_date,omitempty"`
+	WarrantyExpiryDate *string                `json:"warranty_expiry_date,omitempty"`
+	Price              float64                `json:"price,omitempty"`
+	Stock              int                    `json:"stock,omitempty"`
+	Notes              *string                `json:"notes"`
+	CustomFields       map[string]interface{} `json:"custom_fields,omitempty"` // replaces all values when set
 }
 
 type AssetWishlistRequest struct {
@@ -74,3 +81,31 @@ type UpdateAssetStockRequest struct {
 	Quantity int     `json:"quantity"`
 	Reason   *string `json:"reason,omitempty"`
 }
+
+// AssetComponentRequest attaches an asset of the same owner as a component of another asset
+type AssetComponentRequest struct {
+	ComponentAssetID uint `json:"component_asset_id" binding:"required"`
+}
+
+// AssetAsOfRequest is the point in time an asset is rebuilt at from its audit history, an RFC 3339 timestamp
+// or a date standing for the end of that day
+type AssetAsOfRequest struct {
+	AsOf string `form:"as_of" json:"as_of"`
+}
+
+// Time parses as_of, nil when it is not set
+func (r AssetAsOfRequest) Time() (*time.Time, error) {
+	value := strings.TrimSpace(r.AsOf)
+	if value == "" {
+		return nil, nil
+	}
+	if asOf, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return &asOf, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.New("as_of must be an RFC 3339 timestamp or a 2006-01-02 date")
+	}
+	endOfDay := date.AddDate(0, 0, 1).Add(-time.Microsecond)
+	return &endOfDay, nil
+}
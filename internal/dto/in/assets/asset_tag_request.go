@@ -0,0 +1,10 @@
+package assets
+
+type AssetTagRequest struct {
+	TagName     string  `json:"tag_name" binding:"required"`
+	Description *string `json:"description"`
+}
+
+type AssetTagMapRequest struct {
+	TagIDs []uint `json:"tag_ids" binding:"required"`
+}
@@ -0,0 +1,10 @@
+package assets
+
+// AssetTransferRequest starts a transfer to a user or, with ToAssetGroupID, into an asset group owned by the recipient
+type AssetTransferRequest struct {
+	AssetID          uint    `json:"asset_id" validate:"required"`
+	ToUserID         uint    `json:"to_user_id" validate:"optional"`
+	ToAssetGroupID   uint    `json:"to_asset_group_id" validate:"optional"`
+	FromAssetGroupID uint    `json:"from_asset_group_id" validate:"optional"`
+	Message          *string `json:"message" validate:"optional"`
+}
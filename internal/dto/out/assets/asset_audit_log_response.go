@@ -0,0 +1,43 @@
+package assets
+
+import "time"
+
+type AssetAuditLogResponse struct {
+	LogID       uint                            `json:"log_id"`
+	TableName   string                          `json:"table_name"`
+	Action      string                          `json:"action"`
+	RecordID    *string                         `json:"record_id"`
+	PerformedAt time.Time                       `json:"performed_at"`
+	PerformedBy *string                         `json:"performed_by"`
+	Changes     []AssetAuditFieldChangeResponse `json:"changes" gorm:"-"`
+	OldData     *string                         `json:"-"`
+	NewData     *string                         `json:"-"`
+	FullRow     bool                            `json:"-"`
+}
+
+// AssetAuditFieldChangeResponse is one changed field, nested objects are flattened to dotted paths
+type AssetAuditFieldChangeResponse struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// AuditChainVerificationResponse is the outcome of walking one tenant's audit chain
+type AuditChainVerificationResponse struct {
+	TenantID           string                   `json:"tenant_id"`
+	Valid              bool                     `json:"valid"`
+	EntriesChecked     int                      `json:"entries_checked"`
+	CheckpointsChecked int                      `json:"checkpoints_checked"`
+	HeadLogID          *uint                    `json:"head_log_id,omitempty"`
+	HeadHash           *string                  `json:"head_hash,omitempty"`
+	BrokenLink         *AuditChainBreakResponse `json:"broken_link,omitempty"`
+}
+
+// AuditChainBreakResponse is the first entry or checkpoint that does not match the chain
+type AuditChainBreakResponse struct {
+	LogID        uint    `json:"log_id"`
+	CheckpointID *uint   `json:"checkpoint_id,omitempty"`
+	Reason       string  `json:"reason"`
+	Expected     *string `json:"expected,omitempty"`
+	Actual       *string `json:"actual,omitempty"`
+}
@@ -1,7 +1,13 @@
 package assets
 
+import "asset-service/internal/utils/customfield"
+
 type AssetCategoryResponse struct {
-	AssetCategoryID uint   `json:"asset_category_id"`
-	CategoryName    string `json:"category_name"`
-	Description     string `json:"description"`
+	AssetCategoryID    uint               `json:"asset_category_id"`
+	CategoryName       string             `json:"category_name"`
+	Description        string             `json:"description"`
+	DepreciationMethod *string            `json:"depreciation_method,omitempty"`
+	UsefulLifeMonths   *int               `json:"useful_life_months,omitempty"`
+	SalvageValue       float64            `json:"salvage_value,omitempty"`
+	CustomFields       customfield.Schema `json:"custom_fields,omitempty"`
 }
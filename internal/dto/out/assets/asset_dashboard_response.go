@@ -0,0 +1,38 @@
+package assets
+
+// AssetDashboardResponse is the inventory summary of the home screen, Wishlist is only set for a user's own
+// dashboard since wishlists are not shared with asset groups
+type AssetDashboardResponse struct {
+	AssetGroupID             *uint                            `json:"asset_group_id,omitempty"`
+	Days                     int                              `json:"days"`
+	TotalAssets              int64                            `json:"total_assets"`
+	TotalPurchaseValue       float64                          `json:"total_purchase_value"`
+	TotalStock               int64                            `json:"total_stock"`
+	ByStatus                 []AssetDashboardCountResponse    `json:"by_status"`
+	ByCategory               []AssetDashboardCountResponse    `json:"by_category"`
+	UpcomingMaintenanceCount int                              `json:"upcoming_maintenance_count"`
+	UpcomingMaintenance      []AssetMaintenanceDueResponse    `json:"upcoming_maintenance"`
+	ExpiringWarrantyCount    int                              `json:"expiring_warranty_count"`
+	ExpiringWarranties       []AssetDashboardWarrantyResponse `json:"expiring_warranties"`
+	Wishlist                 *AssetDashboardWishlistResponse  `json:"wishlist,omitempty"`
+}
+
+// AssetDashboardCountResponse is the number and purchase value of the assets of one status or category
+type AssetDashboardCountResponse struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	Count      int64   `json:"count"`
+	TotalValue float64 `json:"total_value"`
+}
+
+type AssetDashboardWarrantyResponse struct {
+	AssetID            uint      `json:"asset_id"`
+	Name               string    `json:"name"`
+	CategoryName       string    `json:"category_name"`
+	WarrantyExpiryDate *DateOnly `json:"warranty_expiry_date"`
+}
+
+type AssetDashboardWishlistResponse struct {
+	ItemCount     int64   `json:"item_count"`
+	TotalEstimate float64 `json:"total_estimate"`
+}
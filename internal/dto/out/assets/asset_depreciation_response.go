@@ -0,0 +1,25 @@
+package assets
+
+type AssetDepreciationResponse struct {
+	AssetID                 uint                              `json:"asset_id"`
+	AssetName               string                            `json:"asset_name"`
+	CategoryName            string                            `json:"category_name"`
+	DepreciationMethod      string                            `json:"depreciation_method"`
+	UsefulLifeMonths        int                               `json:"useful_life_months"`
+	PurchaseDate            *DateOnly                         `json:"purchase_date"`
+	PurchasePrice           float64                           `json:"purchase_price"`
+	SalvageValue            float64                           `json:"salvage_value"`
+	BookValue               float64                           `json:"book_value"`
+	AccumulatedDepreciation float64                           `json:"accumulated_depreciation"`
+	Schedule                []AssetDepreciationPeriodResponse `json:"schedule"`
+}
+
+type AssetDepreciationPeriodResponse struct {
+	Period                  int       `json:"period"`
+	StartDate               *DateOnly `json:"start_date"`
+	EndDate                 *DateOnly `json:"end_date"`
+	OpeningValue            float64   `json:"opening_value"`
+	Depreciation            float64   `json:"depreciation"`
+	ClosingValue            float64   `json:"closing_value"`
+	AccumulatedDepreciation float64   `json:"accumulated_depreciation"`
+}
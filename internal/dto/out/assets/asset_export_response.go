@@ -0,0 +1,115 @@
+package assets
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AssetExportHeader is the column order of the asset CSV/XLSX export
+var AssetExportHeader = []string{
+	"asset_id", "name", "serial_number", "barcode", "description", "category", "status",
+	"purchase_date", "expiry_date", "warranty_expiry_date", "price",
+	"initial_quantity", "latest_quantity", "notes", "image_urls", "created_at",
+}
+
+type AssetExportResponse struct {
+	AssetID            uint      `json:"asset_id"`
+	Name               string    `json:"name"`
+	SerialNumber       *string   `json:"serial_number"`
+	Barcode            *string   `json:"barcode"`
+	Description        *string   `json:"description"`
+	CategoryName       string    `json:"category"`
+	StatusName         string    `json:"status"`
+	PurchaseDate       *DateOnly `json:"purchase_date"`
+	ExpiryDate         *DateOnly `json:"expiry_date"`
+	WarrantyExpiryDate *DateOnly `json:"warranty_expiry_date"`
+	Price              float64   `json:"price"`
+	InitialQuantity    int       `json:"initial_quantity"`
+	LatestQuantity     int       `json:"latest_quantity"`
+	Notes              *string   `json:"notes"`
+	ImageURLs          []string  `json:"image_urls"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// Values returns the row in AssetExportHeader order
+func (a AssetExportResponse) Values() []string {
+	return []string{
+		strconv.FormatUint(uint64(a.AssetID), 10),
+		a.Name,
+		exportString(a.SerialNumber),
+		exportString(a.Barcode),
+		exportString(a.Description),
+		a.CategoryName,
+		a.StatusName,
+		exportDate(a.PurchaseDate),
+		exportDate(a.ExpiryDate),
+		exportDate(a.WarrantyExpiryDate),
+		strconv.FormatFloat(a.Price, 'f', 2, 64),
+		strconv.Itoa(a.InitialQuantity),
+		strconv.Itoa(a.LatestQuantity),
+		exportString(a.Notes),
+		strings.Join(a.ImageURLs, " "),
+		a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// MaintenanceExportHeader is the column order of the maintenance schedule and record exports
+var MaintenanceExportHeader = []string{
+	"id", "maintenance_id", "asset_id", "asset_name", "maintenance_type", "maintenance_date",
+	"maintenance_details", "maintenance_cost", "performed_by", "interval_days", "next_due_date",
+}
+
+// MaintenanceExportResponse is one maintenance schedule or record, MaintenanceID is only set for records
+type MaintenanceExportResponse struct {
+	ID                  uint      `json:"id"`
+	MaintenanceID       *uint     `json:"maintenance_id,omitempty"`
+	AssetID             uint      `json:"asset_id"`
+	AssetName           string    `json:"asset_name"`
+	MaintenanceTypeName *string   `json:"maintenance_type"`
+	MaintenanceDate     *DateOnly `json:"maintenance_date"`
+	MaintenanceDetails  *string   `json:"maintenance_details"`
+	MaintenanceCost     float64   `json:"maintenance_cost"`
+	PerformedBy         *string   `json:"performed_by"`
+	IntervalDays        *int      `json:"interval_days"`
+	NextDueDate         *DateOnly `json:"next_due_date"`
+}
+
+// Values returns the row in MaintenanceExportHeader order
+func (m MaintenanceExportResponse) Values() []string {
+	maintenanceID, intervalDays := "", ""
+	if m.MaintenanceID != nil {
+		maintenanceID = strconv.FormatUint(uint64(*m.MaintenanceID), 10)
+	}
+	if m.IntervalDays != nil {
+		intervalDays = strconv.Itoa(*m.IntervalDays)
+	}
+
+	return []string{
+		strconv.FormatUint(uint64(m.ID), 10),
+		maintenanceID,
+		strconv.FormatUint(uint64(m.AssetID), 10),
+		m.AssetName,
+		exportString(m.MaintenanceTypeName),
+		exportDate(m.MaintenanceDate),
+		exportString(m.MaintenanceDetails),
+		strconv.FormatFloat(m.MaintenanceCost, 'f', 2, 64),
+		exportString(m.PerformedBy),
+		intervalDays,
+		exportDate(m.NextDueDate),
+	}
+}
+
+func exportString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func exportDate(value *DateOnly) string {
+	if value == nil || time.Time(*value).IsZero() {
+		return ""
+	}
+	return time.Time(*value).Format("2006-01-02")
+}
@@ -1,5 +1,7 @@
 package assets
 
+import "time"
+
 type AssetGroupResponse struct {
 	AssetGroupID   uint   `gorm:"primaryKey;column:asset_group_id"  json:"asset_group_id,omitempty"`
 	AssetGroupName string `gorm:"type:varchar(100);not null"  json:"asset_group_name,omitempty"`
@@ -45,3 +47,20 @@ type AssetGroupAssetResponse struct {
 	Stock              AssetStockResponse    `json:"stock,omitempty"`
 	Notes              *string               `json:"notes,omitempty"`
 }
+
+type AssetGroupAssetLoanResponse struct {
+	LoanID            uint       `json:"loan_id"`
+	AssetGroupID      uint       `json:"asset_group_id"`
+	AssetID           uint       `json:"asset_id"`
+	AssetName         string     `json:"asset_name,omitempty"`
+	BorrowerUserID    uint       `json:"borrower_user_id"`
+	BorrowerName      string     `json:"borrower_name,omitempty"`
+	LenderUserID      uint       `json:"lender_user_id"`
+	CheckedOutAt      time.Time  `json:"checked_out_at"`
+	DueAt             time.Time  `json:"due_at"`
+	ReturnedAt        *time.Time `json:"returned_at,omitempty"`
+	CheckoutCondition *string    `json:"checkout_condition,omitempty"`
+	ReturnCondition   *string    `json:"return_condition,omitempty"`
+	Overdue           bool       `json:"overdue"`
+	DaysOverdue       int        `json:"days_overdue,omitempty"`
+}
@@ -0,0 +1,15 @@
+package assets
+
+type AssetImportResponse struct {
+	DryRun    bool                  `json:"dry_run"`
+	TotalRows int                   `json:"total_rows"`
+	ValidRows int                   `json:"valid_rows"`
+	Imported  int                   `json:"imported"`
+	Errors    []AssetImportRowError `json:"errors"`
+}
+
+type AssetImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
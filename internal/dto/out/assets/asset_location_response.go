@@ -0,0 +1,26 @@
+package assets
+
+import "time"
+
+type AssetLocationResponse struct {
+	LocationID       uint                    `json:"location_id"`
+	AssetGroupID     *uint                   `json:"asset_group_id,omitempty"`
+	ParentLocationID *uint                   `json:"parent_location_id,omitempty"`
+	Name             string                  `json:"name"`
+	LocationType     string                  `json:"location_type"`
+	Description      *string                 `json:"description,omitempty"`
+	Path             string                  `json:"path,omitempty"`
+	Children         []AssetLocationResponse `json:"children,omitempty"`
+}
+
+type AssetLocationHistoryResponse struct {
+	HistoryID        uint      `json:"history_id"`
+	AssetID          uint      `json:"asset_id"`
+	FromLocationID   *uint     `json:"from_location_id,omitempty"`
+	FromLocationName *string   `json:"from_location_name,omitempty"`
+	ToLocationID     *uint     `json:"to_location_id,omitempty"`
+	ToLocationName   *string   `json:"to_location_name,omitempty"`
+	MovedAt          time.Time `json:"moved_at"`
+	MovedBy          *string   `json:"moved_by,omitempty"`
+	Notes            *string   `json:"notes,omitempty"`
+}
@@ -0,0 +1,47 @@
+package assets
+
+import "strconv"
+
+// MaintenanceCostHeader is the column order of the maintenance cost CSV reports
+var MaintenanceCostHeader = []string{
+	"period", "group_id", "group_name", "record_count", "total_cost", "average_cost",
+}
+
+// MaintenanceCostReportResponse is a maintenance cost report, Rows are ordered by period then by cost
+type MaintenanceCostReportResponse struct {
+	DateFrom    *DateOnly                    `json:"date_from,omitempty"`
+	DateTo      *DateOnly                    `json:"date_to,omitempty"`
+	GroupBy     string                       `json:"group_by,omitempty"`
+	Period      string                       `json:"period,omitempty"`
+	RecordCount int64                        `json:"record_count"`
+	TotalCost   float64                      `json:"total_cost"`
+	Rows        []MaintenanceCostRowResponse `json:"rows"`
+}
+
+// MaintenanceCostRowResponse is the cost of one group in one period, Period is empty for summaries and
+// GroupID is empty when the report is not grouped
+type MaintenanceCostRowResponse struct {
+	Period      string  `json:"period,omitempty"`
+	GroupID     *uint   `json:"group_id,omitempty"`
+	GroupName   string  `json:"group_name,omitempty"`
+	RecordCount int64   `json:"record_count"`
+	TotalCost   float64 `json:"total_cost"`
+	AverageCost float64 `json:"average_cost"`
+}
+
+// Values returns the row in MaintenanceCostHeader order
+func (m MaintenanceCostRowResponse) Values() []string {
+	groupID := ""
+	if m.GroupID != nil {
+		groupID = strconv.FormatUint(uint64(*m.GroupID), 10)
+	}
+
+	return []string{
+		m.Period,
+		groupID,
+		m.GroupName,
+		strconv.FormatInt(m.RecordCount, 10),
+		strconv.FormatFloat(m.TotalCost, 'f', 2, 64),
+		strconv.FormatFloat(m.AverageCost, 'f', 2, 64),
+	}
+}
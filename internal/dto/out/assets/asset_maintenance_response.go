@@ -43,12 +43,33 @@ type MaintenanceTypeResponse struct {
 }
 
 type AssetMaintenanceRecordResponse struct {
-	MaintenanceRecordID uint       `json:"maintenance_record_id"`
-	MaintenanceTypeName string     `json:"maintenance_type_name"`
-	MaintenanceDetails  *string    `json:"maintenance_details,omitempty"`
-	MaintenanceDate     *time.Time `json:"maintenance_date"`
-	MaintenanceCost     float64    `json:"maintenance_cost"`
-	PerformedBy         *string    `json:"performed_by,omitempty"`
-	IntervalDays        *int       `json:"interval_days,omitempty"`
-	NextDueDate         *time.Time `json:"next_due_date,omitempty"`
+	MaintenanceRecordID uint                                 `json:"maintenance_record_id"`
+	MaintenanceTypeName string                               `json:"maintenance_type_name"`
+	MaintenanceDetails  *string                              `json:"maintenance_details,omitempty"`
+	MaintenanceDate     *time.Time                           `json:"maintenance_date"`
+	MaintenanceCost     float64                              `json:"maintenance_cost"`
+	PerformedBy         *string                              `json:"performed_by,omitempty"`
+	IntervalDays        *int                                 `json:"interval_days,omitempty"`
+	NextDueDate         *time.Time                           `json:"next_due_date,omitempty"`
+	Attachments         []AssetMaintenanceAttachmentResponse `json:"attachments,omitempty"`
+}
+
+// AssetMaintenanceAttachmentResponse is a file attached to a maintenance schedule or record
+type AssetMaintenanceAttachmentResponse struct {
+	AttachmentID        uint       `json:"attachment_id"`
+	MaintenanceID       uint       `json:"maintenance_id"`
+	MaintenanceRecordID *uint      `json:"maintenance_record_id,omitempty"`
+	FileURL             string     `json:"file_url"`
+	FileName            string     `json:"file_name"`
+	CreatedAt           *time.Time `json:"created_at"`
+}
+
+// AssetMaintenanceDueResponse is a maintenance schedule whose next due date falls inside the check window
+type AssetMaintenanceDueResponse struct {
+	ID                  uint      `json:"id"`
+	UserClientID        string    `json:"user_client_id"`
+	AssetID             uint      `json:"asset_id"`
+	AssetName           string    `json:"asset_name"`
+	MaintenanceTypeName string    `json:"maintenance_type_name"`
+	NextDueDate         time.Time `json:"next_due_date"`
 }
@@ -28,21 +28,41 @@ func (d *DateOnly) UnmarshalJSON(data []byte) error {
 }
 
 type AssetResponse struct {
-	AssetID            uint                  `json:"asset_id,omitempty"`
-	UserClientID       string                `json:"user_client_id,omitempty"`
-	SerialNumber       *string               `json:"serial_number,omitempty"`
-	Name               string                `json:"name,omitempty"`
-	Description        string                `json:"description,omitempty"`
-	Barcode            *string               `json:"barcode,omitempty"`
-	Status             AssetStatusResponse   `json:"status,omitempty"`
-	Category           AssetCategoryResponse `json:"category,omitempty"`
-	Images             []AssetImageResponse  `json:"images,omitempty"`
-	PurchaseDate       *DateOnly             `json:"purchase_date,omitempty"`
-	ExpiryDate         *DateOnly             `json:"expiry_date,omitempty"`
-	WarrantyExpiryDate *DateOnly             `json:"warranty_expiry_date,omitempty"`
-	Price              float64               `json:"price,omitempty"`
-	Stock              AssetStockResponse    `json:"stock,omitempty"`
-	Notes              *string               `json:"notes,omitempty"`
+	AssetID              uint                   `json:"asset_id,omitempty"`
+	UserClientID         string                 `json:"user_client_id,omitempty"`
+	SerialNumber         *string                `json:"serial_number,omitempty"`
+	Name                 string                 `json:"name,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Barcode              *string                `json:"barcode,omitempty"`
+	Status               AssetStatusResponse    `json:"status,omitempty"`
+	Category             AssetCategoryResponse  `json:"category,omitempty"`
+	Location             *AssetLocationResponse `json:"location,omitempty"`
+	Images               []AssetImageResponse   `json:"images,omitempty"`
+	Tags                 []AssetTagResponse     `json:"tags,omitempty"`
+	PurchaseDate         *DateOnly              `json:"purchase_date,omitempty"`
+	ExpiryDate           *DateOnly              `json:"expiry_date,omitempty"`
+	WarrantyExpiryDate   *DateOnly              `json:"warranty_expiry_date,omitempty"`
+	Price                float64                `json:"price,omitempty"`
+	BookValue            float64                `json:"book_value"`
+	Stock                AssetStockResponse     `json:"stock,omitempty"`
+	Notes                *string                `json:"notes,omitempty"`
+	CustomFields         map[string]interface{} `json:"custom_fields,omitempty"`
+	ParentAssetID        *uint                  `json:"parent_asset_id,omitempty"`
+	ComponentCount       int                    `json:"component_count,omitempty"`
+	TotalPrice           float64                `json:"total_price"`            // price of the asset plus all of its nested components
+	TotalMaintenanceCost float64                `json:"total_maintenance_cost"` // maintenance cost of the asset plus all of its nested components
+}
+
+// AssetComponentResponse is one asset directly attached to a parent asset
+type AssetComponentResponse struct {
+	AssetID        uint    `json:"asset_id"`
+	Name           string  `json:"name"`
+	SerialNumber   *string `json:"serial_number,omitempty"`
+	Barcode        *string `json:"barcode,omitempty"`
+	CategoryName   string  `json:"category_name"`
+	StatusName     string  `json:"status_name"`
+	Price          float64 `json:"price"`
+	ComponentCount int     `json:"component_count"`
 }
 
 type AssetWishlistResponse struct {
@@ -88,3 +108,30 @@ type AssetStockResponse struct {
 	Quantity        int     `json:"quantity"`
 	Reason          *string `json:"reason,omitempty,omitempty"`
 }
+
+// AssetExpiringResponse is one approaching warranty or expiry date, an asset with both dates appears twice
+type AssetExpiringResponse struct {
+	AssetID         uint      `json:"asset_id"`
+	UserClientID    string    `json:"user_client_id,omitempty"`
+	Name            string    `json:"name"`
+	SerialNumber    *string   `json:"serial_number,omitempty"`
+	Barcode         *string   `json:"barcode,omitempty"`
+	CategoryName    string    `json:"category_name"`
+	StatusName      string    `json:"status_name"`
+	ExpiryType      string    `json:"expiry_type"`
+	ExpiryDate      *DateOnly `json:"expiry_date"`
+	DaysUntilExpiry int       `json:"days_until_expiry"`
+}
+
+// AssetTrashResponse is a deleted asset waiting in the trash, purge_at is unset when the trash is kept forever
+type AssetTrashResponse struct {
+	AssetID      uint       `json:"asset_id"`
+	Name         string     `json:"name"`
+	SerialNumber *string    `json:"serial_number,omitempty"`
+	Barcode      *string    `json:"barcode,omitempty"`
+	CategoryName string     `json:"category_name"`
+	StatusName   string     `json:"status_name"`
+	DeletedAt    time.Time  `json:"deleted_at"`
+	DeletedBy    *string    `json:"deleted_by,omitempty"`
+	PurgeAt      *time.Time `json:"purge_at,omitempty"`
+}
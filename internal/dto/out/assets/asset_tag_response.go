@@ -0,0 +1,7 @@
+package assets
+
+type AssetTagResponse struct {
+	TagID       uint    `json:"tag_id,omitempty"`
+	TagName     string  `json:"tag_name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
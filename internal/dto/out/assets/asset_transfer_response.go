@@ -0,0 +1,17 @@
+package assets
+
+import "time"
+
+type AssetTransferResponse struct {
+	TransferID       uint       `json:"transfer_id"`
+	AssetID          uint       `json:"asset_id"`
+	AssetName        string     `json:"asset_name,omitempty"`
+	FromUserClientID string     `json:"from_user_client_id"`
+	ToUserClientID   string     `json:"to_user_client_id"`
+	FromAssetGroupID *uint      `json:"from_asset_group_id,omitempty"`
+	ToAssetGroupID   *uint      `json:"to_asset_group_id,omitempty"`
+	Status           string     `json:"status"`
+	Message          *string    `json:"message,omitempty"`
+	RespondedAt      *time.Time `json:"responded_at,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"asset-service/internal/utils/audit"
 	"asset-service/internal/utils/jwt"
 	"asset-service/package/response"
 	"github.com/gin-gonic/gin"
@@ -64,6 +65,7 @@ func (a assetMiddleware) HandlerAsset() gin.HandlerFunc {
 		}
 
 		c.Set("token", tokenClaims)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), tokenClaims.ClientID))
 		c.Next()
 	}
 }
@@ -110,6 +112,7 @@ func (a assetMiddleware) HandlerAssetGroup() gin.HandlerFunc {
 		}
 
 		c.Set("token", tokenClaims)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), tokenClaims.ClientID))
 		c.Next()
 	}
 }
@@ -1,6 +1,8 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/customfield"
 	"gorm.io/gorm"
 	"time"
 )
@@ -19,6 +21,9 @@ type Asset struct {
 	StatusID uint        `gorm:"not null" json:"status_id,omitempty"`
 	Status   AssetStatus `gorm:"foreignKey:StatusID;references:AssetStatusID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"status,omitempty"`
 
+	LocationID    *uint `json:"location_id,omitempty"`
+	ParentAssetID *uint `json:"parent_asset_id,omitempty"`
+
 	PurchaseDate       *time.Time `gorm:"type:date" json:"purchase_date,omitempty"`
 	ExpiryDate         *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
 	WarrantyExpiryDate *time.Time `gorm:"type:date" json:"warranty_expiry_date,omitempty"`
@@ -26,6 +31,8 @@ type Asset struct {
 	Stock              int        `gorm:"not null" json:"stock,omitempty"`
 	Notes              *string    `gorm:"type:text" json:"notes,omitempty"`
 
+	CustomFields customfield.Values `gorm:"type:jsonb" json:"custom_fields,omitempty"`
+
 	CreatedAt *time.Time      `gorm:"autoCreateTime" json:"created_at,omitempty"`
 	CreatedBy *string         `gorm:"type:varchar(255)" json:"created_by,omitempty"`
 	UpdatedAt *time.Time      `gorm:"autoUpdateTime" json:"updated_at,omitempty"`
@@ -33,3 +40,7 @@ type Asset struct {
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 	DeletedBy *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (Asset) AuditTable() string {
+	return utils.TableAssetName
+}
@@ -6,8 +6,22 @@ type AssetAuditLog struct {
 	LogID       uint      `gorm:"primaryKey" json:"log_id"`
 	TableName   string    `gorm:"not null" json:"table_name"`
 	Action      string    `gorm:"type:varchar(255);not null" json:"action"`
+	RecordID    *string   `gorm:"type:varchar(255)" json:"record_id,omitempty"`
 	OldData     *string   `gorm:"type:text" json:"old_data,omitempty"`
 	NewData     *string   `gorm:"type:text" json:"new_data,omitempty"`
 	PerformedAt time.Time `gorm:"autoCreateTime" json:"performed_at"`
 	PerformedBy *string   `gorm:"type:varchar(255)" json:"performed_by,omitempty"`
+	TenantID    *string   `gorm:"type:varchar(255)" json:"tenant_id,omitempty"`
+	PrevHash    *string   `gorm:"type:varchar(64)" json:"prev_hash,omitempty"`
+	Hash        *string   `gorm:"type:varchar(64)" json:"hash,omitempty"`
+}
+
+// AssetAuditCheckpoint is a signed record of the head of a tenant's audit chain at the time it was taken
+type AssetAuditCheckpoint struct {
+	CheckpointID uint      `gorm:"primaryKey" json:"checkpoint_id"`
+	TenantID     string    `gorm:"type:varchar(255);not null" json:"tenant_id"`
+	LogID        uint      `gorm:"not null" json:"log_id"`
+	Hash         string    `gorm:"type:varchar(64);not null" json:"hash"`
+	Signature    string    `gorm:"type:varchar(64);not null" json:"signature"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -1,19 +1,29 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/customfield"
 	"gorm.io/gorm"
 	"time"
 )
 
 type AssetCategory struct {
-	AssetCategoryID uint            `gorm:"primaryKey" json:"asset_category_id"`
-	UserClientID    string          `gorm:"type:varchar(50);not null" json:"user_client_id,omitempty"`
-	CategoryName    string          `gorm:"type:varchar(255);not null" json:"category_name"`
-	Description     string          `gorm:"type:text" json:"description"`
-	CreatedAt       *time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	CreatedBy       *string         `gorm:"type:varchar(255)" json:"created_by"`
-	UpdatedAt       *time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	UpdatedBy       *string         `gorm:"type:varchar(255)" json:"updated_by"`
-	DeletedAt       *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
-	DeletedBy       *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+	AssetCategoryID    uint               `gorm:"primaryKey" json:"asset_category_id"`
+	UserClientID       string             `gorm:"type:varchar(50);not null" json:"user_client_id,omitempty"`
+	CategoryName       string             `gorm:"type:varchar(255);not null" json:"category_name"`
+	Description        string             `gorm:"type:text" json:"description"`
+	DepreciationMethod *string            `gorm:"type:varchar(50)" json:"depreciation_method"`
+	UsefulLifeMonths   *int               `json:"useful_life_months"`
+	SalvageValue       float64            `gorm:"type:decimal(40,2);default:0" json:"salvage_value"`
+	CustomFields       customfield.Schema `gorm:"type:jsonb" json:"custom_fields,omitempty"`
+	CreatedAt          *time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy          *string            `gorm:"type:varchar(255)" json:"created_by"`
+	UpdatedAt          *time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	UpdatedBy          *string            `gorm:"type:varchar(255)" json:"updated_by"`
+	DeletedAt          *gorm.DeletedAt    `gorm:"index" json:"deleted_at,omitempty"`
+	DeletedBy          *string            `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+}
+
+func (AssetCategory) AuditTable() string {
+	return utils.TableAssetCategoryName
 }
@@ -0,0 +1,30 @@
+package assets
+
+import "time"
+
+type AssetExpiryAlert struct {
+	AlertID      uint       `gorm:"primaryKey" json:"alert_id"`
+	UserClientID string     `gorm:"type:varchar(50);not null" json:"user_client_id,omitempty"`
+	AssetID      uint       `gorm:"not null" json:"asset_id"`
+	ExpiryType   string     `gorm:"type:varchar(20);not null" json:"expiry_type"`
+	ExpiryDate   time.Time  `gorm:"type:date;not null" json:"expiry_date"`
+	NotifiedAt   *time.Time `gorm:"autoCreateTime" json:"notified_at"`
+	CreatedAt    *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy    *string    `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+}
+
+// AssetExpiringEvent is published on asset.expiry.due, one event per user and check run
+type AssetExpiringEvent struct {
+	ClientID   string              `json:"client_id"`
+	CheckedAt  time.Time           `json:"checked_at"`
+	WithinDays int                 `json:"within_days"`
+	Assets     []AssetExpiringItem `json:"assets"`
+}
+
+type AssetExpiringItem struct {
+	AssetID         uint   `json:"asset_id"`
+	AssetName       string `json:"asset_name"`
+	ExpiryType      string `json:"expiry_type"`
+	ExpiryDate      string `json:"expiry_date"`
+	DaysUntilExpiry int    `json:"days_until_expiry"`
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -20,3 +21,7 @@ type AssetGroup struct {
 	DeletedAt       *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty,omitempty"`
 	DeletedBy       *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetGroup) AuditTable() string {
+	return utils.TableAssetGroupName
+}
@@ -0,0 +1,30 @@
+package assets
+
+import (
+	"asset-service/internal/utils"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AssetGroupAssetLoan struct {
+	LoanID            uint            `gorm:"primaryKey;column:loan_id" json:"loan_id"`
+	AssetGroupID      uint            `gorm:"not null" json:"asset_group_id"`
+	AssetID           uint            `gorm:"not null" json:"asset_id"`
+	BorrowerUserID    uint            `gorm:"not null" json:"borrower_user_id"`
+	LenderUserID      uint            `gorm:"not null" json:"lender_user_id"`
+	CheckedOutAt      time.Time       `gorm:"not null" json:"checked_out_at"`
+	DueAt             time.Time       `gorm:"not null" json:"due_at"`
+	ReturnedAt        *time.Time      `json:"returned_at,omitempty"`
+	CheckoutCondition *string         `gorm:"type:text" json:"checkout_condition,omitempty"`
+	ReturnCondition   *string         `gorm:"type:text" json:"return_condition,omitempty"`
+	CreatedAt         *time.Time      `gorm:"autoCreateTime" json:"created_at,omitempty"`
+	CreatedBy         *string         `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	UpdatedAt         *time.Time      `gorm:"autoUpdateTime" json:"updated_at,omitempty"`
+	UpdatedBy         *string         `gorm:"type:varchar(255)" json:"updated_by,omitempty"`
+	DeletedAt         *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	DeletedBy         *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+}
+
+func (AssetGroupAssetLoan) AuditTable() string {
+	return utils.TableAssetGroupAssetLoanName
+}
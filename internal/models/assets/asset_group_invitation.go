@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -23,3 +24,7 @@ type AssetGroupInvitation struct {
 	DeletedAt        *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty,omitempty"`
 	DeletedBy        *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetGroupInvitation) AuditTable() string {
+	return utils.TableAssetGroupInvitationName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -15,3 +16,7 @@ type AssetGroupMember struct {
 	DeletedAt    *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty,omitempty"`
 	DeletedBy    *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetGroupMember) AuditTable() string {
+	return utils.TableAssetGroupMemberName
+}
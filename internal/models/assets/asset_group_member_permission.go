@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -16,3 +17,7 @@ type AssetGroupMemberPermission struct {
 	DeletedAt    *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty,omitempty"`
 	DeletedBy    *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetGroupMemberPermission) AuditTable() string {
+	return utils.TableAssetGroupMemberPermissionName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -20,6 +21,10 @@ type AssetImage struct {
 	DeletedBy    *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
 
+func (AssetImage) AuditTable() string {
+	return utils.TableAssetImageName
+}
+
 type ImageDeleteRequest struct {
 	ClientID string   `json:"client_id"`
 	Images   []string `json:"images"`
@@ -0,0 +1,43 @@
+package assets
+
+import (
+	"asset-service/internal/utils"
+	"gorm.io/gorm"
+	"time"
+)
+
+// AssetLocation is a node of a location tree, it belongs to either a user or an asset group
+type AssetLocation struct {
+	LocationID       uint            `gorm:"primaryKey;column:location_id" json:"location_id"`
+	UserClientID     *string         `gorm:"type:varchar(50)" json:"user_client_id,omitempty"`
+	AssetGroupID     *uint           `json:"asset_group_id,omitempty"`
+	ParentLocationID *uint           `json:"parent_location_id,omitempty"`
+	Name             string          `gorm:"type:varchar(100);not null" json:"name"`
+	LocationType     string          `gorm:"type:varchar(20);not null" json:"location_type"`
+	Description      *string         `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt        *time.Time      `gorm:"autoCreateTime" json:"created_at,omitempty"`
+	CreatedBy        *string         `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	UpdatedAt        *time.Time      `gorm:"autoUpdateTime" json:"updated_at,omitempty"`
+	UpdatedBy        *string         `gorm:"type:varchar(255)" json:"updated_by,omitempty"`
+	DeletedAt        *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	DeletedBy        *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+}
+
+func (AssetLocation) AuditTable() string {
+	return utils.TableAssetLocationName
+}
+
+type AssetLocationHistory struct {
+	HistoryID      uint       `gorm:"primaryKey;column:history_id" json:"history_id"`
+	AssetID        uint       `gorm:"not null" json:"asset_id"`
+	FromLocationID *uint      `json:"from_location_id,omitempty"`
+	ToLocationID   *uint      `json:"to_location_id,omitempty"`
+	MovedAt        time.Time  `gorm:"not null" json:"moved_at"`
+	Notes          *string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt      *time.Time `gorm:"autoCreateTime" json:"created_at,omitempty"`
+	CreatedBy      *string    `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+}
+
+func (AssetLocationHistory) AuditTable() string {
+	return utils.TableAssetLocationHistoryName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"time"
 
 	"gorm.io/gorm"
@@ -24,3 +25,7 @@ type AssetMaintenance struct {
 	DeletedAt          *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 	DeletedBy          *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetMaintenance) AuditTable() string {
+	return utils.TableAssetMaintenanceName
+}
@@ -0,0 +1,29 @@
+package assets
+
+import (
+	"asset-service/internal/utils"
+	"gorm.io/gorm"
+	"time"
+)
+
+// AssetMaintenanceAttachment is a file attached to a maintenance schedule, or to one of its records when
+// MaintenanceRecordID is set
+type AssetMaintenanceAttachment struct {
+	AttachmentID        uint            `gorm:"primaryKey" json:"attachment_id"`
+	UserClientID        string          `gorm:"type:varchar(50);not null" json:"user_client_id"`
+	AssetID             uint            `gorm:"not null" json:"asset_id"`
+	MaintenanceID       uint            `gorm:"not null" json:"maintenance_id"`
+	MaintenanceRecordID *uint           `json:"maintenance_record_id,omitempty"`
+	FileURL             string          `gorm:"not null" json:"file_url"`
+	FileName            string          `gorm:"type:varchar(255);not null" json:"file_name"`
+	CreatedAt           *time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy           *string         `gorm:"type:varchar(255)" json:"created_by"`
+	UpdatedAt           *time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	UpdatedBy           *string         `gorm:"type:varchar(255)" json:"updated_by"`
+	DeletedAt           *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	DeletedBy           *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+}
+
+func (AssetMaintenanceAttachment) AuditTable() string {
+	return utils.TableAssetMaintenanceAttachmentName
+}
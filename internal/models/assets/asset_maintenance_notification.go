@@ -0,0 +1,33 @@
+package assets
+
+import "time"
+
+type AssetMaintenanceNotification struct {
+	NotificationID uint       `gorm:"primaryKey" json:"notification_id"`
+	UserClientID   string     `gorm:"type:varchar(50);not null" json:"user_client_id,omitempty"`
+	MaintenanceID  uint       `gorm:"not null" json:"maintenance_id"`
+	AssetID        uint       `gorm:"not null" json:"asset_id"`
+	DueDate        time.Time  `gorm:"type:date;not null" json:"due_date"`
+	DueStatus      string     `gorm:"type:varchar(20);not null" json:"due_status"`
+	NotifiedAt     *time.Time `gorm:"autoCreateTime" json:"notified_at"`
+	CreatedAt      *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy      *string    `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+}
+
+// MaintenanceDueEvent is published on asset.maintenance.due, one event per user and check run
+type MaintenanceDueEvent struct {
+	ClientID    string               `json:"client_id"`
+	CheckedAt   time.Time            `json:"checked_at"`
+	WithinDays  int                  `json:"within_days"`
+	Maintenance []MaintenanceDueItem `json:"maintenance"`
+}
+
+type MaintenanceDueItem struct {
+	MaintenanceID       uint   `json:"maintenance_id"`
+	AssetID             uint   `json:"asset_id"`
+	AssetName           string `json:"asset_name"`
+	MaintenanceTypeName string `json:"maintenance_type_name"`
+	NextDueDate         string `json:"next_due_date"`
+	DaysUntilDue        int    `json:"days_until_due"`
+	DueStatus           string `json:"due_status"`
+}
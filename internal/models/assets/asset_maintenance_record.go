@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -24,3 +25,7 @@ type AssetMaintenanceRecord struct {
 	DeletedAt           *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 	DeletedBy           *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetMaintenanceRecord) AuditTable() string {
+	return utils.TableAssetMaintenanceRecordName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -8,8 +9,9 @@ import (
 type AssetMaintenanceType struct {
 	MaintenanceTypeID   uint            `json:"maintenance_type_id" gorm:"column:maintenance_type_id;primaryKey"`
 	UserClientID        string          `gorm:"type:varchar(50);not null" json:"user_client_id,omitempty"`
-	MaintenanceTypeName string          `json:"maintenance_type_name" gorm:"unique"`
+	MaintenanceTypeName string          `json:"maintenance_type_name"`
 	Description         string          `json:"description"`
+	IsDefault           bool            `gorm:"not null;default:false" json:"is_default"`
 	CreatedAt           *time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	CreatedBy           *string         `gorm:"type:varchar(255)" json:"created_by"`
 	UpdatedAt           *time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
@@ -17,3 +19,7 @@ type AssetMaintenanceType struct {
 	DeletedAt           *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 	DeletedBy           *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
 }
+
+func (AssetMaintenanceType) AuditTable() string {
+	return utils.TableAssetMaintenanceTypeName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"gorm.io/gorm"
 	"time"
 )
@@ -25,3 +26,7 @@ type AssetStock struct {
 func (AssetStock) TableName() string {
 	return "asset_stock"
 }
+
+func (AssetStock) AuditTable() string {
+	return utils.TableAssetStockName
+}
@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"asset-service/internal/utils"
 	"time"
 )
 
@@ -21,3 +22,7 @@ type AssetStockHistory struct {
 	Asset Asset      `gorm:"foreignKey:AssetID;constraint:OnDelete:CASCADE"`
 	Stock AssetStock `gorm:"foreignKey:StockID;constraint:OnDelete:CASCADE"`
 }
+
+func (AssetStockHistory) AuditTable() string {
+	return utils.TableAssetStockHistoryName
+}
@@ -0,0 +1,29 @@
+package assets
+
+import (
+	"asset-service/internal/utils"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AssetTransfer struct {
+	TransferID       uint            `gorm:"primaryKey;column:transfer_id" json:"transfer_id"`
+	AssetID          uint            `gorm:"not null" json:"asset_id"`
+	FromUserClientID string          `gorm:"type:varchar(50);not null" json:"from_user_client_id"`
+	ToUserClientID   string          `gorm:"type:varchar(50);not null" json:"to_user_client_id"`
+	FromAssetGroupID *uint           `json:"from_asset_group_id,omitempty"`
+	ToAssetGroupID   *uint           `json:"to_asset_group_id,omitempty"`
+	Status           string          `gorm:"type:varchar(20);not null;default:pending" json:"status"`
+	Message          *string         `gorm:"type:text" json:"message,omitempty"`
+	RespondedAt      *time.Time      `json:"responded_at,omitempty"`
+	CreatedAt        *time.Time      `gorm:"autoCreateTime" json:"created_at,omitempty"`
+	CreatedBy        *string         `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	UpdatedAt        *time.Time      `gorm:"autoUpdateTime" json:"updated_at,omitempty"`
+	UpdatedBy        *string         `gorm:"type:varchar(255)" json:"updated_by,omitempty"`
+	DeletedAt        *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	DeletedBy        *string         `gorm:"type:varchar(255)" json:"deleted_by,omitempty"`
+}
+
+func (AssetTransfer) AuditTable() string {
+	return utils.TableAssetTransferName
+}
@@ -1,6 +1,9 @@
 package assets
 
-import "time"
+import (
+	"asset-service/internal/utils"
+	"time"
+)
 
 type AssetWishlist struct {
 	WishlistID    uint       `gorm:"primaryKey;column:wishlist_id" json:"wishlist_id"`
@@ -20,3 +23,7 @@ type AssetWishlist struct {
 	DeletedAt     *time.Time `gorm:"index;column:deleted_at" json:"deleted_at,omitempty"`
 	DeletedBy     *string    `gorm:"size:255;column:deleted_by" json:"deleted_by,omitempty"`
 }
+
+func (AssetWishlist) AuditTable() string {
+	return utils.TableAssetWishlistName
+}
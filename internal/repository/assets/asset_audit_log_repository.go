@@ -1,540 +1,273 @@
 package assets
 
 import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
-	"encoding/json"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-type AssetAuditLogRepository interface {
-	AfterCreateAsset(asset *assets.Asset) error
-	AfterUpdateAsset(old assets.Asset, asset *assets.Asset) error
-	AfterDeleteAsset(asset *assets.Asset) error
-	AfterCreateAssetStock(assetStock *assets.AssetStock) error
-	AfterUpdateAssetStock(old assets.AssetStock, assetStock *assets.AssetStock) error
-	AfterDeleteAssetStock(assetStock *assets.AssetStock) error
-	AfterCreateAssetMaintenance(assetMaintenance *assets.AssetMaintenance) error
-	AfterUpdateAssetMaintenance(old assets.AssetMaintenance, assetMaintenance *assets.AssetMaintenance) error
-	AfterDeleteAssetMaintenance(assetMaintenance *assets.AssetMaintenance) error
-	AfterCreateAssetCategory(assetCategory *assets.AssetCategory) error
-	AfterUpdateAssetCategory(old *assets.AssetCategory, assetCategory *assets.AssetCategory) error
-	AfterDeleteAssetCategory(assetCategory *assets.AssetCategory) error
-	AfterCreateAssetStatus(assetStatus *assets.AssetStatus) error
-	AfterUpdateAssetStatus(old assets.AssetStatus, assetStatus *assets.AssetStatus) error
-	AfterDeleteAssetStatus(assetStatus *assets.AssetStatus) error
-	AfterCreateAssetMaintenanceRecord(assetMaintenanceRecord *assets.AssetMaintenanceRecord) error
-	AfterUpdateAssetMaintenanceRecord(old assets.AssetMaintenanceRecord, assetMaintenanceRecord *assets.AssetMaintenanceRecord) error
-	AfterDeleteAssetMaintenanceRecord(assetMaintenanceRecord *assets.AssetMaintenanceRecord) error
-	AfterCreateAssetGroupPermission(tx *gorm.DB, asset *assets.AssetGroupPermission) error
-	AfterUpdateAssetGroupPermission(old assets.AssetGroupPermission, asset *assets.AssetGroupPermission) error
-	AfterDeleteAssetGroupPermission(tx *gorm.DB, asset *assets.AssetGroupPermission) error
-}
-
-type assetAuditLogRepository struct {
-	db gorm.DB
-}
-
-func NewAssetAuditLogRepository(db gorm.DB) AssetAuditLogRepository {
-	return &assetAuditLogRepository{db: db}
+// auditLogData reads the row after the change, or before it for deletes; json "null" is written for a missing side
+const auditLogData = "COALESCE(NULLIF(new_data, 'null'), old_data)::jsonb"
+
+// auditRecordKeys maps the tables audited before record_id was written to the json key of their primary key
+var auditRecordKeys = map[string]string{
+	utils.TableAssetName:                  "asset_id",
+	utils.TableAssetCategoryName:          "asset_category_id",
+	utils.TableAssetGroupName:             "asset_group_id",
+	utils.TableAssetGroupAssetName:        "asset_id",
+	utils.TableAssetLocationName:          "location_id",
+	utils.TableAssetMaintenanceName:       "id",
+	utils.TableAssetMaintenanceRecordName: "maintenance_record_id",
+	utils.TableAssetMaintenanceTypeName:   "maintenance_type_id",
+	utils.TableAssetStatusName:            "asset_status_id",
+	utils.TableAssetStockName:             "stock_id",
+	utils.TableAssetTagName:               "tag_id",
+	utils.TableAssetTagMapName:            "asset_id",
+	utils.TableAssetTransferName:          "transfer_id",
 }
 
-func (a assetAuditLogRepository) AfterCreateAsset(asset *assets.Asset) (err error) {
-	newDataBytes, err := json.Marshal(asset)
-	if err != nil {
-		return err
+// auditRecordID reads the primary key of the audited row as text, from the row data for the older entries
+var auditRecordID = func() string {
+	tables := make([]string, 0, len(auditRecordKeys))
+	for table := range auditRecordKeys {
+		tables = append(tables, table)
 	}
-	newData := string(newDataBytes)
+	sort.Strings(tables)
 
-	log := assets.AssetAuditLog{
-		TableName:   "asset",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.CreatedBy,
+	expression := "COALESCE(record_id, " + auditLogData + " ->> CASE table_name"
+	for _, table := range tables {
+		expression += " WHEN '" + table + "' THEN '" + auditRecordKeys[table] + "'"
 	}
+	return expression + " END)"
+}()
 
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+type AssetAuditLogRepository interface {
+	GetCountAuditLogs(clientID string, auditQuery request.AuditLogQueryRequest) (int64, error)
+	GetListAuditLogs(clientID string, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int) ([]response.AssetAuditLogResponse, error)
+	GetCountAssetAuditLogs(assetID uint, since *time.Time, auditQuery request.AuditLogQueryRequest) (int64, error)
+	GetListAssetAuditLogs(assetID uint, since *time.Time, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int) ([]response.AssetAuditLogResponse, error)
+	GetAuditRecordEntries(tableName, recordID string) ([]response.AssetAuditLogResponse, error)
+	GetAuditAssetEntries(tableName string, assetID uint) ([]response.AssetAuditLogResponse, error)
+	GetAuditChainTenants() ([]string, error)
+	GetAuditChainEntries(tenantID string, afterLogID uint, limit int) ([]assets.AssetAuditLog, error)
+	GetAuditCheckpoints(tenantID string) ([]assets.AssetAuditCheckpoint, error)
+	GetUncheckpointedAuditChainHeads() ([]assets.AssetAuditCheckpoint, error)
+	AddAuditCheckpoints(checkpoints []assets.AssetAuditCheckpoint) error
 }
 
-func (a assetAuditLogRepository) AfterUpdateAsset(old assets.Asset, asset *assets.Asset) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(asset)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+type assetAuditLogRepository struct {
+	db gorm.DB
 }
 
-func (a assetAuditLogRepository) AfterDeleteAsset(asset *assets.Asset) error {
-	oldDataBytes, err := json.Marshal(asset)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.DeletedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+func NewAssetAuditLogRepository(db gorm.DB) AssetAuditLogRepository {
+	return &assetAuditLogRepository{db: db}
 }
 
-func (a assetAuditLogRepository) AfterCreateAssetStock(assetStock *assets.AssetStock) error {
-	newDataBytes, err := json.Marshal(assetStock)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_stock",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStock.CreatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+func (a assetAuditLogRepository) GetCountAuditLogs(clientID string, auditQuery request.AuditLogQueryRequest) (int64, error) {
+	filter, args := auditLogFilter(clientAuditScope, []interface{}{clientID, clientID, clientID, clientID}, auditQuery)
+	return a.countAuditLogs(filter, args)
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetStock(old assets.AssetStock, assetStock *assets.AssetStock) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(assetStock)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_stock",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStock.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+func (a assetAuditLogRepository) GetListAuditLogs(clientID string, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int) ([]response.AssetAuditLogResponse, error) {
+	filter, args := auditLogFilter(clientAuditScope, []interface{}{clientID, clientID, clientID, clientID}, auditQuery)
+	return a.listAuditLogs(filter, args, pageIndex, pageSize)
 }
 
-func (a assetAuditLogRepository) AfterDeleteAssetStock(assetStock *assets.AssetStock) error {
-	oldDataBytes, err := json.Marshal(assetStock)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_stock",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStock.DeletedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+// GetCountAssetAuditLogs counts the entries of the asset and of the rows that belong to it, stock, maintenance, tags...
+// When since is set only the entries performed from then on are counted
+func (a assetAuditLogRepository) GetCountAssetAuditLogs(assetID uint, since *time.Time, auditQuery request.AuditLogQueryRequest) (int64, error) {
+	scope, scopeArgs := assetAuditScopeSince(assetID, since)
+	filter, args := auditLogFilter(scope, scopeArgs, auditQuery)
+	return a.countAuditLogs(filter, args)
 }
 
-func (a assetAuditLogRepository) AfterCreateAssetMaintenance(assetMaintenance *assets.AssetMaintenance) error {
-	newDataBytes, err := json.Marshal(assetMaintenance)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenance.CreatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+func (a assetAuditLogRepository) GetListAssetAuditLogs(assetID uint, since *time.Time, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int) ([]response.AssetAuditLogResponse, error) {
+	scope, scopeArgs := assetAuditScopeSince(assetID, since)
+	filter, args := auditLogFilter(scope, scopeArgs, auditQuery)
+	return a.listAuditLogs(filter, args, pageIndex, pageSize)
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetMaintenance(old assets.AssetMaintenance, assetMaintenance *assets.AssetMaintenance) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(assetMaintenance)
+func (a assetAuditLogRepository) countAuditLogs(filter string, args []interface{}) (int64, error) {
+	var count int64
+	err := a.db.Table(utils.TableAssetAuditLogName).
+		Where(filter, args...).
+		Count(&count).Error
 	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenance.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return count, nil
 }
 
-func (a assetAuditLogRepository) AfterDeleteAssetMaintenance(assetMaintenance *assets.AssetMaintenance) error {
-	oldDataBytes, err := json.Marshal(assetMaintenance)
+func (a assetAuditLogRepository) listAuditLogs(filter string, args []interface{}, pageIndex, pageSize int) ([]response.AssetAuditLogResponse, error) {
+	var logs []response.AssetAuditLogResponse
+	err := a.db.Table(utils.TableAssetAuditLogName).
+		Select("log_id, table_name, action, "+auditRecordID+" AS record_id, performed_at, performed_by, old_data, new_data").
+		Where(filter, args...).
+		Order("performed_at DESC, log_id DESC").
+		Limit(pageSize).
+		Offset((pageIndex - 1) * pageSize).
+		Scan(&logs).Error
 	if err != nil {
-		return err
+		log.Error().Err(err).Msg("❌ Failed to fetch audit logs")
+		return nil, err
 	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenance.DeletedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+	return logs, nil
 }
 
-func (a assetAuditLogRepository) AfterCreateAssetCategory(assetCategory *assets.AssetCategory) error {
-	newDataBytes, err := json.Marshal(assetCategory)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_category",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetCategory.CreatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+// GetAuditRecordEntries returns the whole history of one row, oldest first. FullRow is set on the entries written
+// by the callbacks, they carry the whole row where the older ones may only hold the changed columns
+func (a assetAuditLogRepository) GetAuditRecordEntries(tableName, recordID string) ([]response.AssetAuditLogResponse, error) {
+	return a.historyAuditLogs("table_name = ? AND "+auditRecordID+" = ?", []interface{}{tableName, recordID})
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetCategory(old *assets.AssetCategory, assetCategory *assets.AssetCategory) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(assetCategory)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_category",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetCategory.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+// GetAuditAssetEntries returns the history of every row of the table carrying the asset id, oldest first
+func (a assetAuditLogRepository) GetAuditAssetEntries(tableName string, assetID uint) ([]response.AssetAuditLogResponse, error) {
+	return a.historyAuditLogs("table_name = ? AND "+assetAuditScope, []interface{}{tableName, strconv.FormatUint(uint64(assetID), 10)})
 }
 
-func (a assetAuditLogRepository) AfterDeleteAssetCategory(assetCategory *assets.AssetCategory) error {
-	oldDataBytes, err := json.Marshal(assetCategory)
+func (a assetAuditLogRepository) historyAuditLogs(filter string, args []interface{}) ([]response.AssetAuditLogResponse, error) {
+	var logs []response.AssetAuditLogResponse
+	err := a.db.Table(utils.TableAssetAuditLogName).
+		Select("log_id, table_name, action, "+auditRecordID+" AS record_id, performed_at, performed_by, old_data, new_data, record_id IS NOT NULL AS full_row").
+		Where(filter, args...).
+		Order("performed_at ASC, log_id ASC").
+		Scan(&logs).Error
 	if err != nil {
-		return err
+		log.Error().Err(err).Msg("❌ Failed to fetch audit history")
+		return nil, err
 	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_category",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetCategory.DeletedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-	return nil
+	return logs, nil
 }
 
-func (a assetAuditLogRepository) AfterCreateAssetStatus(assetStatus *assets.AssetStatus) error {
-	newDataBytes, err := json.Marshal(assetStatus)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
+const (
+	// clientAuditScope matches the entries performed by the user, on rows the user owned before or after the
+	// change, or on the assets the user owns now
+	clientAuditScope = `(performed_by = ?
+		OR NULLIF(old_data, 'null')::jsonb ->> 'user_client_id' = ?
+		OR NULLIF(new_data, 'null')::jsonb ->> 'user_client_id' = ?
+		OR ` + auditLogData + ` ->> 'asset_id' IN (SELECT asset_id::text FROM asset WHERE user_client_id = ?))`
 
-	log := assets.AssetAuditLog{
-		TableName:   "asset_status",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStatus.CreatedBy,
-	}
+	// assetAuditScope matches the entries of every row carrying the asset id
+	assetAuditScope = auditLogData + " ->> 'asset_id' = ?"
+)
 
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
+// assetAuditScopeSince is the asset scope, limited to the entries performed from since on when it is set
+func assetAuditScopeSince(assetID uint, since *time.Time) (string, []interface{}) {
+	scope, args := assetAuditScope, []interface{}{strconv.FormatUint(uint64(assetID), 10)}
+	if since != nil {
+		scope += " AND performed_at >= ?"
+		args = append(args, *since)
 	}
-
-	return nil
+	return scope, args
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetStatus(old assets.AssetStatus, assetStatus *assets.AssetStatus) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
+// auditLogFilter narrows the scope down by the optional filters of the query
+func auditLogFilter(scope string, scopeArgs []interface{}, auditQuery request.AuditLogQueryRequest) (string, []interface{}) {
+	conditions := []string{scope}
+	args := scopeArgs
 
-	newDataBytes, err := json.Marshal(assetStatus)
-	if err != nil {
-		return err
+	if auditQuery.TableName != "" {
+		conditions = append(conditions, "table_name = ?")
+		args = append(args, auditQuery.TableName)
 	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_status",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStatus.UpdatedBy,
+	if auditQuery.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, auditQuery.Action)
 	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
+	if auditQuery.RecordID != "" {
+		conditions = append(conditions, auditRecordID+" = ?")
+		args = append(args, auditQuery.RecordID)
 	}
-
-	return nil
-}
-
-func (a assetAuditLogRepository) AfterDeleteAssetStatus(assetStatus *assets.AssetStatus) error {
-	oldDataBytes, err := json.Marshal(assetStatus)
-	if err != nil {
-		return err
+	if auditQuery.PerformedBy != "" {
+		conditions = append(conditions, "performed_by = ?")
+		args = append(args, auditQuery.PerformedBy)
 	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_status",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetStatus.DeletedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (a assetAuditLogRepository) AfterCreateAssetMaintenanceRecord(assetMaintenanceRecord *assets.AssetMaintenanceRecord) error {
-	newDataBytes, err := json.Marshal(assetMaintenanceRecord)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance_record",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenanceRecord.CreatedBy,
+	if auditQuery.DateFrom != nil {
+		conditions = append(conditions, "performed_at >= ?")
+		args = append(args, *auditQuery.DateFrom)
 	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
+	if auditQuery.DateTo != nil {
+		conditions = append(conditions, "performed_at < ?")
+		args = append(args, auditQuery.DateTo.AddDate(0, 0, 1))
 	}
-
-	return nil
+	return strings.Join(conditions, " AND "), args
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetMaintenanceRecord(old assets.AssetMaintenanceRecord, assetMaintenanceRecord *assets.AssetMaintenanceRecord) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(assetMaintenanceRecord)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance_record",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenanceRecord.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-
-	return nil
+// GetAuditChainTenants lists every tenant with a chain, including the ones whose entries are all gone but
+// were checkpointed
+func (a assetAuditLogRepository) GetAuditChainTenants() ([]string, error) {
+	var tenants []string
+	query := `SELECT tenant_id FROM ` + utils.TableAssetAuditLogName + ` WHERE tenant_id IS NOT NULL
+		UNION
+		SELECT tenant_id FROM ` + utils.TableAssetAuditCheckpointName + `
+		ORDER BY tenant_id`
+	if err := a.db.Raw(query).Scan(&tenants).Error; err != nil {
+		log.Error().Err(err).Msg("❌ Failed to fetch audit chain tenants")
+		return nil, err
+	}
+	return tenants, nil
 }
 
-func (a assetAuditLogRepository) AfterDeleteAssetMaintenanceRecord(assetMaintenanceRecord *assets.AssetMaintenanceRecord) error {
-	oldDataBytes, err := json.Marshal(assetMaintenanceRecord)
+// GetAuditChainEntries returns the next entries of the tenant's chain after afterLogID, in chain order
+func (a assetAuditLogRepository) GetAuditChainEntries(tenantID string, afterLogID uint, limit int) ([]assets.AssetAuditLog, error) {
+	var entries []assets.AssetAuditLog
+	err := a.db.Table(utils.TableAssetAuditLogName).
+		Where("tenant_id = ? AND log_id > ?", tenantID, afterLogID).
+		Order("log_id ASC").
+		Limit(limit).
+		Find(&entries).Error
 	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_maintenance_record",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: assetMaintenanceRecord.DeletedBy,
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("❌ Failed to fetch audit chain entries")
+		return nil, err
 	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return entries, nil
 }
 
-func (a assetAuditLogRepository) AfterCreateAssetGroupPermission(tx *gorm.DB, asset *assets.AssetGroupPermission) error {
-	newDataBytes, err := json.Marshal(asset)
+func (a assetAuditLogRepository) GetAuditCheckpoints(tenantID string) ([]assets.AssetAuditCheckpoint, error) {
+	var checkpoints []assets.AssetAuditCheckpoint
+	err := a.db.Table(utils.TableAssetAuditCheckpointName).
+		Where("tenant_id = ?", tenantID).
+		Order("log_id ASC, checkpoint_id ASC").
+		Find(&checkpoints).Error
 	if err != nil {
-		return err
+		log.Error().Err(err).Str("tenant_id", tenantID).Msg("❌ Failed to fetch audit checkpoints")
+		return nil, err
 	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_group",
-		Action:      "CREATE",
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.CreatedBy,
-	}
-
-	if err := tx.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return checkpoints, nil
 }
 
-func (a assetAuditLogRepository) AfterUpdateAssetGroupPermission(old assets.AssetGroupPermission, asset *assets.AssetGroupPermission) error {
-	oldDataBytes, err := json.Marshal(old)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	newDataBytes, err := json.Marshal(asset)
-	if err != nil {
-		return err
-	}
-	newData := string(newDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_group",
-		Action:      "UPDATE",
-		OldData:     &oldData,
-		NewData:     &newData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.UpdatedBy,
-	}
-
-	if err := a.db.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
-		return err
-	}
-
-	return nil
+// GetUncheckpointedAuditChainHeads returns the head of every chain that moved since its last checkpoint,
+// unsigned and ready to be checkpointed
+func (a assetAuditLogRepository) GetUncheckpointedAuditChainHeads() ([]assets.AssetAuditCheckpoint, error) {
+	var heads []assets.AssetAuditCheckpoint
+	query := `SELECT head.tenant_id, head.log_id, head.hash
+		FROM (SELECT DISTINCT ON (tenant_id) tenant_id, log_id, hash
+			FROM ` + utils.TableAssetAuditLogName + `
+			WHERE tenant_id IS NOT NULL AND hash IS NOT NULL
+			ORDER BY tenant_id, log_id DESC) AS head
+		WHERE head.log_id > COALESCE((SELECT MAX(c.log_id) FROM ` + utils.TableAssetAuditCheckpointName + ` c
+			WHERE c.tenant_id = head.tenant_id), 0)`
+	if err := a.db.Raw(query).Scan(&heads).Error; err != nil {
+		log.Error().Err(err).Msg("❌ Failed to fetch audit chain heads")
+		return nil, err
+	}
+	return heads, nil
 }
 
-func (a assetAuditLogRepository) AfterDeleteAssetGroupPermission(tx *gorm.DB, asset *assets.AssetGroupPermission) error {
-	oldDataBytes, err := json.Marshal(asset)
-	if err != nil {
-		return err
-	}
-	oldData := string(oldDataBytes)
-
-	log := assets.AssetAuditLog{
-		TableName:   "asset_group",
-		Action:      "DELETE",
-		OldData:     &oldData,
-		PerformedAt: time.Now(),
-		PerformedBy: asset.DeletedBy,
+func (a assetAuditLogRepository) AddAuditCheckpoints(checkpoints []assets.AssetAuditCheckpoint) error {
+	if len(checkpoints) == 0 {
+		return nil
 	}
-
-	if err := tx.Table(utils.TableAssetAuditLogName).Create(&log).Error; err != nil {
+	if err := a.db.Table(utils.TableAssetAuditCheckpointName).Create(&checkpoints).Error; err != nil {
+		log.Error().Err(err).Msg("❌ Failed to add audit checkpoints")
 		return err
 	}
-
 	return nil
 }
@@ -3,36 +3,36 @@ package assets
 import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
 // AssetCategoryRepository defines the interface
 type AssetCategoryRepository interface {
-	AddAssetCategory(assetCategory *assets.AssetCategory) error
+	AddAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory) error
 	GetCountAssetCategory(clientID string) (int64, error)
-	UpdateAssetCategory(assetCategory *assets.AssetCategory, clientID string) error
+	UpdateAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory, clientID string) error
 	GetAssetCategoryByNameAndClientID(name, clientID string) (*assets.AssetCategory, error)
 	GetAssetCategoryById(assetCategoryID uint, clientID string) (*assets.AssetCategory, error)
 	GetAssetCategoryByIdAndNameNotExist(assetCategoryID uint, categoryName string) (*assets.AssetCategory, error)
 	GetListAssetCategory(clientID string, size int, index int) ([]assets.AssetCategory, error)
-	DeleteAssetCategory(assetCategory *assets.AssetCategory) error
+	DeleteAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory) error
 }
 
 // assetCategoryRepository implementation
 type assetCategoryRepository struct {
-	db       gorm.DB
-	logAudit AssetAuditLogRepository
+	db gorm.DB
 }
 
 // NewAssetCategoryRepository initializes the repository
-func NewAssetCategoryRepository(db gorm.DB, logAudit AssetAuditLogRepository) AssetCategoryRepository {
-	return &assetCategoryRepository{db: db, logAudit: logAudit}
+func NewAssetCategoryRepository(db gorm.DB) AssetCategoryRepository {
+	return &assetCategoryRepository{db: db}
 }
 
 // AddAssetCategory inserts a new asset category and logs audit
-func (r *assetCategoryRepository) AddAssetCategory(assetCategory *assets.AssetCategory) error {
-	err := r.db.Table(utils.TableAssetCategoryName).Create(&assetCategory).Error
+func (r *assetCategoryRepository) AddAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetCategoryName).Create(&assetCategory).Error
 	if err != nil {
 		log.Error().Err(err).
 			Str("category_name", assetCategory.CategoryName).
@@ -60,8 +60,8 @@ func (r *assetCategoryRepository) GetCountAssetCategory(clientID string) (int64,
 }
 
 // UpdateAssetCategory modifies an existing asset category and logs changes
-func (r *assetCategoryRepository) UpdateAssetCategory(assetCategory *assets.AssetCategory, clientID string) error {
-	tx := r.db.Begin()
+func (r *assetCategoryRepository) UpdateAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory, clientID string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -71,6 +71,7 @@ func (r *assetCategoryRepository) UpdateAssetCategory(assetCategory *assets.Asse
 
 	err := tx.Table(utils.TableAssetCategoryName).
 		Where("asset_category_id = ? AND user_client_id = ?", assetCategory.AssetCategoryID, clientID).
+		Select("category_name", "description", "depreciation_method", "useful_life_months", "salvage_value", "custom_fields", "updated_by").
 		Updates(assetCategory).Error
 	if err != nil {
 		tx.Rollback()
@@ -148,8 +149,8 @@ func (r *assetCategoryRepository) GetListAssetCategory(clientID string, size int
 }
 
 // DeleteAssetCategory marks a category as deleted
-func (r *assetCategoryRepository) DeleteAssetCategory(assetCategory *assets.AssetCategory) error {
-	tx := r.db.Begin()
+func (r *assetCategoryRepository) DeleteAssetCategory(ctx context.Context, assetCategory *assets.AssetCategory) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -0,0 +1,200 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ancestorQuery lists the asset and every asset it is nested into, depth 1 being the asset itself.
+// The depth guard keeps the walk finite should a cycle ever reach the table.
+const ancestorQuery = `
+	WITH RECURSIVE ancestors AS (
+		SELECT asset_id, parent_asset_id, 1 AS depth FROM asset WHERE asset_id = ?
+		UNION ALL
+		SELECT a.asset_id, a.parent_asset_id, an.depth + 1
+		FROM asset a
+		JOIN ancestors an ON a.asset_id = an.parent_asset_id
+		WHERE an.depth <= ?
+	)
+	SELECT asset_id FROM ancestors ORDER BY depth`
+
+// subtreeHeightQuery returns how many levels of components hang below the asset, 0 for an asset without components
+const subtreeHeightQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT asset_id, 0 AS depth FROM asset WHERE asset_id = ?
+		UNION ALL
+		SELECT a.asset_id, s.depth + 1
+		FROM asset a
+		JOIN subtree s ON a.parent_asset_id = s.asset_id
+		WHERE a.deleted_at IS NULL AND s.depth <= ?
+	)
+	SELECT COALESCE(MAX(depth), 0) FROM subtree`
+
+type AssetComponentRepository interface {
+	AttachComponent(ctx context.Context, parentAssetID, componentAssetID uint, attachedBy string) (*assets.Asset, error)
+	DetachComponent(ctx context.Context, parentAssetID, componentAssetID uint, detachedBy string) (*assets.Asset, error)
+	DetachAllComponents(ctx context.Context, parentAssetID uint, detachedBy string) error
+	GetComponents(parentAssetID uint) ([]response.AssetComponentResponse, error)
+}
+
+type assetComponentRepository struct {
+	db gorm.DB
+}
+
+func NewAssetComponentRepository(db gorm.DB) AssetComponentRepository {
+	return assetComponentRepository{db: db}
+}
+
+// AttachComponent nests the component into the parent asset, both must belong to the same owner. The component
+// must not be attached elsewhere, must not be the parent or one of its ancestors, and the nesting must stay
+// within utils.MaxComponentDepth levels.
+func (r assetComponentRepository) AttachComponent(ctx context.Context, parentAssetID, componentAssetID uint, attachedBy string) (*assets.Asset, error) {
+	var component assets.Asset
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []assets.Asset
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id IN ? AND deleted_at IS NULL", []uint{parentAssetID, componentAssetID}).
+			Order("asset_id ASC").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return errors.New("asset not found")
+		}
+
+		var parent assets.Asset
+		for _, asset := range locked {
+			if asset.AssetID == parentAssetID {
+				parent = asset
+			} else {
+				component = asset
+			}
+		}
+		if parent.UserClientID != component.UserClientID {
+			return errors.New("component must belong to the owner of the parent asset")
+		}
+		if component.ParentAssetID != nil {
+			if *component.ParentAssetID == parentAssetID {
+				return errors.New("asset is already a component of this asset")
+			}
+			return errors.New("asset is already a component of another asset, detach it first")
+		}
+
+		var ancestors []uint
+		if err := tx.Raw(ancestorQuery, parentAssetID, utils.MaxComponentDepth).Scan(&ancestors).Error; err != nil {
+			return err
+		}
+		for _, ancestorID := range ancestors {
+			if ancestorID == componentAssetID {
+				return errors.New("asset cannot be attached to one of its own components")
+			}
+		}
+
+		var height int
+		if err := tx.Raw(subtreeHeightQuery, componentAssetID, utils.MaxComponentDepth).Scan(&height).Error; err != nil {
+			return err
+		}
+		if len(ancestors)+height > utils.MaxComponentDepth {
+			return fmt.Errorf("assets cannot be nested more than %d levels deep", utils.MaxComponentDepth)
+		}
+
+		return r.setParent(tx, &component, &parentAssetID, attachedBy)
+	})
+	if err != nil {
+		log.Error().Uint("parentAssetID", parentAssetID).Uint("componentAssetID", componentAssetID).Err(err).Msg("❌ Failed to attach component")
+		return nil, err
+	}
+
+	log.Info().Uint("parentAssetID", parentAssetID).Uint("componentAssetID", componentAssetID).Msg("✅ Component attached successfully")
+	return &component, nil
+}
+
+func (r assetComponentRepository) DetachComponent(ctx context.Context, parentAssetID, componentAssetID uint, detachedBy string) (*assets.Asset, error) {
+	var component assets.Asset
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND parent_asset_id = ? AND deleted_at IS NULL", componentAssetID, parentAssetID).
+			First(&component).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("asset is not a component of this asset")
+			}
+			return err
+		}
+		return r.setParent(tx, &component, nil, detachedBy)
+	})
+	if err != nil {
+		log.Error().Uint("parentAssetID", parentAssetID).Uint("componentAssetID", componentAssetID).Err(err).Msg("❌ Failed to detach component")
+		return nil, err
+	}
+
+	log.Info().Uint("parentAssetID", parentAssetID).Uint("componentAssetID", componentAssetID).Msg("✅ Component detached successfully")
+	return &component, nil
+}
+
+// DetachAllComponents detaches the direct components of the asset, they become standalone assets
+func (r assetComponentRepository) DetachAllComponents(ctx context.Context, parentAssetID uint, detachedBy string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var components []assets.Asset
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("parent_asset_id = ? AND deleted_at IS NULL", parentAssetID).
+			Find(&components).Error; err != nil {
+			return err
+		}
+		for i := range components {
+			if err := r.setParent(tx, &components[i], nil, detachedBy); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Uint("parentAssetID", parentAssetID).Err(err).Msg("❌ Failed to detach components")
+		return err
+	}
+
+	log.Info().Uint("parentAssetID", parentAssetID).Msg("✅ Components detached successfully")
+	return nil
+}
+
+func (r assetComponentRepository) GetComponents(parentAssetID uint) ([]response.AssetComponentResponse, error) {
+	query := `
+		SELECT
+			a.asset_id, a.name, a.serial_number, a.barcode, a.price,
+			c.category_name, s.status_name,
+			(SELECT COUNT(*) FROM asset sub WHERE sub.parent_asset_id = a.asset_id AND sub.deleted_at IS NULL) AS component_count
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		WHERE a.parent_asset_id = ? AND a.deleted_at IS NULL
+		ORDER BY a.name ASC, a.asset_id ASC`
+
+	var components []response.AssetComponentResponse
+	if err := r.db.Raw(query, parentAssetID).Scan(&components).Error; err != nil {
+		log.Error().Uint("parentAssetID", parentAssetID).Err(err).Msg("❌ Failed to fetch asset components")
+		return nil, err
+	}
+	return components, nil
+}
+
+func (r assetComponentRepository) setParent(tx *gorm.DB, component *assets.Asset, parentAssetID *uint, updatedBy string) error {
+	component.ParentAssetID = parentAssetID
+	component.UpdatedBy = &updatedBy
+	return tx.Table(utils.TableAssetName).
+		Where("asset_id = ?", component.AssetID).
+		Updates(map[string]interface{}{
+			"parent_asset_id": parentAssetID,
+			"updated_by":      updatedBy,
+			"updated_at":      time.Now(),
+		}).Error
+}
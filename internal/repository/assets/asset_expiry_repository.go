@@ -0,0 +1,142 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AssetExpiryRepository interface {
+	GetCountExpiringAssets(clientID string, from, until time.Time) (int64, error)
+	GetListExpiringAssets(clientID string, from, until time.Time, pageIndex, pageSize int) ([]response.AssetExpiringResponse, error)
+	GetAllExpiringAssets(from, until time.Time) ([]response.AssetExpiringResponse, error)
+	AddExpiryAlert(alert *assets.AssetExpiryAlert) (bool, error)
+	DeleteExpiryAlerts(alertIDs []uint) error
+}
+
+type assetExpiryRepository struct {
+	db gorm.DB
+}
+
+func NewAssetExpiryRepository(db gorm.DB) AssetExpiryRepository {
+	return assetExpiryRepository{db: db}
+}
+
+// expiringAssetsQuery lists the warranty and expiry dates between two dates as separate rows,
+// the caller appends the owner condition through the %s placeholder
+const expiringAssetsQuery = `
+	SELECT * FROM (
+		SELECT a.asset_id, a.user_client_id, a.name, a.serial_number, a.barcode,
+			c.category_name, s.status_name, 'warranty' AS expiry_type, a.warranty_expiry_date AS expiry_date
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		WHERE a.deleted_at IS NULL AND a.warranty_expiry_date BETWEEN @from AND @until
+		UNION ALL
+		SELECT a.asset_id, a.user_client_id, a.name, a.serial_number, a.barcode,
+			c.category_name, s.status_name, 'expiry' AS expiry_type, a.expiry_date AS expiry_date
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		WHERE a.deleted_at IS NULL AND a.expiry_date BETWEEN @from AND @until
+	) expiring`
+
+type expiringAssetRow struct {
+	AssetID      uint
+	UserClientID string
+	Name         string
+	SerialNumber *string
+	Barcode      *string
+	CategoryName string
+	StatusName   string
+	ExpiryType   string
+	ExpiryDate   time.Time
+}
+
+func (r assetExpiryRepository) GetCountExpiringAssets(clientID string, from, until time.Time) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM (` + expiringAssetsQuery + ` WHERE expiring.user_client_id = @client_id) counted`
+	err := r.db.Raw(query, map[string]interface{}{"from": from, "until": until, "client_id": clientID}).Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r assetExpiryRepository) GetListExpiringAssets(clientID string, from, until time.Time, pageIndex, pageSize int) ([]response.AssetExpiringResponse, error) {
+	query := expiringAssetsQuery + `
+		WHERE expiring.user_client_id = @client_id
+		ORDER BY expiring.expiry_date ASC, expiring.asset_id ASC
+		LIMIT @limit OFFSET @offset`
+
+	var rows []expiringAssetRow
+	err := r.db.Raw(query, map[string]interface{}{
+		"from":      from,
+		"until":     until,
+		"client_id": clientID,
+		"limit":     pageSize,
+		"offset":    (pageIndex - 1) * pageSize,
+	}).Scan(&rows).Error
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to fetch expiring assets")
+		return nil, err
+	}
+	return toExpiringResponses(rows, from), nil
+}
+
+func (r assetExpiryRepository) GetAllExpiringAssets(from, until time.Time) ([]response.AssetExpiringResponse, error) {
+	query := expiringAssetsQuery + `
+		ORDER BY expiring.user_client_id, expiring.expiry_date ASC, expiring.asset_id ASC`
+
+	var rows []expiringAssetRow
+	if err := r.db.Raw(query, map[string]interface{}{"from": from, "until": until}).Scan(&rows).Error; err != nil {
+		log.Error().Err(err).Msg("❌ Failed to fetch expiring assets")
+		return nil, err
+	}
+	return toExpiringResponses(rows, from), nil
+}
+
+// AddExpiryAlert records the alert and reports false when the same asset, type and date was already alerted
+func (r assetExpiryRepository) AddExpiryAlert(alert *assets.AssetExpiryAlert) (bool, error) {
+	result := r.db.Table(utils.TableAssetExpiryAlertName).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteExpiryAlerts forgets alerts whose event could not be published, so the next check sends them again
+func (r assetExpiryRepository) DeleteExpiryAlerts(alertIDs []uint) error {
+	if len(alertIDs) == 0 {
+		return nil
+	}
+	return r.db.Table(utils.TableAssetExpiryAlertName).
+		Where("alert_id IN ?", alertIDs).
+		Delete(&assets.AssetExpiryAlert{}).Error
+}
+
+func toExpiringResponses(rows []expiringAssetRow, today time.Time) []response.AssetExpiringResponse {
+	result := make([]response.AssetExpiringResponse, len(rows))
+	for i, row := range rows {
+		expiryDate := time.Date(row.ExpiryDate.Year(), row.ExpiryDate.Month(), row.ExpiryDate.Day(), 0, 0, 0, 0, today.Location())
+		result[i] = response.AssetExpiringResponse{
+			AssetID:         row.AssetID,
+			UserClientID:    row.UserClientID,
+			Name:            row.Name,
+			SerialNumber:    row.SerialNumber,
+			Barcode:         row.Barcode,
+			CategoryName:    row.CategoryName,
+			StatusName:      row.StatusName,
+			ExpiryType:      row.ExpiryType,
+			ExpiryDate:      utils.ToDateOnly(&expiryDate),
+			DaysUntilExpiry: utils.DaysBetween(today, expiryDate),
+		}
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/utils"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// AssetExportRepository streams rows for the export endpoints one at a time so large
+// exports never have to be held in memory. When assetGroupID is set the rows are scoped
+// to the assets shared with that group instead of the assets owned by clientID.
+type AssetExportRepository interface {
+	StreamAssets(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.AssetExportResponse) error) error
+	StreamMaintenances(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.MaintenanceExportResponse) error) error
+	StreamMaintenanceRecords(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.MaintenanceExportResponse) error) error
+}
+
+type assetExportRepository struct {
+	db gorm.DB
+}
+
+func NewAssetExportRepository(db gorm.DB) AssetExportRepository {
+	return assetExportRepository{db: db}
+}
+
+func (r assetExportRepository) StreamAssets(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.AssetExportResponse) error) error {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, assetQuery)
+	order, orderArgs := buildAssetQueryOrder("a", assetQuery)
+	args = append(args, orderArgs...)
+
+	query := `
+		SELECT
+			a.asset_id, a.name, a.serial_number, a.barcode, a.description,
+			c.category_name, s.status_name,
+			a.purchase_date, a.expiry_date, a.warranty_expiry_date, COALESCE(a.price, 0) AS price, a.notes, a.created_at,
+			st.initial_quantity, st.latest_quantity,
+			(SELECT COALESCE(string_agg(ai.image_url, ' ' ORDER BY ai.image_id), '')
+			 FROM asset_image ai
+			 WHERE ai.asset_id = a.asset_id AND ai.deleted_at IS NULL) AS image_urls
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		JOIN asset_stock st ON a.asset_id = st.asset_id
+		WHERE ` + scope + `
+		ORDER BY ` + order
+
+	type assetRow struct {
+		AssetID            uint
+		Name               string
+		SerialNumber       *string
+		Barcode            *string
+		Description        *string
+		CategoryName       string
+		StatusName         string
+		PurchaseDate       *time.Time
+		ExpiryDate         *time.Time
+		WarrantyExpiryDate *time.Time
+		Price              float64
+		Notes              *string
+		CreatedAt          time.Time
+		InitialQuantity    int
+		LatestQuantity     int
+		ImageURLs          string
+	}
+
+	rows, err := r.db.Raw(query, args...).Rows()
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to query asset export")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row assetRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		if err := fn(response.AssetExportResponse{
+			AssetID:            row.AssetID,
+			Name:               row.Name,
+			SerialNumber:       row.SerialNumber,
+			Barcode:            row.Barcode,
+			Description:        row.Description,
+			CategoryName:       row.CategoryName,
+			StatusName:         row.StatusName,
+			PurchaseDate:       utils.ToDateOnly(row.PurchaseDate),
+			ExpiryDate:         utils.ToDateOnly(row.ExpiryDate),
+			WarrantyExpiryDate: utils.ToDateOnly(row.WarrantyExpiryDate),
+			Price:              row.Price,
+			InitialQuantity:    row.InitialQuantity,
+			LatestQuantity:     row.LatestQuantity,
+			Notes:              row.Notes,
+			ImageURLs:          strings.Fields(row.ImageURLs),
+			CreatedAt:          row.CreatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r assetExportRepository) StreamMaintenances(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.MaintenanceExportResponse) error) error {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, assetQuery)
+
+	query := `
+		SELECT
+			am.id, am.asset_id, a.name AS asset_name, amt.maintenance_type_name,
+			am.maintenance_date, am.maintenance_details, COALESCE(am.maintenance_cost, 0) AS maintenance_cost,
+			am.performed_by, am.interval_days, am.next_due_date
+		FROM asset_maintenance am
+		JOIN asset a ON am.asset_id = a.asset_id
+		LEFT JOIN asset_maintenance_type amt ON am.maintenance_type_id = amt.maintenance_type_id
+		WHERE am.deleted_at IS NULL AND ` + scope + `
+		ORDER BY am.asset_id ASC, am.maintenance_date ASC, am.id ASC`
+
+	return r.streamMaintenanceRows(clientID, query, args, fn)
+}
+
+func (r assetExportRepository) StreamMaintenanceRecords(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest, fn func(response.MaintenanceExportResponse) error) error {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, assetQuery)
+
+	query := `
+		SELECT
+			amr.maintenance_record_id AS id, amr.maintenance_id, amr.asset_id, a.name AS asset_name, amt.maintenance_type_name,
+			amr.maintenance_date, amr.maintenance_details, COALESCE(amr.maintenance_cost, 0) AS maintenance_cost,
+			amr.performed_by, amr.interval_days, amr.next_due_date
+		FROM asset_maintenance_record amr
+		JOIN asset a ON amr.asset_id = a.asset_id
+		LEFT JOIN asset_maintenance_type amt ON amr.maintenance_type_id = amt.maintenance_type_id
+		WHERE amr.deleted_at IS NULL AND ` + scope + `
+		ORDER BY amr.asset_id ASC, amr.maintenance_date ASC, amr.maintenance_record_id ASC`
+
+	return r.streamMaintenanceRows(clientID, query, args, fn)
+}
+
+func (r assetExportRepository) streamMaintenanceRows(clientID, query string, args []interface{}, fn func(response.MaintenanceExportResponse) error) error {
+	type maintenanceRow struct {
+		ID                  uint
+		MaintenanceID       *uint
+		AssetID             uint
+		AssetName           string
+		MaintenanceTypeName *string
+		MaintenanceDate     *time.Time
+		MaintenanceDetails  *string
+		MaintenanceCost     float64
+		PerformedBy         *string
+		IntervalDays        *int
+		NextDueDate         *time.Time
+	}
+
+	rows, err := r.db.Raw(query, args...).Rows()
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to query maintenance export")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row maintenanceRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		if err := fn(response.MaintenanceExportResponse{
+			ID:                  row.ID,
+			MaintenanceID:       row.MaintenanceID,
+			AssetID:             row.AssetID,
+			AssetName:           row.AssetName,
+			MaintenanceTypeName: row.MaintenanceTypeName,
+			MaintenanceDate:     utils.ToDateOnly(row.MaintenanceDate),
+			MaintenanceDetails:  row.MaintenanceDetails,
+			MaintenanceCost:     row.MaintenanceCost,
+			PerformedBy:         row.PerformedBy,
+			IntervalDays:        row.IntervalDays,
+			NextDueDate:         utils.ToDateOnly(row.NextDueDate),
+		}); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportAssetScope limits the asset alias to the caller's own assets or to the assets of a group,
+// followed by the list filters
+func exportAssetScope(alias, clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest) (string, []interface{}) {
+	filter, filterArgs := buildAssetQueryFilter(alias, assetQuery)
+
+	scope := alias + ".user_client_id = ? AND " + alias + ".deleted_at IS NULL"
+	args := []interface{}{clientID}
+	if assetGroupID != 0 {
+		scope = alias + ".asset_id IN (SELECT aga.asset_id FROM asset_group_asset aga WHERE aga.asset_group_id = ? AND aga.deleted_at IS NULL) AND " + alias + ".deleted_at IS NULL"
+		args = []interface{}{assetGroupID}
+	}
+	return scope + filter, append(args, filterArgs...)
+}
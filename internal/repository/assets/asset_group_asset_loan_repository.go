@@ -0,0 +1,148 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AssetGroupAssetLoanRepository interface {
+	AddLoan(ctx context.Context, loan *assets.AssetGroupAssetLoan) error
+	ReturnLoan(ctx context.Context, loan *assets.AssetGroupAssetLoan) error
+	GetLoanByID(loanID uint) (*assets.AssetGroupAssetLoan, error)
+	GetCountLoans(assetGroupID uint, status string, now time.Time) (int64, error)
+	GetListLoans(assetGroupID uint, status string, now time.Time, pageIndex, pageSize int) ([]response.AssetGroupAssetLoanResponse, error)
+}
+
+type assetGroupAssetLoanRepository struct {
+	db gorm.DB
+}
+
+func NewAssetGroupAssetLoanRepository(db gorm.DB) AssetGroupAssetLoanRepository {
+	return assetGroupAssetLoanRepository{db: db}
+}
+
+// AddLoan checks the asset out, the asset row is locked so two members cannot borrow it at the same time
+func (r assetGroupAssetLoanRepository) AddLoan(ctx context.Context, loan *assets.AssetGroupAssetLoan) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var asset assets.Asset
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND deleted_at IS NULL", loan.AssetID).
+			First(&asset).Error; err != nil {
+			return err
+		}
+
+		var openLoans int64
+		if err := tx.Table(utils.TableAssetGroupAssetLoanName).
+			Where("asset_group_id = ? AND asset_id = ? AND returned_at IS NULL AND deleted_at IS NULL", loan.AssetGroupID, loan.AssetID).
+			Count(&openLoans).Error; err != nil {
+			return err
+		}
+		if openLoans > 0 {
+			return errors.New("asset is already checked out")
+		}
+
+		if err := tx.Table(utils.TableAssetGroupAssetLoanName).Create(loan).Error; err != nil {
+			log.Error().Uint("assetID", loan.AssetID).Uint("assetGroupID", loan.AssetGroupID).Err(err).Msg("❌ Failed to check out asset")
+			return err
+		}
+		return nil
+	})
+}
+
+// ReturnLoan closes an open loan, it fails when the loan was already returned
+func (r assetGroupAssetLoanRepository) ReturnLoan(ctx context.Context, loan *assets.AssetGroupAssetLoan) error {
+	result := r.db.WithContext(ctx).Table(utils.TableAssetGroupAssetLoanName).
+		Where("loan_id = ? AND returned_at IS NULL AND deleted_at IS NULL", loan.LoanID).
+		Updates(map[string]interface{}{
+			"returned_at":      loan.ReturnedAt,
+			"return_condition": loan.ReturnCondition,
+			"updated_by":       loan.UpdatedBy,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		log.Error().Uint("loanID", loan.LoanID).Err(result.Error).Msg("❌ Failed to return asset")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("loan is already returned")
+	}
+	return nil
+}
+
+func (r assetGroupAssetLoanRepository) GetLoanByID(loanID uint) (*assets.AssetGroupAssetLoan, error) {
+	var loan assets.AssetGroupAssetLoan
+	err := r.db.Table(utils.TableAssetGroupAssetLoanName).
+		Where("loan_id = ? AND deleted_at IS NULL", loanID).
+		First(&loan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func (r assetGroupAssetLoanRepository) GetCountLoans(assetGroupID uint, status string, now time.Time) (int64, error) {
+	var count int64
+	filter, args := loanStatusFilter(status, now)
+	err := r.db.Table(utils.TableAssetGroupAssetLoanName+" l").
+		Where("l.asset_group_id = ? AND l.deleted_at IS NULL"+filter, append([]interface{}{assetGroupID}, args...)...).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r assetGroupAssetLoanRepository) GetListLoans(assetGroupID uint, status string, now time.Time, pageIndex, pageSize int) ([]response.AssetGroupAssetLoanResponse, error) {
+	filter, filterArgs := loanStatusFilter(status, now)
+
+	args := []interface{}{assetGroupID}
+	args = append(args, filterArgs...)
+	args = append(args, pageSize, (pageIndex-1)*pageSize)
+
+	query := `
+		SELECT
+			l.loan_id, l.asset_group_id, l.asset_id, a.name AS asset_name,
+			l.borrower_user_id, COALESCE(u.full_name, '') AS borrower_name, l.lender_user_id,
+			l.checked_out_at, l.due_at, l.returned_at, l.checkout_condition, l.return_condition
+		FROM asset_group_asset_loan l
+		JOIN asset a ON l.asset_id = a.asset_id
+		LEFT JOIN users u ON l.borrower_user_id = u.user_id
+		WHERE l.asset_group_id = ? AND l.deleted_at IS NULL` + filter + `
+		ORDER BY l.due_at ASC, l.loan_id ASC
+		LIMIT ? OFFSET ?`
+
+	var loans []response.AssetGroupAssetLoanResponse
+	if err := r.db.Raw(query, args...).Scan(&loans).Error; err != nil {
+		log.Error().Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to fetch asset loans")
+		return nil, err
+	}
+
+	for i := range loans {
+		if loans[i].ReturnedAt == nil && loans[i].DueAt.Before(now) {
+			loans[i].Overdue = true
+			loans[i].DaysOverdue = utils.DaysBetween(loans[i].DueAt.In(now.Location()), now)
+		}
+	}
+	return loans, nil
+}
+
+// loanStatusFilter narrows the loans of a group to open, returned or overdue ones, an empty status keeps all
+func loanStatusFilter(status string, now time.Time) (string, []interface{}) {
+	switch status {
+	case utils.LoanStatusOpen:
+		return " AND l.returned_at IS NULL", nil
+	case utils.LoanStatusReturned:
+		return " AND l.returned_at IS NOT NULL", nil
+	case utils.LoanStatusOverdue:
+		return " AND l.returned_at IS NULL AND l.due_at < ?", []interface{}{now}
+	}
+	return "", nil
+}
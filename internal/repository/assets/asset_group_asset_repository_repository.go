@@ -3,32 +3,32 @@ package assets
 import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"gorm.io/gorm"
 )
 
 type AssetGroupAssetRepository interface {
-	AddAssetGroupAsset(asset *assets.AssetGroupAsset) error
-	UpdateAssetGroupAsset(asset *assets.AssetGroupAsset) error
+	AddAssetGroupAsset(ctx context.Context, asset *assets.AssetGroupAsset) error
+	UpdateAssetGroupAsset(ctx context.Context, asset *assets.AssetGroupAsset) error
 	GetAssetGroupAssetByID(assetGroupID uint) (*assets.AssetGroupAsset, error)
 	GetListAssetGroupAssetByID(assetGroupID uint) ([]assets.AssetGroupAsset, error)
-	DeleteAssetGroupAsset(assetGroupID uint) error
+	DeleteAssetGroupAsset(ctx context.Context, assetGroupID uint) error
 }
 
 type assetGroupAssetRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetGroupAssetRepository(db gorm.DB, audit AssetAuditLogRepository) AssetGroupAssetRepository {
-	return assetGroupAssetRepository{db: db, audit: audit}
+func NewAssetGroupAssetRepository(db gorm.DB) AssetGroupAssetRepository {
+	return assetGroupAssetRepository{db: db}
 }
 
-func (r assetGroupAssetRepository) AddAssetGroupAsset(asset *assets.AssetGroupAsset) error {
-	return r.db.Table(utils.TableAssetGroupAssetName).Create(asset).Error
+func (r assetGroupAssetRepository) AddAssetGroupAsset(ctx context.Context, asset *assets.AssetGroupAsset) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupAssetName).Create(asset).Error
 }
 
-func (r assetGroupAssetRepository) UpdateAssetGroupAsset(asset *assets.AssetGroupAsset) error {
-	return r.db.Table(utils.TableAssetGroupAssetName).Save(asset).Error
+func (r assetGroupAssetRepository) UpdateAssetGroupAsset(ctx context.Context, asset *assets.AssetGroupAsset) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupAssetName).Save(asset).Error
 }
 
 func (r assetGroupAssetRepository) GetAssetGroupAssetByID(assetGroupID uint) (*assets.AssetGroupAsset, error) {
@@ -51,6 +51,6 @@ func (r assetGroupAssetRepository) GetListAssetGroupAssetByID(assetGroupID uint)
 	return groupAssets, nil
 }
 
-func (r assetGroupAssetRepository) DeleteAssetGroupAsset(assetGroupID uint) error {
-	return r.db.Table(utils.TableAssetGroupAssetName).Delete(&assets.AssetGroupAsset{}, assetGroupID).Error
+func (r assetGroupAssetRepository) DeleteAssetGroupAsset(ctx context.Context, assetGroupID uint) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupAssetName).Delete(&assets.AssetGroupAsset{}, assetGroupID).Error
 }
@@ -4,19 +4,20 @@ import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/jwt"
+	"context"
 	"gorm.io/gorm"
 )
 
 type AssetGroupInvitationRepository interface {
-	AddAssetGroupInvitation(asset *assets.AssetGroupInvitation) error
-	DeleteAssetGroupInvitationByID(invitationID uint) error
+	AddAssetGroupInvitation(ctx context.Context, asset *assets.AssetGroupInvitation) error
+	DeleteAssetGroupInvitationByID(ctx context.Context, invitationID uint) error
 	GetAssetGroupInvitationByID(invitationID uint) (*assets.AssetGroupInvitation, error)
 	GetAssetGroupInvitationByInvitedUserID(userID uint) ([]assets.AssetGroupInvitation, error)
 	GetAssetGroupInvitationByInvitedByUserID(userID uint) ([]assets.AssetGroupInvitation, error)
 	GetListAssetGroupInvitation() (*[]assets.AssetGroupInvitation, error)
-	UpdateAssetGroupInvitationByUserID(status string, userID uint) error
-	UpdateAssetGroupInvitationByInvitationTokenAndUserID(status string, invitationToken, userID uint) error
-	DeleteAssetGroupInvitationExpired() error
+	UpdateAssetGroupInvitationByUserID(ctx context.Context, status string, userID uint) error
+	UpdateAssetGroupInvitationByInvitationTokenAndUserID(ctx context.Context, status string, invitationToken, userID uint) error
+	DeleteAssetGroupInvitationExpired(ctx context.Context) error
 }
 
 type assetGroupInvitationRepository struct {
@@ -27,8 +28,8 @@ func NewAssetGroupInvitationRepository(db gorm.DB) AssetGroupInvitationRepositor
 	return assetGroupInvitationRepository{db: db}
 }
 
-func (r assetGroupInvitationRepository) AddAssetGroupInvitation(asset *assets.AssetGroupInvitation) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupInvitationRepository) AddAssetGroupInvitation(ctx context.Context, asset *assets.AssetGroupInvitation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupInvitationName).Create(&asset).Error; err != nil {
 			return err
 		}
@@ -36,8 +37,8 @@ func (r assetGroupInvitationRepository) AddAssetGroupInvitation(asset *assets.As
 	})
 }
 
-func (r assetGroupInvitationRepository) DeleteAssetGroupInvitationByID(invitationID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupInvitationRepository) DeleteAssetGroupInvitationByID(ctx context.Context, invitationID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupInvitationName).Where("invitation_id = ?", invitationID).Delete(&assets.AssetGroupInvitation{}).Error; err != nil {
 			return err
 		}
@@ -80,8 +81,8 @@ func (r assetGroupInvitationRepository) GetListAssetGroupInvitation() (*[]assets
 	return &permissions, nil
 }
 
-func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByUserID(status string, userID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByUserID(ctx context.Context, status string, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupInvitationName).Where("invited_user_id = ?", userID).Updates(map[string]interface{}{
 			"status": status,
 		}).Error; err != nil {
@@ -91,8 +92,8 @@ func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByUserID(statu
 	})
 }
 
-func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByInvitationTokenAndUserID(status string, invitationToken, userID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByInvitationTokenAndUserID(ctx context.Context, status string, invitationToken, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupInvitationName).Where("invitation_token = ? AND invited_user_id = ?", invitationToken, userID).Updates(map[string]interface{}{
 			"status": status,
 		}).Error; err != nil {
@@ -102,8 +103,8 @@ func (r assetGroupInvitationRepository) UpdateAssetGroupInvitationByInvitationTo
 	})
 }
 
-func (r assetGroupInvitationRepository) DeleteAssetGroupInvitationExpired() error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupInvitationRepository) DeleteAssetGroupInvitationExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupInvitationName).Where("expired_at < ?", jwt.GetCurrentTime()).Delete(&assets.AssetGroupInvitation{}).Error; err != nil {
 			return err
 		}
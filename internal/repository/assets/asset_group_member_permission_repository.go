@@ -3,41 +3,42 @@ package assets
 import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"gorm.io/gorm"
 )
 
 type AssetGroupMemberPermissionRepository interface {
-	AddAssetGroupMemberPermission(asset *assets.AssetGroupMemberPermission) error
-	RemoveAssetGroupMemberPermission(userID uint, assetGroupID uint, permissionID uint) error
-	UpdateAssetGroupMemberPermission(asset *assets.AssetGroupMemberPermission) error
+	AddAssetGroupMemberPermission(ctx context.Context, asset *assets.AssetGroupMemberPermission) error
+	RemoveAssetGroupMemberPermission(ctx context.Context, userID uint, assetGroupID uint, permissionID uint) error
+	UpdateAssetGroupMemberPermission(ctx context.Context, asset *assets.AssetGroupMemberPermission) error
 	GetAssetGroupMemberPermissionByID(assetGroupID uint) (*assets.AssetGroupMemberPermission, error)
-	DeleteAssetGroupMemberPermission(assetGroupID uint) error
+	DeleteAssetGroupMemberPermission(ctx context.Context, assetGroupID uint) error
 	GetAdminOrManagePermissionsByUserID(userID uint) ([]assets.AssetGroupPermission, error)
 	GetAdminPermissionsByUserID(userID uint) ([]assets.AssetGroupPermission, error)
 	GetAssetGroupMemberPermissionByUserIDAndGroupID(userID uint, assetGroupID uint) ([]assets.AssetGroupMemberPermission, error)
+	GetPermissionsByUserIDAndGroupID(userID uint, assetGroupID uint) ([]assets.AssetGroupPermission, error)
 }
 
 type assetGroupMemberPermissionRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetGroupMemberPermissionRepository(db gorm.DB, audit AssetAuditLogRepository) AssetGroupMemberPermissionRepository {
-	return assetGroupMemberPermissionRepository{db: db, audit: audit}
+func NewAssetGroupMemberPermissionRepository(db gorm.DB) AssetGroupMemberPermissionRepository {
+	return assetGroupMemberPermissionRepository{db: db}
 }
 
-func (r assetGroupMemberPermissionRepository) AddAssetGroupMemberPermission(asset *assets.AssetGroupMemberPermission) error {
-	return r.db.Table(utils.TableAssetGroupMemberPermissionName).Create(asset).Error
+func (r assetGroupMemberPermissionRepository) AddAssetGroupMemberPermission(ctx context.Context, asset *assets.AssetGroupMemberPermission) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupMemberPermissionName).Create(asset).Error
 }
 
-func (r assetGroupMemberPermissionRepository) RemoveAssetGroupMemberPermission(userID uint, assetGroupID uint, permissionID uint) error {
-	return r.db.Unscoped().Table(utils.TableAssetGroupMemberPermissionName).
+func (r assetGroupMemberPermissionRepository) RemoveAssetGroupMemberPermission(ctx context.Context, userID uint, assetGroupID uint, permissionID uint) error {
+	return r.db.WithContext(ctx).Unscoped().Table(utils.TableAssetGroupMemberPermissionName).
 		Where("user_id = ? AND asset_group_id = ? AND permission_id = ?", userID, assetGroupID, permissionID).
 		Delete(&assets.AssetGroupMemberPermission{}).Error
 }
 
-func (r assetGroupMemberPermissionRepository) UpdateAssetGroupMemberPermission(asset *assets.AssetGroupMemberPermission) error {
-	return r.db.Table(utils.TableAssetGroupMemberPermissionName).Save(asset).Error
+func (r assetGroupMemberPermissionRepository) UpdateAssetGroupMemberPermission(ctx context.Context, asset *assets.AssetGroupMemberPermission) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupMemberPermissionName).Save(asset).Error
 }
 
 func (r assetGroupMemberPermissionRepository) GetAssetGroupMemberPermissionByID(assetGroupID uint) (*assets.AssetGroupMemberPermission, error) {
@@ -48,8 +49,8 @@ func (r assetGroupMemberPermissionRepository) GetAssetGroupMemberPermissionByID(
 	return &asset, nil
 }
 
-func (r assetGroupMemberPermissionRepository) DeleteAssetGroupMemberPermission(assetGroupID uint) error {
-	return r.db.Table(utils.TableAssetGroupMemberPermissionName).Where("asset_group_id = ?", assetGroupID).Delete(&assets.AssetGroupMemberPermission{}).Error
+func (r assetGroupMemberPermissionRepository) DeleteAssetGroupMemberPermission(ctx context.Context, assetGroupID uint) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupMemberPermissionName).Where("asset_group_id = ?", assetGroupID).Delete(&assets.AssetGroupMemberPermission{}).Error
 }
 
 func (r assetGroupMemberPermissionRepository) GetAdminOrManagePermissionsByUserID(userID uint) ([]assets.AssetGroupPermission, error) {
@@ -97,3 +98,20 @@ func (r assetGroupMemberPermissionRepository) GetAssetGroupMemberPermissionByUse
 	}
 	return assetGroupMemberPermission, nil
 }
+
+func (r assetGroupMemberPermissionRepository) GetPermissionsByUserIDAndGroupID(userID uint, assetGroupID uint) ([]assets.AssetGroupPermission, error) {
+	var results []assets.AssetGroupPermission
+
+	err := r.db.
+		Table("asset_group_member_permission AS agmp").
+		Select("agp.*").
+		Joins("JOIN asset_group_permission AS agp ON agmp.permission_id = agp.permission_id").
+		Where("agmp.user_id = ? AND agmp.asset_group_id = ? AND agmp.deleted_at IS NULL AND agp.deleted_at IS NULL", userID, assetGroupID).
+		Order("agp.permission_id ASC").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -4,30 +4,30 @@ import (
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"fmt"
 	"gorm.io/gorm"
 )
 
 type AssetGroupMemberRepository interface {
-	AddAssetGroupMember(asset *assets.AssetGroupMember, userClientID string, memberClientID string) error
-	UpdateAssetGroupMember(asset *assets.AssetGroupMember) error
+	AddAssetGroupMember(ctx context.Context, asset *assets.AssetGroupMember, userClientID string, memberClientID string) error
+	UpdateAssetGroupMember(ctx context.Context, asset *assets.AssetGroupMember) error
 	GetAssetGroupMemberByID(assetGroupID uint) (*[]response.AssetGroupMemberResponse, error)
-	RemoveAssetGroupMember(assetGroupID, userID uint) error
+	RemoveAssetGroupMember(ctx context.Context, assetGroupID, userID uint) error
 	GetAssetGroupMemberByUserIDAndGroupID(userID uint, groupID uint) (assets.AssetGroupMember, error)
 	GetAssetGroupMemberByUserID(userID uint) (*assets.AssetGroupMember, error)
 }
 
 type assetGroupMemberRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetGroupMemberRepository(db gorm.DB, audit AssetAuditLogRepository) AssetGroupMemberRepository {
-	return assetGroupMemberRepository{db: db, audit: audit}
+func NewAssetGroupMemberRepository(db gorm.DB) AssetGroupMemberRepository {
+	return assetGroupMemberRepository{db: db}
 }
 
-func (r assetGroupMemberRepository) AddAssetGroupMember(member *assets.AssetGroupMember, userClientID string, memberClientID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupMemberRepository) AddAssetGroupMember(ctx context.Context, member *assets.AssetGroupMember, userClientID string, memberClientID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 
 		var permission []assets.AssetGroupPermission
 
@@ -74,8 +74,8 @@ func (r assetGroupMemberRepository) AddAssetGroupMember(member *assets.AssetGrou
 	})
 }
 
-func (r assetGroupMemberRepository) UpdateAssetGroupMember(asset *assets.AssetGroupMember) error {
-	return r.db.Table(utils.TableAssetGroupMemberName).Save(asset).Error
+func (r assetGroupMemberRepository) UpdateAssetGroupMember(ctx context.Context, asset *assets.AssetGroupMember) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupMemberName).Save(asset).Error
 }
 
 func (r assetGroupMemberRepository) GetAssetGroupMemberByID(groupID uint) (*[]response.AssetGroupMemberResponse, error) {
@@ -152,8 +152,8 @@ func (r assetGroupMemberRepository) GetAssetGroupMemberByID(groupID uint) (*[]re
 	return &members, nil
 }
 
-func (r assetGroupMemberRepository) RemoveAssetGroupMember(assetGroupID, userID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupMemberRepository) RemoveAssetGroupMember(ctx context.Context, assetGroupID, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Unscoped().Table(utils.TableAssetGroupMemberPermissionName).Where("asset_group_id = ? AND user_id = ?", assetGroupID, userID).Delete(&assets.AssetGroupMemberPermission{}).Error; err != nil {
 			return err
 		}
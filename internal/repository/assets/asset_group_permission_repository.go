@@ -3,41 +3,33 @@ package assets
 import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"gorm.io/gorm"
 )
 
 type AssetGroupPermissionRepository interface {
-	AddAssetGroupPermission(asset *assets.AssetGroupPermission) error
-	UpdateAssetGroupPermission(asset *assets.AssetGroupPermission) error
+	AddAssetGroupPermission(ctx context.Context, asset *assets.AssetGroupPermission) error
+	UpdateAssetGroupPermission(ctx context.Context, asset *assets.AssetGroupPermission) error
 	GetAssetGroupPermissionByID(permissionID uint) (*assets.AssetGroupPermission, error)
 	GetAssetGroupPermissionByUserID(userID uint) ([]assets.AssetGroupPermission, error)
 	GetListAssetGroupPermission() (*[]assets.AssetGroupPermission, error)
-	DeleteAssetGroupPermission(asset *assets.AssetGroupPermission) error
+	DeleteAssetGroupPermission(ctx context.Context, asset *assets.AssetGroupPermission) error
 }
 
 type assetGroupPermissionRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetGroupPermissionRepository(db gorm.DB, audit AssetAuditLogRepository) AssetGroupPermissionRepository {
-	return assetGroupPermissionRepository{db: db, audit: audit}
+func NewAssetGroupPermissionRepository(db gorm.DB) AssetGroupPermissionRepository {
+	return assetGroupPermissionRepository{db: db}
 }
 
-func (r assetGroupPermissionRepository) AddAssetGroupPermission(asset *assets.AssetGroupPermission) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Table(utils.TableAssetGroupPermissionName).Create(&asset).Error; err != nil {
-			return err
-		}
-		if err := r.audit.AfterCreateAssetGroupPermission(tx, asset); err != nil {
-			return err
-		}
-		return nil
-	})
+func (r assetGroupPermissionRepository) AddAssetGroupPermission(ctx context.Context, asset *assets.AssetGroupPermission) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupPermissionName).Create(&asset).Error
 }
 
-func (r assetGroupPermissionRepository) UpdateAssetGroupPermission(asset *assets.AssetGroupPermission) error {
-	return r.db.Table(utils.TableAssetGroupPermissionName).Save(asset).Error
+func (r assetGroupPermissionRepository) UpdateAssetGroupPermission(ctx context.Context, asset *assets.AssetGroupPermission) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupPermissionName).Save(asset).Error
 }
 
 func (r assetGroupPermissionRepository) GetAssetGroupPermissionByID(permissionID uint) (*assets.AssetGroupPermission, error) {
@@ -69,20 +61,13 @@ func (r assetGroupPermissionRepository) GetListAssetGroupPermission() (*[]assets
 	return &permissions, nil
 }
 
-func (r assetGroupPermissionRepository) DeleteAssetGroupPermission(permission *assets.AssetGroupPermission) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupPermissionRepository) DeleteAssetGroupPermission(ctx context.Context, permission *assets.AssetGroupPermission) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var permissionMember *[]assets.AssetGroupMemberPermission
 		if err := tx.Table(utils.TableAssetGroupMemberPermissionName).Where("permission_id = ?", permission.PermissionID).Delete(&permissionMember).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Table(utils.TableAssetGroupPermissionName).Where("permission_id = ?", permission.PermissionID).Delete(&permission).Error; err != nil {
-			return err
-		}
-
-		if err := r.audit.AfterDeleteAssetGroupPermission(tx, permission); err != nil {
-			return err
-		}
-		return nil
+		return tx.Table(utils.TableAssetGroupPermissionName).Where("permission_id = ?", permission.PermissionID).Delete(&permission).Error
 	})
 }
@@ -5,34 +5,34 @@ import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/models/user"
 	"asset-service/internal/utils"
+	"context"
 	"fmt"
 	"gorm.io/gorm"
 )
 
 type AssetGroupRepository interface {
-	AddAssetGroup(assetGroup *assets.AssetGroup, clientID string, user *user.Users) error
-	AddInvitationToken(assetGroupID uint, token string, clientID string) error
-	RemoveInvitationToken(assetGroupID uint, clientID string) error
-	UpdateCurrentUsesInvitationToken(assetGroupID uint, clientID string) error
-	UpdateAssetGroup(asset *assets.AssetGroup) error
+	AddAssetGroup(ctx context.Context, assetGroup *assets.AssetGroup, clientID string, user *user.Users) error
+	AddInvitationToken(ctx context.Context, assetGroupID uint, token string, clientID string) error
+	RemoveInvitationToken(ctx context.Context, assetGroupID uint, clientID string) error
+	UpdateCurrentUsesInvitationToken(ctx context.Context, assetGroupID uint, clientID string) error
+	UpdateAssetGroup(ctx context.Context, asset *assets.AssetGroup) error
 	GetAssetGroupByID(assetGroupID uint) (*assets.AssetGroup, error)
 	GetAssetGroupDetailByUserID(userID uint) (*response.AssetGroupDetailResponse, error)
 	GetAssetGroupByOwnerUserID(id uint) ([]assets.AssetGroup, error)
-	DeleteAssetGroup(assetGroupID uint, userID uint) error
+	DeleteAssetGroup(ctx context.Context, assetGroupID uint, userID uint) error
 	GetAssetGroupByInvitationToken(invitationToken string) (*assets.AssetGroup, error)
 }
 
 type assetGroupRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetGroupRepository(db gorm.DB, audit AssetAuditLogRepository) AssetGroupRepository {
-	return assetGroupRepository{db: db, audit: audit}
+func NewAssetGroupRepository(db gorm.DB) AssetGroupRepository {
+	return assetGroupRepository{db: db}
 }
 
-func (r assetGroupRepository) AddAssetGroup(assetGroup *assets.AssetGroup, clientID string, user *user.Users) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupRepository) AddAssetGroup(ctx context.Context, assetGroup *assets.AssetGroup, clientID string, user *user.Users) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetGroupName).Create(&assetGroup).Error; err != nil {
 			return err
 		}
@@ -89,8 +89,8 @@ func (r assetGroupRepository) AddAssetGroup(assetGroup *assets.AssetGroup, clien
 
 }
 
-func (r assetGroupRepository) AddInvitationToken(assetGroupID uint, token string, clientID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupRepository) AddInvitationToken(ctx context.Context, assetGroupID uint, token string, clientID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var assetGroup assets.AssetGroup
 		if err := tx.Table(utils.TableAssetGroupName).Where("asset_group_id = ?", assetGroupID).First(&assetGroup).Error; err != nil {
 			return err
@@ -112,8 +112,8 @@ func (r assetGroupRepository) AddInvitationToken(assetGroupID uint, token string
 	})
 }
 
-func (r assetGroupRepository) RemoveInvitationToken(assetGroupID uint, clientID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupRepository) RemoveInvitationToken(ctx context.Context, assetGroupID uint, clientID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var assetGroup assets.AssetGroup
 		if err := tx.Table(utils.TableAssetGroupName).Where("asset_group_id = ?", assetGroupID).First(&assetGroup).Error; err != nil {
 			return err
@@ -132,8 +132,8 @@ func (r assetGroupRepository) RemoveInvitationToken(assetGroupID uint, clientID
 	})
 }
 
-func (r assetGroupRepository) UpdateCurrentUsesInvitationToken(assetGroupID uint, clientID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupRepository) UpdateCurrentUsesInvitationToken(ctx context.Context, assetGroupID uint, clientID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var assetGroup assets.AssetGroup
 		if err := tx.Table(utils.TableAssetGroupName).Where("asset_group_id = ?", assetGroupID).First(&assetGroup).Error; err != nil {
 			return err
@@ -151,8 +151,8 @@ func (r assetGroupRepository) UpdateCurrentUsesInvitationToken(assetGroupID uint
 	})
 }
 
-func (r assetGroupRepository) UpdateAssetGroup(asset *assets.AssetGroup) error {
-	return r.db.Table(utils.TableAssetGroupName).Save(asset).Error
+func (r assetGroupRepository) UpdateAssetGroup(ctx context.Context, asset *assets.AssetGroup) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetGroupName).Save(asset).Error
 }
 
 func (r assetGroupRepository) GetAssetGroupByID(assetGroupID uint) (*assets.AssetGroup, error) {
@@ -279,8 +279,8 @@ func (r assetGroupRepository) GetAssetGroupByOwnerUserID(id uint) ([]assets.Asse
 	return assetGroups, nil
 }
 
-func (r assetGroupRepository) DeleteAssetGroup(assetGroupID uint, userID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetGroupRepository) DeleteAssetGroup(ctx context.Context, assetGroupID uint, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Unscoped().Table(utils.TableAssetGroupMemberPermissionName).Where("asset_group_id = ?", assetGroupID).Delete(&assets.AssetGroupMemberPermission{}).Error; err != nil {
 			return err
 		}
@@ -4,6 +4,7 @@ import (
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 	"time"
@@ -11,10 +12,10 @@ import (
 
 // AssetImageRepository defines the interface
 type AssetImageRepository interface {
-	AddAssetImage(assetImage []assets.AssetImage) error
-	DeleteAssetImage(assetID uint, clientID string) error
-	UpdateAssetImage(assetID uint, metadata []response.AssetImageResponse, clientID string) error
-	Cleanup() error
+	AddAssetImage(ctx context.Context, assetImage []assets.AssetImage) error
+	DeleteAssetImage(ctx context.Context, assetID uint, clientID string) error
+	UpdateAssetImage(ctx context.Context, assetID uint, metadata []response.AssetImageResponse, clientID string) error
+	Cleanup(ctx context.Context) error
 	GetAssetImageResponseByAssetID(assetID uint) (*[]response.AssetImageResponse, error)
 	GetAssetImageByAssetID(assetID uint) (*[]assets.AssetImage, error)
 	GetAssetImage() ([]assets.AssetImage, error)
@@ -33,12 +34,12 @@ func NewAssetImageRepository(db gorm.DB) AssetImageRepository {
 
 // AddAssetImage inserts a new asset image and logs audit
 
-func (r assetImageRepository) AddAssetImage(assetImage []assets.AssetImage) error {
+func (r assetImageRepository) AddAssetImage(ctx context.Context, assetImage []assets.AssetImage) error {
 	if len(assetImage) == 0 {
 		return nil // No images to insert
 	}
 
-	err := r.db.Table(utils.TableAssetImageName).Create(&assetImage).Error
+	err := r.db.WithContext(ctx).Table(utils.TableAssetImageName).Create(&assetImage).Error
 	if err != nil {
 		log.Error().Err(err).Msg("❌ Failed to batch insert asset images")
 		return err
@@ -126,8 +127,8 @@ func (r *assetImageRepository) GetAssetImageByClientID(clientID string) (*[]asse
 }
 
 // DeleteAssetImage removes an existing asset image and logs audit
-func (r *assetImageRepository) DeleteAssetImage(assetID uint, clientID string) error {
-	err := r.db.Table(utils.TableAssetImageName).
+func (r *assetImageRepository) DeleteAssetImage(ctx context.Context, assetID uint, clientID string) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetImageName).
 		Where("asset_id = ?", assetID).
 		Updates(map[string]interface{}{"deleted_by": clientID, "deleted_at": time.Now()}).
 		Delete(&assets.Asset{}).Error
@@ -145,12 +146,12 @@ func (r *assetImageRepository) DeleteAssetImage(assetID uint, clientID string) e
 }
 
 // UpdateAssetImage updates an existing asset image and logs audit
-func (r *assetImageRepository) UpdateAssetImage(assetID uint, metadata []response.AssetImageResponse, clientID string) error {
+func (r *assetImageRepository) UpdateAssetImage(ctx context.Context, assetID uint, metadata []response.AssetImageResponse, clientID string) error {
 	if len(metadata) == 0 {
 		return nil // No images to update
 	}
 	// Delete existing asset images for the given assetID
-	err := r.db.Unscoped().Table(utils.TableAssetImageName).
+	err := r.db.WithContext(ctx).Unscoped().Table(utils.TableAssetImageName).
 		Where("asset_id = ?", assetID).
 		Delete(&assets.AssetImage{}).Error
 	if err != nil {
@@ -169,7 +170,7 @@ func (r *assetImageRepository) UpdateAssetImage(assetID uint, metadata []respons
 	}
 
 	// Insert new image metadata
-	if err := r.db.Table(utils.TableAssetImageName).Create(&newImages).Error; err != nil {
+	if err := r.db.WithContext(ctx).Table(utils.TableAssetImageName).Create(&newImages).Error; err != nil {
 		log.Error().Err(err).Uint("asset_id", assetID).Msg("Failed to create new asset images")
 		return err
 	}
@@ -181,8 +182,8 @@ func (r *assetImageRepository) UpdateAssetImage(assetID uint, metadata []respons
 }
 
 // Cleanup removes all asset images
-func (r *assetImageRepository) Cleanup() error {
-	err := r.db.Table(utils.TableAssetImageName).
+func (r *assetImageRepository) Cleanup(ctx context.Context) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetImageName).
 		Delete(&assets.AssetImage{}).Error
 	if err != nil {
 		log.Error().Err(err).Msg("❌ Failed to cleanup asset images")
@@ -0,0 +1,225 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AssetLocationRepository interface {
+	AddLocation(ctx context.Context, location *assets.AssetLocation) error
+	GetLocationByID(locationID uint) (*assets.AssetLocation, error)
+	GetLocations(clientID string, assetGroupID uint) ([]assets.AssetLocation, error)
+	UpdateLocation(ctx context.Context, location *assets.AssetLocation) error
+	DeleteLocation(ctx context.Context, location *assets.AssetLocation) error
+	GetAssetLocation(assetID uint) (*assets.AssetLocation, error)
+	MoveAsset(ctx context.Context, assetID uint, toLocationID *uint, notes *string, movedBy string) (*assets.AssetLocationHistory, error)
+	GetCountLocationHistory(assetID uint) (int64, error)
+	GetListLocationHistory(assetID uint, pageIndex, pageSize int) ([]response.AssetLocationHistoryResponse, error)
+}
+
+type assetLocationRepository struct {
+	db gorm.DB
+}
+
+func NewAssetLocationRepository(db gorm.DB) AssetLocationRepository {
+	return assetLocationRepository{db: db}
+}
+
+func (r assetLocationRepository) AddLocation(ctx context.Context, location *assets.AssetLocation) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetLocationName).Create(location).Error
+	if err != nil {
+		log.Error().Str("name", location.Name).Err(err).Msg("❌ Failed to add asset location")
+		return err
+	}
+
+	log.Info().Uint("locationID", location.LocationID).Msg("✅ Asset location added successfully")
+	return nil
+}
+
+func (r assetLocationRepository) GetLocationByID(locationID uint) (*assets.AssetLocation, error) {
+	var location assets.AssetLocation
+	err := r.db.Table(utils.TableAssetLocationName).
+		Where("location_id = ? AND deleted_at IS NULL", locationID).
+		First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// GetLocations returns every location of the user, or of the asset group when assetGroupID is set
+func (r assetLocationRepository) GetLocations(clientID string, assetGroupID uint) ([]assets.AssetLocation, error) {
+	query := r.db.Table(utils.TableAssetLocationName).Where("deleted_at IS NULL")
+	if assetGroupID != 0 {
+		query = query.Where("asset_group_id = ?", assetGroupID)
+	} else {
+		query = query.Where("user_client_id = ?", clientID)
+	}
+
+	var locations []assets.AssetLocation
+	if err := query.Order("name ASC, location_id ASC").Find(&locations).Error; err != nil {
+		log.Error().Str("clientID", clientID).Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to fetch asset locations")
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (r assetLocationRepository) UpdateLocation(ctx context.Context, location *assets.AssetLocation) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetLocationName).
+		Where("location_id = ? AND deleted_at IS NULL", location.LocationID).
+		Select("name", "location_type", "parent_location_id", "description", "updated_by").
+		Updates(location).Error
+	if err != nil {
+		log.Error().Uint("locationID", location.LocationID).Err(err).Msg("❌ Failed to update asset location")
+		return err
+	}
+
+	log.Info().Uint("locationID", location.LocationID).Msg("✅ Asset location updated successfully")
+	return nil
+}
+
+// DeleteLocation soft deletes an empty location, locations that still hold assets or child locations are kept
+func (r assetLocationRepository) DeleteLocation(ctx context.Context, location *assets.AssetLocation) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Table(utils.TableAssetLocationName).
+			Where("parent_location_id = ? AND deleted_at IS NULL", location.LocationID).
+			Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return errors.New("location still has child locations")
+		}
+
+		var placed int64
+		if err := tx.Table(utils.TableAssetName).
+			Where("location_id = ? AND deleted_at IS NULL", location.LocationID).
+			Count(&placed).Error; err != nil {
+			return err
+		}
+		if placed > 0 {
+			return errors.New("location still holds assets")
+		}
+
+		return tx.Table(utils.TableAssetLocationName).
+			Where("location_id = ?", location.LocationID).
+			Updates(map[string]interface{}{
+				"deleted_by": location.DeletedBy,
+				"deleted_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		log.Error().Uint("locationID", location.LocationID).Err(err).Msg("❌ Failed to delete asset location")
+		return err
+	}
+
+	log.Info().Uint("locationID", location.LocationID).Msg("✅ Asset location deleted successfully")
+	return nil
+}
+
+// GetAssetLocation returns the current location of an asset, nil when the asset has no location
+func (r assetLocationRepository) GetAssetLocation(assetID uint) (*assets.AssetLocation, error) {
+	var locations []assets.AssetLocation
+	err := r.db.Table(utils.TableAssetLocationName+" l").
+		Select("l.*").
+		Joins("JOIN asset a ON a.location_id = l.location_id").
+		Where("a.asset_id = ? AND a.deleted_at IS NULL AND l.deleted_at IS NULL", assetID).
+		Limit(1).
+		Scan(&locations).Error
+	if err != nil || len(locations) == 0 {
+		return nil, err
+	}
+	return &locations[0], nil
+}
+
+// MoveAsset places the asset at toLocationID, or takes it out of its location when toLocationID is nil,
+// and records the movement in the location history
+func (r assetLocationRepository) MoveAsset(ctx context.Context, assetID uint, toLocationID *uint, notes *string, movedBy string) (*assets.AssetLocationHistory, error) {
+	var history assets.AssetLocationHistory
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var asset assets.Asset
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND deleted_at IS NULL", assetID).
+			First(&asset).Error; err != nil {
+			return err
+		}
+		if sameLocation(asset.LocationID, toLocationID) {
+			return errors.New("asset is already at this location")
+		}
+
+		history = assets.AssetLocationHistory{
+			AssetID:        assetID,
+			FromLocationID: asset.LocationID,
+			ToLocationID:   toLocationID,
+			MovedAt:        time.Now(),
+			Notes:          notes,
+			CreatedBy:      &movedBy,
+		}
+		if err := tx.Table(utils.TableAssetLocationHistoryName).Create(&history).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(utils.TableAssetName).
+			Where("asset_id = ?", assetID).
+			Updates(map[string]interface{}{
+				"location_id": toLocationID,
+				"updated_by":  movedBy,
+				"updated_at":  history.MovedAt,
+			}).Error
+	})
+	if err != nil {
+		log.Error().Uint("assetID", assetID).Err(err).Msg("❌ Failed to move asset")
+		return nil, err
+	}
+
+	log.Info().Uint("assetID", assetID).Msg("✅ Asset moved successfully")
+	return &history, nil
+}
+
+func (r assetLocationRepository) GetCountLocationHistory(assetID uint) (int64, error) {
+	var count int64
+	err := r.db.Table(utils.TableAssetLocationHistoryName).
+		Where("asset_id = ?", assetID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r assetLocationRepository) GetListLocationHistory(assetID uint, pageIndex, pageSize int) ([]response.AssetLocationHistoryResponse, error) {
+	query := `
+		SELECT
+			h.history_id, h.asset_id,
+			h.from_location_id, fl.name AS from_location_name,
+			h.to_location_id, tl.name AS to_location_name,
+			h.moved_at, h.created_by AS moved_by, h.notes
+		FROM asset_location_history h
+		LEFT JOIN asset_location fl ON h.from_location_id = fl.location_id
+		LEFT JOIN asset_location tl ON h.to_location_id = tl.location_id
+		WHERE h.asset_id = ?
+		ORDER BY h.moved_at DESC, h.history_id DESC
+		LIMIT ? OFFSET ?`
+
+	var history []response.AssetLocationHistoryResponse
+	if err := r.db.Raw(query, assetID, pageSize, (pageIndex-1)*pageSize).Scan(&history).Error; err != nil {
+		log.Error().Uint("assetID", assetID).Err(err).Msg("❌ Failed to fetch asset location history")
+		return nil, err
+	}
+	return history, nil
+}
+
+func sameLocation(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,148 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	model "asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AssetMaintenanceAttachmentRepository interface {
+	AddAttachments(ctx context.Context, attachments []model.AssetMaintenanceAttachment) error
+	GetAttachment(attachmentID uint, clientID string) (*model.AssetMaintenanceAttachment, error)
+	GetAttachmentsByMaintenanceID(maintenanceID uint, clientID string) ([]response.AssetMaintenanceAttachmentResponse, error)
+	GetAttachmentsByClientID(clientID string) ([]model.AssetMaintenanceAttachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID uint, deletedBy string) error
+	DeleteAttachmentsByAssetID(ctx context.Context, assetID uint, deletedBy string, deletedAt time.Time) error
+	GetDeletedAttachments() ([]model.AssetMaintenanceAttachment, error)
+	PurgeAttachments(ctx context.Context, attachmentIDs []uint) error
+}
+
+type assetMaintenanceAttachmentRepository struct {
+	db gorm.DB
+}
+
+func NewAssetMaintenanceAttachmentRepository(db gorm.DB) AssetMaintenanceAttachmentRepository {
+	return assetMaintenanceAttachmentRepository{db: db}
+}
+
+func (r assetMaintenanceAttachmentRepository) AddAttachments(ctx context.Context, attachments []model.AssetMaintenanceAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceAttachmentName).Create(&attachments).Error; err != nil {
+		log.Error().Err(err).Msg("❌ Failed to insert maintenance attachments")
+		return err
+	}
+
+	log.Info().Int("count", len(attachments)).Msg("✅ Maintenance attachments inserted successfully")
+	return nil
+}
+
+func (r assetMaintenanceAttachmentRepository) GetAttachment(attachmentID uint, clientID string) (*model.AssetMaintenanceAttachment, error) {
+	var attachment model.AssetMaintenanceAttachment
+	err := r.db.Table(utils.TableAssetMaintenanceAttachmentName).
+		Where("attachment_id = ? AND user_client_id = ? AND deleted_at IS NULL", attachmentID, clientID).
+		First(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachmentsByMaintenanceID lists the files of a maintenance schedule, including those of its records
+func (r assetMaintenanceAttachmentRepository) GetAttachmentsByMaintenanceID(maintenanceID uint, clientID string) ([]response.AssetMaintenanceAttachmentResponse, error) {
+	var attachments []response.AssetMaintenanceAttachmentResponse
+	err := r.db.Table(utils.TableAssetMaintenanceAttachmentName).
+		Select("attachment_id, maintenance_id, maintenance_record_id, file_url, file_name, created_at").
+		Where("maintenance_id = ? AND user_client_id = ? AND deleted_at IS NULL", maintenanceID, clientID).
+		Order("attachment_id ASC").
+		Scan(&attachments).Error
+	if err != nil {
+		log.Error().Uint("maintenanceID", maintenanceID).Err(err).Msg("❌ Failed to fetch maintenance attachments")
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r assetMaintenanceAttachmentRepository) GetAttachmentsByClientID(clientID string) ([]model.AssetMaintenanceAttachment, error) {
+	var attachments []model.AssetMaintenanceAttachment
+	err := r.db.Table(utils.TableAssetMaintenanceAttachmentName).
+		Where("user_client_id = ? AND deleted_at IS NULL", clientID).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r assetMaintenanceAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID uint, deletedBy string) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceAttachmentName).
+		Where("attachment_id = ? AND deleted_at IS NULL", attachmentID).
+		Updates(map[string]interface{}{"deleted_by": deletedBy, "deleted_at": time.Now()}).Error
+	if err != nil {
+		log.Error().Uint("attachmentID", attachmentID).Err(err).Msg("❌ Failed to delete maintenance attachment")
+		return err
+	}
+	return nil
+}
+
+// DeleteAttachmentsByAssetID soft deletes every attachment of the asset's maintenance when the asset goes to the
+// trash, the files are kept until the asset is purged
+func (r assetMaintenanceAttachmentRepository) DeleteAttachmentsByAssetID(ctx context.Context, assetID uint, deletedBy string, deletedAt time.Time) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceAttachmentName).
+		Where("asset_id = ? AND deleted_at IS NULL", assetID).
+		Updates(map[string]interface{}{"deleted_by": deletedBy, "deleted_at": deletedAt}).Error
+	if err != nil {
+		log.Error().Uint("assetID", assetID).Err(err).Msg("❌ Failed to delete maintenance attachments")
+		return err
+	}
+	return nil
+}
+
+// GetDeletedAttachments lists the deleted attachments whose files have not been purged yet. The attachments of
+// an asset in the trash are left to the purge of the asset, they come back when it is restored
+func (r assetMaintenanceAttachmentRepository) GetDeletedAttachments() ([]model.AssetMaintenanceAttachment, error) {
+	var attachments []model.AssetMaintenanceAttachment
+	err := r.db.Unscoped().Table(utils.TableAssetMaintenanceAttachmentName).
+		Where(`deleted_at IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM asset a WHERE a.asset_id = asset_maintenance_attachment.asset_id AND a.deleted_at IS NOT NULL)`).
+		Order("user_client_id ASC, attachment_id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// PurgeAttachments removes the rows once their files were handed over for deletion
+func (r assetMaintenanceAttachmentRepository) PurgeAttachments(ctx context.Context, attachmentIDs []uint) error {
+	if len(attachmentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Table(utils.TableAssetMaintenanceAttachmentName).
+		Where("attachment_id IN ? AND deleted_at IS NOT NULL", attachmentIDs).
+		Delete(&model.AssetMaintenanceAttachment{}).Error
+}
+
+// recordAttachments groups the live attachments of the given maintenance records by record id
+func recordAttachments(db *gorm.DB, recordIDs []uint) (map[uint][]response.AssetMaintenanceAttachmentResponse, error) {
+	grouped := make(map[uint][]response.AssetMaintenanceAttachmentResponse)
+	if len(recordIDs) == 0 {
+		return grouped, nil
+	}
+
+	var attachments []response.AssetMaintenanceAttachmentResponse
+	err := db.Table(utils.TableAssetMaintenanceAttachmentName).
+		Select("attachment_id, maintenance_id, maintenance_record_id, file_url, file_name, created_at").
+		Where("maintenance_record_id IN ? AND deleted_at IS NULL", recordIDs).
+		Order("attachment_id ASC").
+		Scan(&attachments).Error
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to fetch maintenance record attachments")
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		grouped[*attachment.MaintenanceRecordID] = append(grouped[*attachment.MaintenanceRecordID], attachment)
+	}
+	return grouped, nil
+}
@@ -0,0 +1,113 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// maintenanceCostGroups maps the group_by values to their id and name columns
+var maintenanceCostGroups = map[string][2]string{
+	request.MaintenanceCostGroupByAsset:    {"a.asset_id", "a.name"},
+	request.MaintenanceCostGroupByCategory: {"c.asset_category_id", "c.category_name"},
+	request.MaintenanceCostGroupByType:     {"amt.maintenance_type_id", "COALESCE(amt.maintenance_type_name, '')"},
+}
+
+// maintenanceCostPeriods maps the period values to the label of the period a maintenance date falls in
+var maintenanceCostPeriods = map[string]string{
+	request.MaintenanceCostPeriodMonth:   `to_char(amr.maintenance_date, 'YYYY-MM')`,
+	request.MaintenanceCostPeriodQuarter: `to_char(amr.maintenance_date, 'YYYY-"Q"Q')`,
+}
+
+type AssetMaintenanceCostRepository interface {
+	GetMaintenanceCosts(clientID string, costQuery request.MaintenanceCostQueryRequest) ([]response.MaintenanceCostRowResponse, error)
+}
+
+type assetMaintenanceCostRepository struct {
+	db gorm.DB
+}
+
+func NewAssetMaintenanceCostRepository(db gorm.DB) AssetMaintenanceCostRepository {
+	return assetMaintenanceCostRepository{db: db}
+}
+
+// GetMaintenanceCosts sums the maintenance record costs of the caller's assets, or of the assets of
+// costQuery.AssetGroupID when set, per costQuery.Period and costQuery.GroupBy
+func (r assetMaintenanceCostRepository) GetMaintenanceCosts(clientID string, costQuery request.MaintenanceCostQueryRequest) ([]response.MaintenanceCostRowResponse, error) {
+	var conditions []string
+	var args []interface{}
+	if costQuery.AssetGroupID != nil {
+		conditions = append(conditions, "a.asset_id IN (SELECT aga.asset_id FROM asset_group_asset aga WHERE aga.asset_group_id = ? AND aga.deleted_at IS NULL)")
+		args = append(args, *costQuery.AssetGroupID)
+	} else {
+		conditions = append(conditions, "a.user_client_id = ?")
+		args = append(args, clientID)
+	}
+	conditions = append(conditions, "a.deleted_at IS NULL", "amr.deleted_at IS NULL")
+
+	if costQuery.AssetID != nil {
+		conditions = append(conditions, "a.asset_id = ?")
+		args = append(args, *costQuery.AssetID)
+	}
+	if costQuery.CategoryID != nil {
+		conditions = append(conditions, "a.category_id = ?")
+		args = append(args, *costQuery.CategoryID)
+	}
+	if costQuery.MaintenanceTypeID != nil {
+		conditions = append(conditions, "amr.maintenance_type_id = ?")
+		args = append(args, *costQuery.MaintenanceTypeID)
+	}
+	if costQuery.DateFrom != nil {
+		conditions = append(conditions, "amr.maintenance_date >= ?")
+		args = append(args, *costQuery.DateFrom)
+	}
+	if costQuery.DateTo != nil {
+		conditions = append(conditions, "amr.maintenance_date <= ?")
+		args = append(args, *costQuery.DateTo)
+	}
+
+	period, groupID, groupName := "''", "NULL::int", "''"
+	var groupBy, orderBy []string
+	if expression, ok := maintenanceCostPeriods[costQuery.Period]; ok {
+		period = expression
+		groupBy = append(groupBy, "1")
+		orderBy = append(orderBy, "period ASC")
+	}
+	if columns, ok := maintenanceCostGroups[costQuery.GroupBy]; ok {
+		groupID, groupName = columns[0], columns[1]
+		groupBy = append(groupBy, "2", "3")
+	}
+	orderBy = append(orderBy, "total_cost DESC", "group_name ASC")
+
+	query := `
+		SELECT
+			` + period + ` AS period,
+			` + groupID + ` AS group_id,
+			` + groupName + ` AS group_name,
+			COUNT(*) AS record_count,
+			COALESCE(SUM(amr.maintenance_cost), 0) AS total_cost,
+			COALESCE(AVG(amr.maintenance_cost), 0) AS average_cost
+		FROM asset_maintenance_record amr
+		JOIN asset a ON amr.asset_id = a.asset_id
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		LEFT JOIN asset_maintenance_type amt ON amr.maintenance_type_id = amt.maintenance_type_id
+		WHERE ` + strings.Join(conditions, " AND ")
+	if len(groupBy) > 0 {
+		query += `
+		GROUP BY ` + strings.Join(groupBy, ", ")
+	} else {
+		query += `
+		HAVING COUNT(*) > 0`
+	}
+	query += `
+		ORDER BY ` + strings.Join(orderBy, ", ")
+
+	var rows []response.MaintenanceCostRowResponse
+	if err := r.db.Raw(query, args...).Scan(&rows).Error; err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to aggregate maintenance costs")
+		return nil, err
+	}
+	return rows, nil
+}
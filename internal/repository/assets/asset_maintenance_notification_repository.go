@@ -0,0 +1,44 @@
+package assets
+
+import (
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssetMaintenanceNotificationRepository interface {
+	AddMaintenanceNotification(notification *assets.AssetMaintenanceNotification) (bool, error)
+	DeleteMaintenanceNotifications(notificationIDs []uint) error
+}
+
+type assetMaintenanceNotificationRepository struct {
+	db gorm.DB
+}
+
+func NewAssetMaintenanceNotificationRepository(db gorm.DB) AssetMaintenanceNotificationRepository {
+	return assetMaintenanceNotificationRepository{db: db}
+}
+
+// AddMaintenanceNotification records the notification and reports false when the same
+// schedule, due date and status was already notified
+func (r assetMaintenanceNotificationRepository) AddMaintenanceNotification(notification *assets.AssetMaintenanceNotification) (bool, error) {
+	result := r.db.Table(utils.TableAssetMaintenanceNotificationName).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteMaintenanceNotifications forgets notifications whose event could not be published, so the next check
+// reports them again
+func (r assetMaintenanceNotificationRepository) DeleteMaintenanceNotifications(notificationIDs []uint) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	return r.db.Table(utils.TableAssetMaintenanceNotificationName).
+		Where("notification_id IN ?", notificationIDs).
+		Delete(&assets.AssetMaintenanceNotification{}).Error
+}
@@ -5,23 +5,25 @@ import (
 	response "asset-service/internal/dto/out/assets"
 	model "asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"fmt"
 	"gorm.io/gorm"
 	"time"
 )
 
 type AssetMaintenanceRecordRepository interface {
-	AddAssetMaintenanceRecord(maintenance *model.AssetMaintenanceRecord) error
+	AddAssetMaintenanceRecord(ctx context.Context, maintenance *model.AssetMaintenanceRecord) error
 	GetCountTotalMaintenanceRecordByAssetID(assetID uint, clientID string) (int64, error)
 	GetMaintenanceRecordByAssetID(assetID uint, clientID string) (*model.AssetMaintenanceRecord, error)
+	GetMaintenanceRecordByID(maintenanceRecordID uint, clientID string) (*model.AssetMaintenanceRecord, error)
 	GetMaintenanceRecordByMaintenanceID(maintenanceID uint, clientID string) (*response.AssetMaintenancesResponse, error)
 	GetListMaintenanceRecordByAssetIDAndMaintenanceID(assetID, maintenanceID uint, clientID string) (*[]out.AssetMaintenanceRecordResponse, error)
 	GetMaintenanceRecordByRecordIDAndAssetIDAndMaintenanceID(maintenanceRecordID, assetID, maintenanceID uint, clientID string) (interface{}, error)
 	GetListMaintenance() ([]response.AssetMaintenancesResponse, error)
 	GetListMaintenanceByClientID(clientID string) ([]response.AssetMaintenancesResponse, error)
 	GetListMaintenanceRecordByAssetID(assetID uint, clientID string) (*[]out.AssetMaintenanceRecordResponse, error)
-	Update(maintenance *model.AssetMaintenanceRecord) error
-	Delete(assetID uint) error
+	Update(ctx context.Context, maintenance *model.AssetMaintenanceRecord) error
+	Delete(ctx context.Context, assetID uint, deletedBy string, deletedAt time.Time) error
 	GetMaintenanceByTypeExist(clientID string, assetID int, typeID int) (model.AssetMaintenanceRecord, error)
 }
 
@@ -33,8 +35,8 @@ func NewAssetMaintenanceRecordRepository(db gorm.DB) AssetMaintenanceRecordRepos
 	return assetMaintenanceRecordRepository{db: db}
 }
 
-func (r assetMaintenanceRecordRepository) AddAssetMaintenanceRecord(maintenance *model.AssetMaintenanceRecord) error {
-	return r.db.Table(utils.TableAssetMaintenanceRecordName).Create(maintenance).Error
+func (r assetMaintenanceRecordRepository) AddAssetMaintenanceRecord(ctx context.Context, maintenance *model.AssetMaintenanceRecord) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceRecordName).Create(maintenance).Error
 }
 
 func (r assetMaintenanceRecordRepository) GetCountTotalMaintenanceRecordByAssetID(assetID uint, clientID string) (int64, error) {
@@ -53,6 +55,15 @@ func (r assetMaintenanceRecordRepository) GetMaintenanceRecordByAssetID(assetID
 	return &maintenance, err
 }
 
+func (r assetMaintenanceRecordRepository) GetMaintenanceRecordByID(maintenanceRecordID uint, clientID string) (*model.AssetMaintenanceRecord, error) {
+	var maintenance model.AssetMaintenanceRecord
+	err := r.db.Table(utils.TableAssetMaintenanceRecordName).Where("maintenance_record_id = ? AND user_client_id = ?", maintenanceRecordID, clientID).First(&maintenance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &maintenance, nil
+}
+
 func (r assetMaintenanceRecordRepository) GetMaintenanceRecordByMaintenanceID(maintenanceID uint, clientID string) (*response.AssetMaintenancesResponse, error) {
 	assetMaintenance := `
 		SELECT am.id, am.user_client_id, am.asset_id, amt.maintenance_type_id, amt.maintenance_type_name, am.maintenance_date, am.maintenance_details, am.maintenance_cost, am.performed_by, am.interval_days, am.next_due_date
@@ -135,6 +146,10 @@ func (r assetMaintenanceRecordRepository) GetListMaintenanceRecordByAssetIDAndMa
 		})
 	}
 
+	if err := r.withAttachments(result); err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
 
@@ -267,18 +282,22 @@ func (r assetMaintenanceRecordRepository) GetListMaintenanceRecordByAssetID(asse
 		})
 	}
 
+	if err := r.withAttachments(result); err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
 
-func (r assetMaintenanceRecordRepository) Update(maintenance *model.AssetMaintenanceRecord) error {
-	return r.db.Table(utils.TableAssetMaintenanceRecordName).Save(maintenance).Error
+func (r assetMaintenanceRecordRepository) Update(ctx context.Context, maintenance *model.AssetMaintenanceRecord) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceRecordName).Save(maintenance).Error
 }
 
-func (r assetMaintenanceRecordRepository) Delete(assetID uint) error {
+func (r assetMaintenanceRecordRepository) Delete(ctx context.Context, assetID uint, deletedBy string, deletedAt time.Time) error {
 	if assetID != 0 { // Ensure it exists before deleting
-		if err := r.db.Unscoped().Table("asset_maintenance_record").Model(&model.AssetMaintenanceRecord{}).
+		if err := r.db.WithContext(ctx).Table("asset_maintenance_record").Model(&model.AssetMaintenanceRecord{}).
 			Where("asset_id = ?", assetID).
-			Delete(&model.AssetMaintenanceRecord{}).Error; err != nil {
+			Updates(map[string]interface{}{"deleted_by": deletedBy, "deleted_at": deletedAt}).Error; err != nil {
 			return fmt.Errorf("failed to delete asset maintenance record: %w", err)
 		}
 	}
@@ -337,5 +356,26 @@ func (r assetMaintenanceRecordRepository) GetMaintenanceRecordByRecordIDAndAsset
 		})
 	}
 
+	if err := r.withAttachments(result); err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
+
+// withAttachments fills in the files attached to each record
+func (r assetMaintenanceRecordRepository) withAttachments(records []out.AssetMaintenanceRecordResponse) error {
+	recordIDs := make([]uint, 0, len(records))
+	for _, record := range records {
+		recordIDs = append(recordIDs, record.MaintenanceRecordID)
+	}
+
+	attachments, err := recordAttachments(&r.db, recordIDs)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		records[i].Attachments = attachments[records[i].MaintenanceRecordID]
+	}
+	return nil
+}
@@ -1,24 +1,28 @@
 package assets
 
 import (
+	request "asset-service/internal/dto/in/assets"
 	response "asset-service/internal/dto/out/assets"
 	model "asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"fmt"
 	"gorm.io/gorm"
 	"time"
 )
 
 type AssetMaintenanceRepository interface {
-	AddAssetMaintenance(maintenance *model.AssetMaintenance) error
+	AddAssetMaintenance(ctx context.Context, maintenance *model.AssetMaintenance) error
 	GetMaintenanceByAssetID(assetID uint, clientID string) (*model.AssetMaintenance, error)
 	GetMaintenanceByMaintenanceIDAndAssetID(maintenanceID uint, assetID uint, clientID string) (*model.AssetMaintenance, error)
 	GetMaintenanceResponseByID(maintenanceID uint, clientID string) (*response.AssetMaintenancesResponse, error)
 	GetListMaintenanceByAssetID(assetID uint, clientID string) ([]response.AssetMaintenancesResponse, error)
 	GetListMaintenance() ([]response.AssetMaintenancesResponse, error)
 	GetListMaintenanceByClientID(clientID string) ([]response.AssetMaintenancesResponse, error)
-	Update(maintenance *model.AssetMaintenance) error
-	Delete(assetID uint, fullName string) error
+	GetListMaintenanceDue(until time.Time) ([]response.AssetMaintenanceDueResponse, error)
+	GetListMaintenanceDueByScope(clientID string, assetGroupID uint, until time.Time) ([]response.AssetMaintenanceDueResponse, error)
+	Update(ctx context.Context, maintenance *model.AssetMaintenance) error
+	Delete(ctx context.Context, assetID uint, fullName string, deletedAt time.Time) error
 	GetMaintenanceByTypeExist(clientID string, assetID int, typeID int) (model.AssetMaintenance, error)
 }
 
@@ -31,8 +35,8 @@ func NewAssetMaintenanceRepository(db gorm.DB) AssetMaintenanceRepository {
 	return assetMaintenanceRepository{db: db}
 }
 
-func (r assetMaintenanceRepository) AddAssetMaintenance(maintenance *model.AssetMaintenance) error {
-	return r.db.Table(utils.TableAssetMaintenanceName).Create(maintenance).Error
+func (r assetMaintenanceRepository) AddAssetMaintenance(ctx context.Context, maintenance *model.AssetMaintenance) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceName).Create(maintenance).Error
 }
 
 func (r assetMaintenanceRepository) GetMaintenanceByAssetID(assetID uint, clientID string) (*model.AssetMaintenance, error) {
@@ -209,16 +213,55 @@ func (r assetMaintenanceRepository) GetListMaintenanceByClientID(clientID string
 	return result, nil
 }
 
-func (r assetMaintenanceRepository) Update(maintenance *model.AssetMaintenance) error {
-	return r.db.Table(utils.TableAssetMaintenanceName).Save(maintenance).Error
+// GetListMaintenanceDue returns every active schedule due on or before until, overdue ones included
+func (r assetMaintenanceRepository) GetListMaintenanceDue(until time.Time) ([]response.AssetMaintenanceDueResponse, error) {
+	query := `
+		SELECT am.id, am.user_client_id, am.asset_id, a.name AS asset_name,
+			COALESCE(amt.maintenance_type_name, '') AS maintenance_type_name, am.next_due_date
+		FROM "asset_maintenance" am
+		JOIN "asset" a ON am.asset_id = a.asset_id AND a.deleted_at IS NULL
+		LEFT JOIN "asset_maintenance_type" amt ON am.maintenance_type_id = amt.maintenance_type_id
+		WHERE am.deleted_at IS NULL AND am.next_due_date IS NOT NULL AND am.next_due_date <= ?
+		ORDER BY am.user_client_id, am.next_due_date
+	`
+
+	var result []response.AssetMaintenanceDueResponse
+	if err := r.db.Raw(query, until).Scan(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetListMaintenanceDueByScope lists the schedules due by until, overdue ones included, of the assets of the user
+// or of the asset group when assetGroupID is set
+func (r assetMaintenanceRepository) GetListMaintenanceDueByScope(clientID string, assetGroupID uint, until time.Time) ([]response.AssetMaintenanceDueResponse, error) {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, request.AssetQueryRequest{})
+	query := `
+		SELECT am.id, am.user_client_id, am.asset_id, a.name AS asset_name,
+			COALESCE(amt.maintenance_type_name, '') AS maintenance_type_name, am.next_due_date
+		FROM "asset_maintenance" am
+		JOIN "asset" a ON am.asset_id = a.asset_id
+		LEFT JOIN "asset_maintenance_type" amt ON am.maintenance_type_id = amt.maintenance_type_id
+		WHERE am.deleted_at IS NULL AND am.next_due_date IS NOT NULL AND am.next_due_date <= ? AND ` + scope + `
+		ORDER BY am.next_due_date, am.id
+	`
+
+	var result []response.AssetMaintenanceDueResponse
+	if err := r.db.Raw(query, append([]interface{}{until}, args...)...).Scan(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r assetMaintenanceRepository) Update(ctx context.Context, maintenance *model.AssetMaintenance) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceName).Save(maintenance).Error
 }
 
-func (r assetMaintenanceRepository) Delete(assetID uint, fullName string) error {
+func (r assetMaintenanceRepository) Delete(ctx context.Context, assetID uint, fullName string, deletedAt time.Time) error {
 	if assetID != 0 { // Ensure it exists before deleting
-		if err := r.db.Table("asset_maintenance").Model(model.AssetMaintenance{}).
+		if err := r.db.WithContext(ctx).Table("asset_maintenance").Model(model.AssetMaintenance{}).
 			Where("asset_id = ?", assetID).
-			Updates(map[string]interface{}{"deleted_by": fullName, "deleted_at": time.Now()}).
-			Delete(&model.AssetMaintenance{}).Error; err != nil {
+			Updates(map[string]interface{}{"deleted_by": fullName, "deleted_at": deletedAt}).Error; err != nil {
 			return fmt.Errorf("failed to delete asset maintenance: %w", err)
 		}
 	}
@@ -3,18 +3,24 @@ package assets
 import (
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"time"
 )
 
 // AssetMaintenanceTypeRepository defines the interface for managing asset maintenance types
 type AssetMaintenanceTypeRepository interface {
 	GetAssetMaintenanceTypeByName(name string, clientID string) (*assets.AssetMaintenanceType, error)
-	AddAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error
+	AddAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error
 	GetAssetMaintenanceType(clientID string) ([]assets.AssetMaintenanceType, error)
 	GetAssetMaintenanceTypeByID(assetMaintenanceTypeID uint, clientID string) (*assets.AssetMaintenanceType, error)
-	UpdateAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error
-	DeleteAssetMaintenanceTypeByID(assetMaintenanceTypeID uint, clientID string) error
-	DeleteAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error
+	MaintenanceTypeNameExists(name string, clientID string, isDefault bool, excludeMaintenanceTypeID uint) (bool, error)
+	UpdateAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType) error
+	DeleteMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, reassignTo *uint) error
+	DeleteAssetMaintenanceTypeByID(ctx context.Context, assetMaintenanceTypeID uint, clientID string) error
+	DeleteAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error
 	GetAssetMaintenanceTypeByType(maintenanceType string, clientID string) (*assets.AssetMaintenanceType, error)
 	GetAssetMaintenanceTypeByTypeAndID(maintenanceType string, maintenanceTypeID uint, clientID string) (*assets.AssetMaintenanceType, error)
 	GetAssetMaintenanceTypeByTypeNotExist(maintenanceType string, clientID string) (*assets.AssetMaintenanceType, error)
@@ -41,40 +47,118 @@ func (r assetMaintenanceTypeRepository) GetAssetMaintenanceTypeByName(name strin
 	return &assetMaintenanceType, err
 }
 
-func (r assetMaintenanceTypeRepository) AddAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error {
+func (r assetMaintenanceTypeRepository) AddAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error {
 	assetMaintenanceType.UserClientID = clientID
-	return r.db.Table(utils.TableAssetMaintenanceTypeName).Create(assetMaintenanceType).Error
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceTypeName).Create(assetMaintenanceType).Error
 }
 
+// GetAssetMaintenanceType lists the maintenance types of the user followed by the default types
 func (r assetMaintenanceTypeRepository) GetAssetMaintenanceType(clientID string) ([]assets.AssetMaintenanceType, error) {
 	var assetMaintenanceTypes []assets.AssetMaintenanceType
 	err := r.db.Table(utils.TableAssetMaintenanceTypeName).
-		Where("user_client_id = ? AND deleted_at IS NULL", clientID).
+		Where("(user_client_id = ? OR is_default = TRUE) AND deleted_at IS NULL", clientID).
+		Order("is_default ASC, maintenance_type_name ASC").
 		Find(&assetMaintenanceTypes).Error
 	return assetMaintenanceTypes, err
 }
 
+// GetAssetMaintenanceTypeByID returns a maintenance type of the user or a default type
 func (r assetMaintenanceTypeRepository) GetAssetMaintenanceTypeByID(assetMaintenanceTypeID uint, clientID string) (*assets.AssetMaintenanceType, error) {
 	var assetMaintenanceType assets.AssetMaintenanceType
 	err := r.db.Table(utils.TableAssetMaintenanceTypeName).
-		Where("maintenance_type_id = ? AND user_client_id = ?", assetMaintenanceTypeID, clientID).
+		Where("maintenance_type_id = ? AND (user_client_id = ? OR is_default = TRUE) AND deleted_at IS NULL", assetMaintenanceTypeID, clientID).
 		First(&assetMaintenanceType).Error
 	return &assetMaintenanceType, err
 }
 
-func (r assetMaintenanceTypeRepository) UpdateAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error {
-	assetMaintenanceType.UserClientID = clientID
-	return r.db.Table(utils.TableAssetMaintenanceTypeName).Save(assetMaintenanceType).Error
-}
-
-func (r assetMaintenanceTypeRepository) DeleteAssetMaintenanceTypeByID(assetMaintenanceTypeID uint, clientID string) error {
-	return r.db.Table(utils.TableAssetMaintenanceTypeName).
+// MaintenanceTypeNameExists checks the name case-insensitively among the types of the user, or among the default
+// types when isDefault is set
+func (r assetMaintenanceTypeRepository) MaintenanceTypeNameExists(name string, clientID string, isDefault bool, excludeMaintenanceTypeID uint) (bool, error) {
+	query := r.db.Table(utils.TableAssetMaintenanceTypeName).
+		Where("LOWER(maintenance_type_name) = LOWER(?) AND maintenance_type_id <> ? AND deleted_at IS NULL", name, excludeMaintenanceTypeID)
+	if isDefault {
+		query = query.Where("is_default = TRUE")
+	} else {
+		query = query.Where("user_client_id = ? AND is_default = FALSE", clientID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r assetMaintenanceTypeRepository) UpdateAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceTypeName).
+		Where("maintenance_type_id = ? AND deleted_at IS NULL", assetMaintenanceType.MaintenanceTypeID).
+		Updates(map[string]interface{}{
+			"maintenance_type_name": assetMaintenanceType.MaintenanceTypeName,
+			"description":           assetMaintenanceType.Description,
+			"updated_by":            assetMaintenanceType.UpdatedBy,
+			"updated_at":            time.Now(),
+		}).Error
+	if err != nil {
+		log.Error().Uint("maintenanceTypeID", assetMaintenanceType.MaintenanceTypeID).Err(err).Msg("❌ Failed to update maintenance type")
+		return err
+	}
+
+	log.Info().Uint("maintenanceTypeID", assetMaintenanceType.MaintenanceTypeID).Msg("✅ Maintenance type updated successfully")
+	return nil
+}
+
+// DeleteMaintenanceType soft deletes the maintenance type. Maintenance schedules and records still using it are moved
+// to reassignTo, without reassignTo a type that is still in use is kept.
+func (r assetMaintenanceTypeRepository) DeleteMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, reassignTo *uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var used int64
+		if err := tx.Table(utils.TableAssetMaintenanceName).
+			Where("maintenance_type_id = ? AND deleted_at IS NULL", assetMaintenanceType.MaintenanceTypeID).
+			Count(&used).Error; err != nil {
+			return err
+		}
+
+		if reassignTo == nil {
+			if used > 0 {
+				return fmt.Errorf("maintenance type is still used by %d maintenance schedules, reassign them to another type", used)
+			}
+		} else {
+			for _, table := range []string{utils.TableAssetMaintenanceName, utils.TableAssetMaintenanceRecordName} {
+				if err := tx.Table(table).
+					Where("maintenance_type_id = ?", assetMaintenanceType.MaintenanceTypeID).
+					Updates(map[string]interface{}{
+						"maintenance_type_id": *reassignTo,
+						"updated_by":          assetMaintenanceType.DeletedBy,
+					}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Table(utils.TableAssetMaintenanceTypeName).
+			Where("maintenance_type_id = ?", assetMaintenanceType.MaintenanceTypeID).
+			Updates(map[string]interface{}{
+				"deleted_by": assetMaintenanceType.DeletedBy,
+				"deleted_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		log.Error().Uint("maintenanceTypeID", assetMaintenanceType.MaintenanceTypeID).Err(err).Msg("❌ Failed to delete maintenance type")
+		return err
+	}
+
+	log.Info().Uint("maintenanceTypeID", assetMaintenanceType.MaintenanceTypeID).Msg("✅ Maintenance type deleted successfully")
+	return nil
+}
+
+func (r assetMaintenanceTypeRepository) DeleteAssetMaintenanceTypeByID(ctx context.Context, assetMaintenanceTypeID uint, clientID string) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceTypeName).
 		Where("maintenance_type_id = ? AND user_client_id = ?", assetMaintenanceTypeID, clientID).
 		Delete(&assets.AssetMaintenanceType{}).Error
 }
 
-func (r assetMaintenanceTypeRepository) DeleteAssetMaintenanceType(assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error {
-	return r.db.Table(utils.TableAssetMaintenanceTypeName).
+func (r assetMaintenanceTypeRepository) DeleteAssetMaintenanceType(ctx context.Context, assetMaintenanceType *assets.AssetMaintenanceType, clientID string) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetMaintenanceTypeName).
 		Where("user_client_id = ?", clientID).
 		Delete(assetMaintenanceType).Error
 }
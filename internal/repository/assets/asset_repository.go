@@ -1,50 +1,64 @@
 package assets
 
 import (
+	request "asset-service/internal/dto/in/assets"
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"asset-service/internal/utils/customfield"
+	"asset-service/internal/utils/depreciation"
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"strings"
 	"time"
 )
 
 type AssetRepository interface {
-	AddAsset(asset *assets.Asset, images []response.AssetImageResponse) error
-	AddAssetFromWishlist(asset *assets.Asset, assetWishlist *assets.AssetWishlist, images []response.AssetImageResponse) error
+	AddAsset(ctx context.Context, asset *assets.Asset, images []response.AssetImageResponse) error
+	AddAssetFromWishlist(ctx context.Context, asset *assets.Asset, assetWishlist *assets.AssetWishlist, images []response.AssetImageResponse) error
+	ImportAssets(ctx context.Context, assetList []*assets.Asset, assetGroup *assets.AssetGroupAsset) error
 	GetAssetByNameAndClientID(name string, clientID string) (*assets.Asset, error)
 	AssetNameExists(name string, clientID string) (bool, error)
+	AssetBarcodeExists(barcode string, clientID string, excludeAssetID uint) (bool, error)
+	AssetSerialNumberExists(serialNumber string, clientID string, excludeAssetID uint) (bool, error)
+	GetAssetByIdentifier(clientID string, userID uint, barcode, serialNumber string) (*assets.Asset, error)
+	GetAssetSharedSince(assetID, userID uint) (*time.Time, error)
 	GetAsset(assetID uint, clientID string) (*assets.Asset, error)
 	GetAssetByAssetGroupID(assetID, assetGroupID uint) (*assets.Asset, error)
-	GetCountAsset(clientID string) (int64, error)
-	GetListAssets(clientID string, index int, size int) ([]response.AssetResponse, error)
-	GetListAssetsByAssetGroup(clientID string, assetGroupID uint, pageIndex, pageSize int) ([]response.AssetResponse, error)
-	GetCountListAssetsByAssetGroup(clientID string, assetGroupID uint) (int64, error)
+	GetCountAsset(clientID string, assetQuery request.AssetQueryRequest) (int64, error)
+	GetListAssets(clientID string, index int, size int, assetQuery request.AssetQueryRequest) ([]response.AssetResponse, error)
+	GetListAssetsByAssetGroup(clientID string, assetGroupID uint, pageIndex, pageSize int, assetQuery request.AssetQueryRequest) ([]response.AssetResponse, error)
+	GetCountListAssetsByAssetGroup(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest) (int64, error)
 	GetAssetResponseByID(clientID string, id uint) (*response.AssetResponse, error)
 	GetAssetByID(clientID string, id uint) (*assets.Asset, error)
-	UpdateAsset(asset *assets.Asset, clientID string) error
-	UpdateMaintenanceDateAsset(assetID uint, maintenanceDate *time.Time, clientID string) error
-	UpdateAssetStatus(assetID uint, statusID uint, clientID string) (*assets.Asset, error)
-	UpdateAssetCategory(assetID uint, categoryID uint, clientID string) (*assets.Asset, error)
-	DeleteAsset(id uint, clientID string) error
+	UpdateAsset(ctx context.Context, asset *assets.Asset, clientID string) error
+	RevertAsset(ctx context.Context, asset *assets.Asset, clientID string) error
+	UpdateMaintenanceDateAsset(ctx context.Context, assetID uint, maintenanceDate *time.Time, clientID string) error
+	UpdateAssetStatus(ctx context.Context, assetID uint, statusID uint, clientID string) (*assets.Asset, error)
+	UpdateAssetCategory(ctx context.Context, assetID uint, categoryID uint, clientID string) (*assets.Asset, error)
+	DeleteAsset(ctx context.Context, id uint, clientID string, deletedAt time.Time) error
 	GetAssetByIDForMaintenance(id uint, clientID string) (*assets.Asset, error)
 	GetAssetByCategoryID(assetCategoryID uint, clientID string) ([]assets.Asset, error)
-	GetAssetDeleted() ([]assets.Asset, error)
+	GetCountAssetByStatus(clientID string, assetGroupID uint) ([]response.AssetDashboardCountResponse, error)
+	GetCountAssetByCategory(clientID string, assetGroupID uint) ([]response.AssetDashboardCountResponse, error)
+	GetListWarrantyExpiring(clientID string, assetGroupID uint, from, until time.Time) ([]response.AssetDashboardWarrantyResponse, error)
 }
 
 type assetRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetRepository(db gorm.DB, audit AssetAuditLogRepository) AssetRepository {
-	return assetRepository{db: db, audit: audit}
+func NewAssetRepository(db gorm.DB) AssetRepository {
+	return assetRepository{db: db}
 }
 
-func (r assetRepository) AddAsset(asset *assets.Asset, images []response.AssetImageResponse) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetRepository) AddAsset(ctx context.Context, asset *assets.Asset, images []response.AssetImageResponse) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetName).Create(&asset).Error; err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to create assets: %w", err)
@@ -87,8 +101,8 @@ func (r assetRepository) AddAsset(asset *assets.Asset, images []response.AssetIm
 	})
 }
 
-func (r assetRepository) AddAssetFromWishlist(asset *assets.Asset, assetWishlist *assets.AssetWishlist, images []response.AssetImageResponse) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r assetRepository) AddAssetFromWishlist(ctx context.Context, asset *assets.Asset, assetWishlist *assets.AssetWishlist, images []response.AssetImageResponse) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Table(utils.TableAssetName).Create(&asset).Error; err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to create assets: %w", err)
@@ -137,6 +151,44 @@ func (r assetRepository) AddAssetFromWishlist(asset *assets.Asset, assetWishlist
 	})
 }
 
+// ImportAssets creates every asset with its initial stock in a single transaction,
+// when assetGroup is set each asset is also shared with that group
+func (r assetRepository) ImportAssets(ctx context.Context, assetList []*assets.Asset, assetGroup *assets.AssetGroupAsset) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, asset := range assetList {
+			if err := tx.Table(utils.TableAssetName).Create(asset).Error; err != nil {
+				return fmt.Errorf("failed to create asset %s: %w", asset.Name, err)
+			}
+
+			assetStock := &assets.AssetStock{
+				AssetID:         asset.AssetID,
+				UserClientID:    asset.UserClientID,
+				InitialQuantity: asset.Stock,
+				LatestQuantity:  asset.Stock,
+				ChangeType:      "INCREASE",
+				Quantity:        asset.Stock,
+				CreatedBy:       asset.CreatedBy,
+			}
+			if err := tx.Table(utils.TableAssetStockName).Create(assetStock).Error; err != nil {
+				return fmt.Errorf("failed to create asset stock %s: %w", asset.Name, err)
+			}
+
+			if assetGroup != nil {
+				assetGroupAsset := &assets.AssetGroupAsset{
+					AssetID:      asset.AssetID,
+					AssetGroupID: assetGroup.AssetGroupID,
+					UserID:       assetGroup.UserID,
+					CreatedBy:    assetGroup.CreatedBy,
+				}
+				if err := tx.Table(utils.TableAssetGroupAssetName).Create(assetGroupAsset).Error; err != nil {
+					return fmt.Errorf("failed to create asset group asset %s: %w", asset.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
 func (r assetRepository) GetAssetByNameAndClientID(name string, clientID string) (*assets.Asset, error) {
 	var asset assets.Asset
 	err := r.db.Table(utils.TableAssetName).Where("name = ? AND user_client_id = ?", name, clientID).First(&asset).Error
@@ -157,6 +209,74 @@ func (r assetRepository) AssetNameExists(name string, clientID string) (bool, er
 	return count > 0, nil
 }
 
+func (r assetRepository) AssetBarcodeExists(barcode string, clientID string, excludeAssetID uint) (bool, error) {
+	var count int64
+	err := r.db.Table(utils.TableAssetName).
+		Where("barcode = ? AND user_client_id = ? AND asset_id <> ? AND deleted_at IS NULL", barcode, clientID, excludeAssetID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r assetRepository) AssetSerialNumberExists(serialNumber string, clientID string, excludeAssetID uint) (bool, error) {
+	var count int64
+	err := r.db.Table(utils.TableAssetName).
+		Where("serial_number = ? AND user_client_id = ? AND asset_id <> ? AND deleted_at IS NULL", serialNumber, clientID, excludeAssetID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetAssetByIdentifier resolves a barcode or serial number to an asset owned by the user or shared with one of the user's groups
+func (r assetRepository) GetAssetByIdentifier(clientID string, userID uint, barcode, serialNumber string) (*assets.Asset, error) {
+	query := r.db.Table(utils.TableAssetName).
+		Where(`deleted_at IS NULL AND (user_client_id = ? OR asset_id IN (
+			SELECT aga.asset_id
+			FROM asset_group_asset aga
+			JOIN asset_group_member agm ON agm.asset_group_id = aga.asset_group_id
+			WHERE agm.user_id = ? AND aga.deleted_at IS NULL AND agm.deleted_at IS NULL))`, clientID, userID)
+
+	if barcode != "" {
+		query = query.Where("barcode = ?", barcode)
+	} else {
+		query = query.Where("serial_number = ?", serialNumber)
+	}
+
+	var asset assets.Asset
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "user_client_id = ? DESC, asset_id ASC", Vars: []interface{}{clientID}, WithoutParentheses: true}}).
+		Take(&asset).Error
+	if err != nil {
+		log.Warn().Str("clientID", clientID).Str("barcode", barcode).Str("serial_number", serialNumber).Msg("⚠ Asset not found by identifier")
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetAssetSharedSince returns when the asset was first shared, among its current shares, with a group the user
+// owns or is a member of, nil when it is not shared with the user
+func (r assetRepository) GetAssetSharedSince(assetID, userID uint) (*time.Time, error) {
+	var since sql.NullTime
+	err := r.db.Table(utils.TableAssetGroupAssetName+" aga").
+		Select("MIN(aga.created_at)").
+		Joins("JOIN asset_group ag ON ag.asset_group_id = aga.asset_group_id").
+		Where(`aga.asset_id = ? AND aga.deleted_at IS NULL AND ag.deleted_at IS NULL AND (ag.owner_user_id = ? OR EXISTS (
+			SELECT 1 FROM asset_group_member agm
+			WHERE agm.asset_group_id = aga.asset_group_id AND agm.user_id = ? AND agm.deleted_at IS NULL))`, assetID, userID, userID).
+		Row().Scan(&since)
+	if err != nil {
+		return nil, err
+	}
+	if !since.Valid {
+		return nil, nil
+	}
+	return &since.Time, nil
+}
+
 func (r assetRepository) GetAsset(assetID uint, clientID string) (*assets.Asset, error) {
 	var asset assets.Asset
 	err := r.db.Table(utils.TableAssetName).
@@ -182,10 +302,11 @@ func (r assetRepository) GetAssetByAssetGroupID(assetID, assetGroupID uint) (*as
 	return &asset, nil
 }
 
-func (r assetRepository) GetCountAsset(clientID string) (int64, error) {
+func (r assetRepository) GetCountAsset(clientID string, query request.AssetQueryRequest) (int64, error) {
 	var count int64
-	err := r.db.Table(utils.TableAssetName).
-		Where("user_client_id = ? AND deleted_at IS NULL", clientID).
+	filter, args := buildAssetQueryFilter("a", query)
+	err := r.db.Table(utils.TableAssetName+" a").
+		Where("a.user_client_id = ? AND a.deleted_at IS NULL"+filter, append([]interface{}{clientID}, args...)...).
 		Count(&count).Error
 	if err != nil {
 		return 0, err
@@ -193,20 +314,32 @@ func (r assetRepository) GetCountAsset(clientID string) (int64, error) {
 	return count, nil
 }
 
-func (r assetRepository) GetListAssets(clientID string, index, size int) ([]response.AssetResponse, error) {
+func (r assetRepository) GetListAssets(clientID string, index, size int, assetQuery request.AssetQueryRequest) ([]response.AssetResponse, error) {
+	filter, filterArgs := buildAssetQueryFilter("a", assetQuery)
+	order, orderArgs := buildAssetQueryOrder("a", assetQuery)
+
+	args := []interface{}{clientID}
+	args = append(args, filterArgs...)
+	args = append(args, orderArgs...)
+	args = append(args, size, (index-1)*size)
+
 	query := `
 		SELECT 
 			a.asset_id, a.user_client_id, a.serial_number, a.name, a.description, a.barcode,
-			a.purchase_date, a.expiry_date, a.warranty_expiry_date, a.price, a.notes,
+			a.purchase_date, a.expiry_date, a.warranty_expiry_date, a.price, a.notes, a.custom_fields,
 			c.asset_category_id, c.category_name, c.description AS category_description,
+			c.depreciation_method, c.useful_life_months, COALESCE(c.salvage_value, 0) AS salvage_value,
 			s.asset_status_id, s.status_name, s.description AS status_description,
-			st.stock_id, st.initial_quantity, st.latest_quantity
+			st.stock_id, st.initial_quantity, st.latest_quantity,
+			l.location_id, l.name AS location_name, l.location_type,
+			a.parent_asset_id
 		FROM asset a
 		JOIN asset_category c ON a.category_id = c.asset_category_id
 		JOIN asset_status s ON a.status_id = s.asset_status_id
 		JOIN asset_stock st ON a.asset_id = st.asset_id
-		WHERE a.user_client_id = ? AND a.deleted_at IS NULL
-		ORDER BY a.created_at ASC
+		LEFT JOIN asset_location l ON a.location_id = l.location_id AND l.deleted_at IS NULL
+		WHERE a.user_client_id = ? AND a.deleted_at IS NULL` + filter + `
+		ORDER BY ` + order + `
 		LIMIT ? OFFSET ?
 	`
 
@@ -222,20 +355,28 @@ func (r assetRepository) GetListAssets(clientID string, index, size int) ([]resp
 		WarrantyExpiryRaw   *time.Time
 		Price               float64
 		Notes               *string
+		CustomFields        customfield.Values
 		AssetStatusID       uint
 		StatusName          string
 		StatusDescription   string
 		AssetCategoryID     uint
 		CategoryName        string
 		CategoryDescription string
+		DepreciationMethod  *string
+		UsefulLifeMonths    *int
+		SalvageValue        float64
 		StockID             uint
 		InitialQty          int
 		LatestQty           int
+		LocationID          *uint
+		LocationName        *string
+		LocationType        *string
+		ParentAssetID       *uint
 	}
 
 	var rows []assetRow
 
-	if err := r.db.Raw(query, clientID, size, (index-1)*size).Scan(&rows).Error; err != nil {
+	if err := r.db.Raw(query, args...).Scan(&rows).Error; err != nil {
 		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to fetch asset list")
 		return nil, err
 	}
@@ -255,15 +396,20 @@ func (r assetRepository) GetListAssets(clientID string, index, size int) ([]resp
 			WarrantyExpiryDate: utils.ToDateOnly(row.WarrantyExpiryRaw),
 			Price:              row.Price,
 			Notes:              row.Notes,
+			CustomFields:       row.CustomFields,
+			ParentAssetID:      row.ParentAssetID,
 			Status: response.AssetStatusResponse{
 				AssetStatusID: row.AssetStatusID,
 				StatusName:    row.StatusName,
 				Description:   row.StatusDescription,
 			},
 			Category: response.AssetCategoryResponse{
-				AssetCategoryID: row.AssetCategoryID,
-				CategoryName:    row.CategoryName,
-				Description:     row.CategoryDescription,
+				AssetCategoryID:    row.AssetCategoryID,
+				CategoryName:       row.CategoryName,
+				Description:        row.CategoryDescription,
+				DepreciationMethod: row.DepreciationMethod,
+				UsefulLifeMonths:   row.UsefulLifeMonths,
+				SalvageValue:       row.SalvageValue,
 			},
 			Stock: response.AssetStockResponse{
 				StockID:         row.StockID,
@@ -272,6 +418,14 @@ func (r assetRepository) GetListAssets(clientID string, index, size int) ([]resp
 				LatestQuantity:  row.LatestQty,
 			},
 		}
+		if row.LocationID != nil {
+			assetResponses[i].Location = &response.AssetLocationResponse{
+				LocationID:   *row.LocationID,
+				Name:         *row.LocationName,
+				LocationType: *row.LocationType,
+			}
+		}
+		assetResponses[i].BookValue = assetBookValue(row.Price, row.PurchaseDateRaw, assetResponses[i].Category)
 		assetIDs[i] = row.AssetID
 	}
 
@@ -294,15 +448,56 @@ func (r assetRepository) GetListAssets(clientID string, index, size int) ([]resp
 		imageMap[img.AssetID] = append(imageMap[img.AssetID], response.AssetImageResponse{ImageURL: img.ImageURL})
 	}
 
+	tagQuery := `
+		SELECT m.asset_id, t.tag_id, t.tag_name, t.description
+		FROM asset_tag_map m
+		JOIN asset_tags t ON m.tag_id = t.tag_id
+		WHERE t.user_client_id = ? AND t.deleted_at IS NULL AND m.asset_id IN ?
+		ORDER BY t.tag_name ASC
+	`
+	var tagRows []struct {
+		AssetID     uint
+		TagID       uint
+		TagName     string
+		Description *string
+	}
+	if err := r.db.Raw(tagQuery, clientID, assetIDs).Scan(&tagRows).Error; err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to fetch asset tags")
+		return nil, err
+	}
+
+	tagMap := make(map[uint][]response.AssetTagResponse)
+	for _, tag := range tagRows {
+		tagMap[tag.AssetID] = append(tagMap[tag.AssetID], response.AssetTagResponse{
+			TagID:       tag.TagID,
+			TagName:     tag.TagName,
+			Description: tag.Description,
+		})
+	}
+
 	for i := range assetResponses {
 		assetResponses[i].Images = imageMap[assetResponses[i].AssetID]
+		assetResponses[i].Tags = tagMap[assetResponses[i].AssetID]
+	}
+
+	if err := r.applyComponentRollups(assetResponses); err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to roll up asset components")
+		return nil, err
 	}
 
 	log.Info().Str("clientID", clientID).Int("assets_count", len(assetResponses)).Msg("✅ Successfully fetched asset list")
 	return assetResponses, nil
 }
 
-func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID uint, pageIndex, pageSize int) ([]response.AssetResponse, error) {
+func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID uint, pageIndex, pageSize int, assetQuery request.AssetQueryRequest) ([]response.AssetResponse, error) {
+	filter, filterArgs := buildAssetQueryFilter("asset", assetQuery)
+	order, orderArgs := buildAssetQueryOrder("asset", assetQuery)
+
+	args := []interface{}{assetGroupID}
+	args = append(args, filterArgs...)
+	args = append(args, orderArgs...)
+	args = append(args, pageSize, (pageIndex-1)*pageSize)
+
 	selectQuery := `
        SELECT 
            asset.asset_id,
@@ -316,26 +511,35 @@ func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID
            asset.warranty_expiry_date,
            asset.price,
            asset.notes,
+           asset.custom_fields,
            category.asset_category_id,
            category.category_name,
            category.description AS category_description,
+           category.depreciation_method,
+           category.useful_life_months,
+           COALESCE(category.salvage_value, 0) AS salvage_value,
            status.asset_status_id,
            status.status_name,
            status.description AS status_description,
            stock.stock_id,
            stock.initial_quantity,
-           stock.latest_quantity           
+           stock.latest_quantity,
+           location.location_id,
+           location.name AS location_name,
+           location.location_type,
+           asset.parent_asset_id
        FROM "asset" asset
        LEFT JOIN "asset_group_asset" aga ON aga.asset_id = asset.asset_id
        INNER JOIN "asset_category" category ON asset.category_id = category.asset_category_id
        INNER JOIN "asset_status" status ON asset.status_id = status.asset_status_id
        INNER JOIN "asset_stock" stock ON asset.asset_id = stock.asset_id
-       WHERE aga.asset_group_id = ? AND asset.deleted_at IS NULL
-       ORDER BY asset.created_at ASC 
+       LEFT JOIN "asset_location" location ON asset.location_id = location.location_id AND location.deleted_at IS NULL
+       WHERE aga.asset_group_id = ? AND asset.deleted_at IS NULL` + filter + `
+       ORDER BY ` + order + `
        LIMIT ? OFFSET ?;
    `
 
-	rows, err := r.db.Raw(selectQuery, assetGroupID, pageSize, (pageIndex-1)*pageSize).Rows()
+	rows, err := r.db.Raw(selectQuery, args...).Rows()
 	if err != nil {
 		log.Error().Str("assetGroupID", fmt.Sprintf("%d", assetGroupID)).Err(err).Msg("❌ Failed to fetch asset list by asset group")
 		return nil, err
@@ -357,6 +561,13 @@ func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID
 		var warrantyExpiryDate sql.NullTime
 		var price sql.NullFloat64
 		var notes sql.NullString
+		var depreciationMethod sql.NullString
+		var usefulLifeMonths sql.NullInt64
+		var customFields customfield.Values
+		var locationID sql.NullInt64
+		var locationName sql.NullString
+		var locationType sql.NullString
+		var parentAssetID sql.NullInt64
 
 		err := rows.Scan(
 			&asset.AssetID,
@@ -370,15 +581,23 @@ func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID
 			&warrantyExpiryDate,
 			&price,
 			&notes,
+			&customFields,
 			&category.AssetCategoryID,
 			&category.CategoryName,
 			&category.Description,
+			&depreciationMethod,
+			&usefulLifeMonths,
+			&category.SalvageValue,
 			&status.AssetStatusID,
 			&status.StatusName,
 			&status.Description,
 			&stock.StockID,
 			&stock.InitialQuantity,
 			&stock.LatestQuantity,
+			&locationID,
+			&locationName,
+			&locationType,
+			&parentAssetID,
 		)
 
 		if err != nil {
@@ -412,8 +631,21 @@ func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID
 			asset.WarrantyExpiryDate = (*response.DateOnly)(&warrantyExpiryDate.Time)
 		}
 
+		if depreciationMethod.Valid {
+			category.DepreciationMethod = &depreciationMethod.String
+		}
+		if usefulLifeMonths.Valid {
+			months := int(usefulLifeMonths.Int64)
+			category.UsefulLifeMonths = &months
+		}
+
+		asset.CustomFields = customFields
+		asset.Location = assetLocation(locationID, locationName, locationType)
+		asset.ParentAssetID = nullUint(parentAssetID)
+
 		// Assign category and status details
 		asset.Category = category
+		asset.BookValue = assetBookValue(asset.Price, (*time.Time)(asset.PurchaseDate), category)
 		asset.Status = status
 		asset.Stock = stock
 
@@ -445,15 +677,21 @@ func (r assetRepository) GetListAssetsByAssetGroup(clientID string, assetGroupID
 		assetsList = append(assetsList, asset)
 	}
 
+	if err := r.applyComponentRollups(assetsList); err != nil {
+		log.Error().Str("assetGroupID", fmt.Sprintf("%d", assetGroupID)).Err(err).Msg("❌ Failed to roll up asset components")
+		return nil, err
+	}
+
 	log.Info().Str("clientID", clientID).Int("assets_count", len(assetsList)).Msg("✅ Successfully fetched asset list")
 	return assetsList, nil
 }
 
-func (r assetRepository) GetCountListAssetsByAssetGroup(clientID string, assetGroupID uint) (int64, error) {
+func (r assetRepository) GetCountListAssetsByAssetGroup(clientID string, assetGroupID uint, assetQuery request.AssetQueryRequest) (int64, error) {
 	var count int64
+	filter, args := buildAssetQueryFilter("asset", assetQuery)
 	err := r.db.Table(utils.TableAssetName).
 		Joins("JOIN asset_group_asset aga ON asset.asset_id = aga.asset_id").
-		Where("user_client_id = ? AND deleted_at IS NULL AND asset_group_id = ?", clientID, assetGroupID).
+		Where("aga.asset_group_id = ? AND asset.deleted_at IS NULL"+filter, append([]interface{}{assetGroupID}, args...)...).
 		Count(&count).Error
 	if err != nil {
 		return 0, err
@@ -475,19 +713,28 @@ func (r assetRepository) GetAssetResponseByID(clientID string, id uint) (*respon
            asset.warranty_expiry_date,
            asset.price,
            asset.notes,
+           asset.custom_fields,
            category.asset_category_id,
            category.category_name,
            category.description AS category_description,
+           category.depreciation_method,
+           category.useful_life_months,
+           COALESCE(category.salvage_value, 0) AS salvage_value,
            status.asset_status_id,
            status.status_name,
            status.description AS status_description,
            stock.stock_id,
            stock.initial_quantity,
-           stock.latest_quantity           
+           stock.latest_quantity,
+           location.location_id,
+           location.name AS location_name,
+           location.location_type,
+           asset.parent_asset_id
        FROM "asset" asset
        INNER JOIN "asset_category" category ON asset.category_id = category.asset_category_id
        INNER JOIN "asset_status" status ON asset.status_id = status.asset_status_id
        INNER JOIN "asset_stock" stock ON asset.asset_id = stock.asset_id
+       LEFT JOIN "asset_location" location ON asset.location_id = location.location_id AND location.deleted_at IS NULL
        WHERE asset.user_client_id = ? AND asset.asset_id = ? AND asset.deleted_at IS NULL
        ORDER BY asset.created_at ASC;
    `
@@ -508,6 +755,13 @@ func (r assetRepository) GetAssetResponseByID(clientID string, id uint) (*respon
 	var warrantyExpiryDate sql.NullTime
 	var price sql.NullFloat64
 	var notes sql.NullString
+	var depreciationMethod sql.NullString
+	var usefulLifeMonths sql.NullInt64
+	var customFields customfield.Values
+	var locationID sql.NullInt64
+	var locationName sql.NullString
+	var locationType sql.NullString
+	var parentAssetID sql.NullInt64
 
 	err := rows.Scan(
 		&asset.AssetID,
@@ -521,15 +775,23 @@ func (r assetRepository) GetAssetResponseByID(clientID string, id uint) (*respon
 		&warrantyExpiryDate,
 		&price,
 		&notes,
+		&customFields,
 		&category.AssetCategoryID,
 		&category.CategoryName,
 		&category.Description,
+		&depreciationMethod,
+		&usefulLifeMonths,
+		&category.SalvageValue,
 		&status.AssetStatusID,
 		&status.StatusName,
 		&status.Description,
 		&stock.StockID,
 		&stock.InitialQuantity,
 		&stock.LatestQuantity,
+		&locationID,
+		&locationName,
+		&locationType,
+		&parentAssetID,
 	)
 
 	if err != nil {
@@ -563,12 +825,31 @@ func (r assetRepository) GetAssetResponseByID(clientID string, id uint) (*respon
 		asset.WarrantyExpiryDate = (*response.DateOnly)(&warrantyExpiryDate.Time)
 	}
 
+	if depreciationMethod.Valid {
+		category.DepreciationMethod = &depreciationMethod.String
+	}
+	if usefulLifeMonths.Valid {
+		months := int(usefulLifeMonths.Int64)
+		category.UsefulLifeMonths = &months
+	}
+
+	asset.CustomFields = customFields
+	asset.Location = assetLocation(locationID, locationName, locationType)
+	asset.ParentAssetID = nullUint(parentAssetID)
+
 	// Assign category and status details
 	asset.Category = category
+	asset.BookValue = assetBookValue(asset.Price, (*time.Time)(asset.PurchaseDate), category)
 	asset.Status = status
 	asset.Stock = stock
 
-	return &asset, nil
+	rollup := []response.AssetResponse{asset}
+	if err := r.applyComponentRollups(rollup); err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to roll up asset components")
+		return nil, err
+	}
+
+	return &rollup[0], nil
 }
 
 func (r assetRepository) GetAssetByID(clientID string, id uint) (*assets.Asset, error) {
@@ -580,8 +861,8 @@ func (r assetRepository) GetAssetByID(clientID string, id uint) (*assets.Asset,
 	return &asset, nil
 }
 
-func (r assetRepository) UpdateAsset(asset *assets.Asset, clientID string) error {
-	tx := r.db.Begin()
+func (r assetRepository) UpdateAsset(ctx context.Context, asset *assets.Asset, clientID string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	// Update asset fields (only changed fields)
@@ -598,8 +879,27 @@ func (r assetRepository) UpdateAsset(asset *assets.Asset, clientID string) error
 	return nil
 }
 
-func (r assetRepository) UpdateMaintenanceDateAsset(assetID uint, maintenanceDate *time.Time, clientID string) error {
-	tx := r.db.Begin()
+// revertedAssetColumns are the columns a revert writes back, location, parent, stock and owner keep going
+// through their own endpoints
+var revertedAssetColumns = []string{
+	"serial_number", "name", "description", "barcode", "category_id", "status_id", "purchase_date",
+	"expiry_date", "warranty_expiry_date", "price", "notes", "custom_fields", "updated_by",
+}
+
+// RevertAsset writes the reverted columns of the asset, unlike UpdateAsset the empty ones included
+func (r assetRepository) RevertAsset(ctx context.Context, asset *assets.Asset, clientID string) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetName).
+		Select(revertedAssetColumns).
+		Where("asset_id = ? AND user_client_id = ?", asset.AssetID, clientID).
+		Updates(asset).Error
+	if err != nil {
+		return fmt.Errorf("failed to revert asset: %w", err)
+	}
+	return nil
+}
+
+func (r assetRepository) UpdateMaintenanceDateAsset(ctx context.Context, assetID uint, maintenanceDate *time.Time, clientID string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	// Update asset fields (only changed fields)
@@ -616,13 +916,13 @@ func (r assetRepository) UpdateMaintenanceDateAsset(assetID uint, maintenanceDat
 	return nil
 }
 
-func (r assetRepository) UpdateAssetStatus(assetID uint, statusID uint, clientID string) (*assets.Asset, error) {
+func (r assetRepository) UpdateAssetStatus(ctx context.Context, assetID uint, statusID uint, clientID string) (*assets.Asset, error) {
 	// Start a transaction
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	var assetOld assets.Asset
-	err := r.db.Table(utils.TableAssetName).Where("asset_id = ?", assetID).First(&assetOld).Error
+	err := r.db.WithContext(ctx).Table(utils.TableAssetName).Where("asset_id = ?", assetID).First(&assetOld).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to find asset: %w", err)
 	}
@@ -659,13 +959,13 @@ func (r assetRepository) UpdateAssetStatus(assetID uint, statusID uint, clientID
 	return &asset, nil
 }
 
-func (r assetRepository) UpdateAssetCategory(assetID uint, categoryID uint, clientID string) (*assets.Asset, error) {
+func (r assetRepository) UpdateAssetCategory(ctx context.Context, assetID uint, categoryID uint, clientID string) (*assets.Asset, error) {
 	// Start a transaction
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	var assetOld assets.Asset
-	err := r.db.Table(utils.TableAssetName).Where("asset_id = ?", assetID).First(&assetOld).Error
+	err := r.db.WithContext(ctx).Table(utils.TableAssetName).Where("asset_id = ?", assetID).First(&assetOld).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to find asset: %w", err)
 	}
@@ -702,11 +1002,12 @@ func (r assetRepository) UpdateAssetCategory(assetID uint, categoryID uint, clie
 	return &asset, nil
 }
 
-func (r assetRepository) DeleteAsset(id uint, clientID string) error {
-	if err := r.db.Table(utils.TableAssetName).Model(assets.Asset{}).
+// DeleteAsset moves the asset to the trash, deletedAt is shared with the rows deleted along with it so a restore
+// can tell them apart from the ones deleted before
+func (r assetRepository) DeleteAsset(ctx context.Context, id uint, clientID string, deletedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Table(utils.TableAssetName).Model(assets.Asset{}).
 		Where("asset_id = ? AND user_client_id = ?", id, clientID).
-		Updates(map[string]interface{}{"deleted_by": clientID, "deleted_at": time.Now()}).
-		Delete(&assets.Asset{}).Error; err != nil {
+		Updates(map[string]interface{}{"deleted_by": clientID, "deleted_at": deletedAt}).Error; err != nil {
 		return fmt.Errorf("failed to delete asset: %w", err)
 	}
 	return nil
@@ -730,13 +1031,265 @@ func (r assetRepository) GetAssetByCategoryID(assetCategoryID uint, clientID str
 	return asset, nil
 }
 
-func (r assetRepository) GetAssetDeleted() ([]assets.Asset, error) {
-	var asset []assets.Asset
-	err := r.db.Unscoped().Table(utils.TableAssetName).
-		Where("deleted_at IS NOT NULL").
-		Find(&asset).Error
-	if err != nil {
+// buildAssetQueryFilter turns the query spec into extra WHERE conditions for the given asset alias
+func buildAssetQueryFilter(alias string, query request.AssetQueryRequest) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if query.CategoryID != nil {
+		conditions = append(conditions, alias+".category_id = ?")
+		args = append(args, *query.CategoryID)
+	}
+	if query.StatusID != nil {
+		conditions = append(conditions, alias+".status_id = ?")
+		args = append(args, *query.StatusID)
+	}
+	if query.MinPrice != nil {
+		conditions = append(conditions, alias+".price >= ?")
+		args = append(args, *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		conditions = append(conditions, alias+".price <= ?")
+		args = append(args, *query.MaxPrice)
+	}
+	if query.PurchaseDateFrom != nil {
+		conditions = append(conditions, alias+".purchase_date >= ?")
+		args = append(args, *query.PurchaseDateFrom)
+	}
+	if query.PurchaseDateTo != nil {
+		conditions = append(conditions, alias+".purchase_date <= ?")
+		args = append(args, *query.PurchaseDateTo)
+	}
+	if query.WarrantyExpiryFrom != nil {
+		conditions = append(conditions, alias+".warranty_expiry_date >= ?")
+		args = append(args, *query.WarrantyExpiryFrom)
+	}
+	if query.WarrantyExpiryTo != nil {
+		conditions = append(conditions, alias+".warranty_expiry_date <= ?")
+		args = append(args, *query.WarrantyExpiryTo)
+	}
+	if query.LocationID != nil {
+		conditions = append(conditions, alias+".location_id IN ("+locationSubtreeQuery+")")
+		args = append(args, *query.LocationID)
+	}
+	if len(query.TagIDs) > 0 {
+		conditions = append(conditions, alias+".asset_id IN (SELECT m.asset_id FROM asset_tag_map m WHERE m.tag_id IN ?)")
+		args = append(args, query.TagIDs)
+	}
+	for _, key := range sortedKeys(query.CustomFields) {
+		var matches []string
+		for _, document := range customfield.FilterDocuments(key, query.CustomFields[key]) {
+			matches = append(matches, alias+".custom_fields @> ?::jsonb")
+			args = append(args, document)
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+	if query.Search != "" {
+		conditions = append(conditions, "("+alias+".search_vector @@ plainto_tsquery('simple', ?) OR "+alias+".barcode = ? OR "+alias+".serial_number ILIKE ?)")
+		args = append(args, query.Search, query.Search, query.Search+"%")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
+// buildAssetQueryOrder returns the ORDER BY clause for the given asset alias, ranking search hits first by default
+func buildAssetQueryOrder(alias string, query request.AssetQueryRequest) (string, []interface{}) {
+	direction := "ASC"
+	if query.SortOrder == "desc" {
+		direction = "DESC"
+	}
+
+	if column, ok := request.AssetSortFields[query.SortBy]; ok {
+		return alias + "." + column + " " + direction + " NULLS LAST, " + alias + ".asset_id ASC", nil
+	}
+
+	if query.Search != "" {
+		return "ts_rank(" + alias + ".search_vector, plainto_tsquery('simple', ?)) DESC, " + alias + ".created_at ASC", []interface{}{query.Search}
+	}
+
+	return alias + ".created_at " + direction, nil
+}
+
+// assetBookValue depreciates the price with the settings of the asset category up to today,
+// assets without a purchase date or a depreciating category keep their price
+func assetBookValue(price float64, purchaseDate *time.Time, category response.AssetCategoryResponse) float64 {
+	settings := depreciation.NewSettings(category.DepreciationMethod, category.UsefulLifeMonths, category.SalvageValue)
+	if purchaseDate == nil || !settings.Enabled() {
+		return price
+	}
+	return settings.BookValue(price, *purchaseDate, utils.GetToday())
+}
+
+// locationSubtreeQuery selects the location bound to its single placeholder and every location below it
+const locationSubtreeQuery = `
+	WITH RECURSIVE location_tree AS (
+		SELECT location_id FROM asset_location WHERE location_id = ? AND deleted_at IS NULL
+		UNION ALL
+		SELECT child.location_id FROM asset_location child
+		JOIN location_tree parent ON child.parent_location_id = parent.location_id
+		WHERE child.deleted_at IS NULL
+	)
+	SELECT location_id FROM location_tree`
+
+// componentRollupQuery adds up, for each listed asset, the price and maintenance cost of the asset and all of
+// its nested components, and counts its direct components
+const componentRollupQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT asset_id AS root_id, asset_id, 0 AS depth FROM asset WHERE asset_id IN ?
+		UNION ALL
+		SELECT t.root_id, a.asset_id, t.depth + 1
+		FROM asset a
+		JOIN tree t ON a.parent_asset_id = t.asset_id
+		WHERE a.deleted_at IS NULL AND t.depth < ?
+	)
+	SELECT
+		t.root_id AS asset_id,
+		COUNT(*) FILTER (WHERE t.depth = 1) AS component_count,
+		COALESCE(SUM(a.price), 0) AS total_price,
+		COALESCE(SUM(m.maintenance_cost), 0) AS total_maintenance_cost
+	FROM tree t
+	JOIN asset a ON a.asset_id = t.asset_id
+	LEFT JOIN (
+		SELECT asset_id, SUM(maintenance_cost) AS maintenance_cost
+		FROM asset_maintenance_record
+		WHERE deleted_at IS NULL
+		GROUP BY asset_id
+	) m ON m.asset_id = t.asset_id
+	GROUP BY t.root_id`
+
+// applyComponentRollups fills the component count and the rolled up totals of the assets
+func (r assetRepository) applyComponentRollups(assetResponses []response.AssetResponse) error {
+	if len(assetResponses) == 0 {
+		return nil
+	}
+
+	byAssetID := make(map[uint]int, len(assetResponses))
+	assetIDs := make([]uint, len(assetResponses))
+	for i, asset := range assetResponses {
+		byAssetID[asset.AssetID] = i
+		assetIDs[i] = asset.AssetID
+	}
+
+	var rollups []struct {
+		AssetID              uint
+		ComponentCount       int
+		TotalPrice           float64
+		TotalMaintenanceCost float64
+	}
+	if err := r.db.Raw(componentRollupQuery, assetIDs, utils.MaxComponentDepth).Scan(&rollups).Error; err != nil {
+		return err
+	}
+
+	for _, rollup := range rollups {
+		i := byAssetID[rollup.AssetID]
+		assetResponses[i].ComponentCount = rollup.ComponentCount
+		assetResponses[i].TotalPrice = rollup.TotalPrice
+		assetResponses[i].TotalMaintenanceCost = rollup.TotalMaintenanceCost
+	}
+	return nil
+}
+
+func nullUint(value sql.NullInt64) *uint {
+	if !value.Valid {
+		return nil
+	}
+	id := uint(value.Int64)
+	return &id
+}
+
+// assetLocation builds the location of an asset from the LEFT JOINed columns, nil when the asset has no location
+func assetLocation(id sql.NullInt64, name, locationType sql.NullString) *response.AssetLocationResponse {
+	if !id.Valid {
+		return nil
+	}
+	return &response.AssetLocationResponse{
+		LocationID:   uint(id.Int64),
+		Name:         name.String,
+		LocationType: locationType.String,
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetCountAssetByStatus counts the assets of the user, or of the asset group when assetGroupID is set, per status
+func (r assetRepository) GetCountAssetByStatus(clientID string, assetGroupID uint) ([]response.AssetDashboardCountResponse, error) {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, request.AssetQueryRequest{})
+	query := `
+		SELECT s.asset_status_id AS id, s.status_name AS name, COUNT(*) AS count, COALESCE(SUM(a.price), 0) AS total_value
+		FROM asset a
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		WHERE ` + scope + `
+		GROUP BY s.asset_status_id, s.status_name
+		ORDER BY count DESC, name ASC`
+
+	var counts []response.AssetDashboardCountResponse
+	if err := r.db.Raw(query, args...).Scan(&counts).Error; err != nil {
+		log.Error().Str("clientID", clientID).Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to count assets by status")
 		return nil, err
 	}
-	return asset, nil
+	return counts, nil
+}
+
+// GetCountAssetByCategory counts the assets of the user, or of the asset group when assetGroupID is set, per category
+func (r assetRepository) GetCountAssetByCategory(clientID string, assetGroupID uint) ([]response.AssetDashboardCountResponse, error) {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, request.AssetQueryRequest{})
+	query := `
+		SELECT c.asset_category_id AS id, c.category_name AS name, COUNT(*) AS count, COALESCE(SUM(a.price), 0) AS total_value
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		WHERE ` + scope + `
+		GROUP BY c.asset_category_id, c.category_name
+		ORDER BY count DESC, name ASC`
+
+	var counts []response.AssetDashboardCountResponse
+	if err := r.db.Raw(query, args...).Scan(&counts).Error; err != nil {
+		log.Error().Str("clientID", clientID).Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to count assets by category")
+		return nil, err
+	}
+	return counts, nil
+}
+
+// GetListWarrantyExpiring lists the assets whose warranty ends between from and until, soonest first
+func (r assetRepository) GetListWarrantyExpiring(clientID string, assetGroupID uint, from, until time.Time) ([]response.AssetDashboardWarrantyResponse, error) {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, request.AssetQueryRequest{})
+	query := `
+		SELECT a.asset_id, a.name, c.category_name, a.warranty_expiry_date
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		WHERE ` + scope + ` AND a.warranty_expiry_date BETWEEN ? AND ?
+		ORDER BY a.warranty_expiry_date ASC, a.asset_id ASC`
+
+	type warrantyRow struct {
+		AssetID            uint
+		Name               string
+		CategoryName       string
+		WarrantyExpiryDate *time.Time
+	}
+
+	var rows []warrantyRow
+	if err := r.db.Raw(query, append(args, from, until)...).Scan(&rows).Error; err != nil {
+		log.Error().Str("clientID", clientID).Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to list expiring warranties")
+		return nil, err
+	}
+
+	warranties := make([]response.AssetDashboardWarrantyResponse, 0, len(rows))
+	for _, row := range rows {
+		warranties = append(warranties, response.AssetDashboardWarrantyResponse{
+			AssetID:            row.AssetID,
+			Name:               row.Name,
+			CategoryName:       row.CategoryName,
+			WarrantyExpiryDate: (*response.DateOnly)(row.WarrantyExpiryDate),
+		})
+	}
+	return warranties, nil
 }
@@ -4,17 +4,19 @@ import (
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"gorm.io/gorm"
 )
 
 type AssetStatusRepository interface {
 	GetAssetStatusByName(name string) error
+	GetAssetStatusByStatusName(name string) (*assets.AssetStatus, error)
 	GetCountAssetStatus() (int64, error)
-	AddAssetStatus(assetStatus **assets.AssetStatus) error
+	AddAssetStatus(ctx context.Context, assetStatus **assets.AssetStatus) error
 	GetAssetStatus(size, index int) ([]response.AssetStatusResponse, error)
 	GetAssetStatusByID(assetStatusID uint) (*assets.AssetStatus, error)
-	UpdateAssetStatus(status *assets.AssetStatus) error
-	DeleteAssetStatus(status *assets.AssetStatus) error
+	UpdateAssetStatus(ctx context.Context, status *assets.AssetStatus) error
+	DeleteAssetStatus(ctx context.Context, status *assets.AssetStatus) error
 }
 
 type assetStatusRepository struct {
@@ -34,6 +36,15 @@ func (r assetStatusRepository) GetAssetStatusByName(name string) error {
 	return nil
 }
 
+func (r assetStatusRepository) GetAssetStatusByStatusName(name string) (*assets.AssetStatus, error) {
+	var assetStatus assets.AssetStatus
+	err := r.db.Table(utils.TableAssetStatusName).Where("LOWER(status_name) = LOWER(?)", name).First(&assetStatus).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assetStatus, nil
+}
+
 func (r assetStatusRepository) GetCountAssetStatus() (int64, error) {
 	var count int64
 	err := r.db.Table(utils.TableAssetStatusName).Where("deleted_at IS NULL").Count(&count).Error
@@ -43,8 +54,8 @@ func (r assetStatusRepository) GetCountAssetStatus() (int64, error) {
 	return count, nil
 }
 
-func (r assetStatusRepository) AddAssetStatus(assetStatus **assets.AssetStatus) error {
-	err := r.db.Table(utils.TableAssetStatusName).Create(assetStatus).Error
+func (r assetStatusRepository) AddAssetStatus(ctx context.Context, assetStatus **assets.AssetStatus) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetStatusName).Create(assetStatus).Error
 	if err != nil {
 		return err
 	}
@@ -74,16 +85,16 @@ func (r assetStatusRepository) GetAssetStatusByID(assetStatusID uint) (*assets.A
 	return &assetStatus, nil
 }
 
-func (r assetStatusRepository) UpdateAssetStatus(status *assets.AssetStatus) error {
-	err := r.db.Table(utils.TableAssetStatusName).Save(status).Error
+func (r assetStatusRepository) UpdateAssetStatus(ctx context.Context, status *assets.AssetStatus) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetStatusName).Save(status).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r assetStatusRepository) DeleteAssetStatus(status *assets.AssetStatus) error {
-	err := r.db.Table(utils.TableAssetStatusName).Model(status).
+func (r assetStatusRepository) DeleteAssetStatus(ctx context.Context, status *assets.AssetStatus) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetStatusName).Model(status).
 		Update("deleted_by", status.DeletedBy).
 		Delete(status).Error
 	if err != nil {
@@ -1,10 +1,12 @@
 package assets
 
 import (
+	request "asset-service/internal/dto/in/assets"
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -13,15 +15,16 @@ import (
 
 // AssetStockRepository defines the interface
 type AssetStockRepository interface {
-	AddAssetStock(assetStock *assets.AssetStock) error
-	DeleteAssetStock(assetID uint, clientID string) error
+	AddAssetStock(ctx context.Context, assetStock *assets.AssetStock) error
+	DeleteAssetStock(ctx context.Context, assetID uint, clientID string) error
 	GetAssetStockResponseByAssetID(assetID uint, clientID string) (*response.AssetStockResponse, error)
 	GetAssetStockByAssetID(assetID uint, clientID string) (*assets.AssetStock, error)
 	GetAssetStock() ([]assets.AssetStock, error)
 	GetAssetStockByClientID(clientID string) (*[]assets.AssetStock, error)
-	UpdateAssetStock(assetStock *assets.AssetStock, clientID string) error
+	UpdateAssetStock(ctx context.Context, assetStock *assets.AssetStock, clientID string) error
 	GetAssetStockByAssetIDAndAssetGroupID(assetID, assetGroupID uint) (*assets.AssetStock, error)
-	UpdateAssetStockByAssetGroupID(assetStock *assets.AssetStock, assetGroupID uint, clientID string) error
+	UpdateAssetStockByAssetGroupID(ctx context.Context, assetStock *assets.AssetStock, assetGroupID uint, clientID string) error
+	GetTotalStock(clientID string, assetGroupID uint) (int64, error)
 }
 
 // assetStockRepository implementation
@@ -35,13 +38,13 @@ func NewAssetStockRepository(db gorm.DB) AssetStockRepository {
 }
 
 // AddAssetStock inserts a new asset stock and logs audit
-func (r *assetStockRepository) AddAssetStock(assetStock *assets.AssetStock) error {
-	return r.db.Table(utils.TableAssetStockName).Create(&assetStock).Error
+func (r *assetStockRepository) AddAssetStock(ctx context.Context, assetStock *assets.AssetStock) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetStockName).Create(&assetStock).Error
 }
 
 // DeleteAssetStock removes an asset stock and logs audit
-func (r *assetStockRepository) DeleteAssetStock(assetID uint, clientID string) error {
-	return r.db.Table(utils.TableAssetStockName).Where("asset_id = ? AND user_client_id = ?", assetID, clientID).
+func (r *assetStockRepository) DeleteAssetStock(ctx context.Context, assetID uint, clientID string) error {
+	return r.db.WithContext(ctx).Table(utils.TableAssetStockName).Where("asset_id = ? AND user_client_id = ?", assetID, clientID).
 		Updates(map[string]interface{}{"deleted_by": clientID, "deleted_at": time.Now()}).
 		Delete(&assets.AssetStock{}).Error
 }
@@ -74,8 +77,8 @@ func (r *assetStockRepository) GetAssetStockByClientID(clientID string) (*[]asse
 	return &assetStocks, err
 }
 
-func (r *assetStockRepository) UpdateAssetStock(assetStock *assets.AssetStock, clientID string) error {
-	tx := r.db.Begin()
+func (r *assetStockRepository) UpdateAssetStock(ctx context.Context, assetStock *assets.AssetStock, clientID string) error {
+	tx := r.db.WithContext(ctx).Begin()
 
 	var existingStock assets.AssetStock
 	if err := tx.Table(utils.TableAssetStockName).
@@ -165,8 +168,8 @@ func (r *assetStockRepository) GetAssetStockByAssetIDAndAssetGroupID(assetID, as
 	return &assetStock, nil
 }
 
-func (r *assetStockRepository) UpdateAssetStockByAssetGroupID(assetStock *assets.AssetStock, assetGroupID uint, clientID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+func (r *assetStockRepository) UpdateAssetStockByAssetGroupID(ctx context.Context, assetStock *assets.AssetStock, assetGroupID uint, clientID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var existingStock assets.AssetStock
 		query := `
 		SELECT stock.*
@@ -175,7 +178,7 @@ func (r *assetStockRepository) UpdateAssetStockByAssetGroupID(assetStock *assets
 		LEFT JOIN "asset_group_asset" aga ON a.asset_id = aga.asset_id
 		WHERE a.asset_id = ? AND aga.asset_group_id = ?;
 	`
-		err := r.db.Raw(query, assetStock.AssetID, assetGroupID).First(&existingStock).Error
+		err := r.db.WithContext(ctx).Raw(query, assetStock.AssetID, assetGroupID).First(&existingStock).Error
 		if err != nil {
 			return err
 		}
@@ -251,3 +254,20 @@ func abs(value int) int {
 	}
 	return value
 }
+
+// GetTotalStock sums the current quantity of the assets of the user, or of the asset group when assetGroupID is set
+func (r *assetStockRepository) GetTotalStock(clientID string, assetGroupID uint) (int64, error) {
+	scope, args := exportAssetScope("a", clientID, assetGroupID, request.AssetQueryRequest{})
+	query := `
+		SELECT COALESCE(SUM(st.latest_quantity), 0)
+		FROM asset_stock st
+		JOIN asset a ON st.asset_id = a.asset_id
+		WHERE ` + scope
+
+	var total int64
+	if err := r.db.Raw(query, args...).Scan(&total).Error; err != nil {
+		log.Error().Str("clientID", clientID).Uint("assetGroupID", assetGroupID).Err(err).Msg("❌ Failed to sum asset stock")
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,257 @@
+package assets
+
+import (
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssetTagRepository defines the interface
+type AssetTagRepository interface {
+	AddAssetTag(ctx context.Context, assetTag *assets.AssetTag) error
+	GetCountAssetTag(clientID string) (int64, error)
+	GetListAssetTag(clientID string, size int, index int) ([]assets.AssetTag, error)
+	GetAssetTagByID(tagID uint, clientID string) (*assets.AssetTag, error)
+	GetAssetTagByNameAndClientID(name, clientID string) (*assets.AssetTag, error)
+	GetAssetTagsByIDs(tagIDs []uint, clientID string) ([]assets.AssetTag, error)
+	GetAssetTagsByAssetID(assetID uint, clientID string) ([]assets.AssetTag, error)
+	UpdateAssetTag(ctx context.Context, assetTag *assets.AssetTag, clientID string) error
+	DeleteAssetTag(ctx context.Context, assetTag *assets.AssetTag) error
+	GetAssetTagMap(assetID, tagID uint) (*assets.AssetTagMap, error)
+	AttachAssetTags(ctx context.Context, assetID uint, tagIDs []uint, attachedBy string) error
+	DeleteAssetTagMap(ctx context.Context, assetTagMap *assets.AssetTagMap) error
+}
+
+// assetTagRepository implementation
+type assetTagRepository struct {
+	db gorm.DB
+}
+
+// NewAssetTagRepository initializes the repository
+func NewAssetTagRepository(db gorm.DB) AssetTagRepository {
+	return &assetTagRepository{db: db}
+}
+
+// AddAssetTag inserts a new asset tag
+func (r *assetTagRepository) AddAssetTag(ctx context.Context, assetTag *assets.AssetTag) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetTagName).Create(&assetTag).Error
+	if err != nil {
+		log.Error().Err(err).
+			Str("tag_name", assetTag.TagName).
+			Msg("❌ Failed to add asset tag")
+		return err
+	}
+
+	log.Info().
+		Str("tag_name", assetTag.TagName).
+		Msg("✅ Asset tag added successfully")
+	return nil
+}
+
+// GetCountAssetTag retrieves the count of asset tags owned by the user
+func (r *assetTagRepository) GetCountAssetTag(clientID string) (int64, error) {
+	var count int64
+	err := r.db.Table(utils.TableAssetTagName).
+		Where("user_client_id = ? AND deleted_at IS NULL", clientID).
+		Count(&count).Error
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to count asset tags")
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetListAssetTag retrieves all tags owned by the user
+func (r *assetTagRepository) GetListAssetTag(clientID string, size int, index int) ([]assets.AssetTag, error) {
+	var assetTags []assets.AssetTag
+	err := r.db.Table(utils.TableAssetTagName).
+		Where("user_client_id = ?", clientID).
+		Order("tag_name ASC").
+		Limit(size).
+		Offset((index - 1) * size).
+		Find(&assetTags).
+		Error
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to retrieve asset tags")
+		return nil, err
+	}
+	return assetTags, nil
+}
+
+// GetAssetTagByID retrieves a tag by ID
+func (r *assetTagRepository) GetAssetTagByID(tagID uint, clientID string) (*assets.AssetTag, error) {
+	var assetTag assets.AssetTag
+	err := r.db.Table(utils.TableAssetTagName).
+		Where("tag_id = ? AND user_client_id = ?", tagID, clientID).
+		First(&assetTag).Error
+	if err != nil {
+		log.Warn().
+			Uint("tag_id", tagID).
+			Msg("⚠ Asset tag not found")
+		return nil, err
+	}
+	return &assetTag, nil
+}
+
+// GetAssetTagByNameAndClientID fetches a tag by name
+func (r *assetTagRepository) GetAssetTagByNameAndClientID(name, clientID string) (*assets.AssetTag, error) {
+	var assetTag assets.AssetTag
+	err := r.db.Table(utils.TableAssetTagName).
+		Where("tag_name = ? AND user_client_id = ?", name, clientID).
+		First(&assetTag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assetTag, nil
+}
+
+// GetAssetTagsByIDs retrieves the tags matching the given IDs
+func (r *assetTagRepository) GetAssetTagsByIDs(tagIDs []uint, clientID string) ([]assets.AssetTag, error) {
+	var assetTags []assets.AssetTag
+	err := r.db.Table(utils.TableAssetTagName).
+		Where("tag_id IN ? AND user_client_id = ?", tagIDs, clientID).
+		Find(&assetTags).Error
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to retrieve asset tags by IDs")
+		return nil, err
+	}
+	return assetTags, nil
+}
+
+// GetAssetTagsByAssetID retrieves the tags attached to an asset
+func (r *assetTagRepository) GetAssetTagsByAssetID(assetID uint, clientID string) ([]assets.AssetTag, error) {
+	var assetTags []assets.AssetTag
+	err := r.db.Table(utils.TableAssetTagName+" t").
+		Select("t.*").
+		Joins("JOIN asset_tag_map m ON m.tag_id = t.tag_id").
+		Where("m.asset_id = ? AND t.user_client_id = ? AND t.deleted_at IS NULL", assetID, clientID).
+		Order("t.tag_name ASC").
+		Scan(&assetTags).Error
+	if err != nil {
+		log.Error().Err(err).Uint("asset_id", assetID).Msg("❌ Failed to retrieve asset tags by asset")
+		return nil, err
+	}
+	return assetTags, nil
+}
+
+// UpdateAssetTag modifies an existing asset tag
+func (r *assetTagRepository) UpdateAssetTag(ctx context.Context, assetTag *assets.AssetTag, clientID string) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetTagName).
+		Where("tag_id = ? AND user_client_id = ?", assetTag.TagID, clientID).
+		Updates(assetTag).Error
+	if err != nil {
+		log.Error().Err(err).
+			Uint("tag_id", assetTag.TagID).
+			Msg("❌ Failed to update asset tag")
+		return err
+	}
+
+	log.Info().
+		Uint("tag_id", assetTag.TagID).
+		Msg("✅ Asset tag updated successfully")
+	return nil
+}
+
+// DeleteAssetTag marks a tag as deleted and detaches it from every asset
+func (r *assetTagRepository) DeleteAssetTag(ctx context.Context, assetTag *assets.AssetTag) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(utils.TableAssetTagMapName).
+			Where("tag_id = ?", assetTag.TagID).
+			Unscoped().
+			Delete(&assets.AssetTagMap{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(utils.TableAssetTagName).
+			Where("tag_id = ? AND user_client_id = ?", assetTag.TagID, assetTag.UserClientID).
+			Updates(map[string]interface{}{
+				"deleted_by": assetTag.DeletedBy,
+				"deleted_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Uint("tag_id", assetTag.TagID).
+			Msg("❌ Failed to delete asset tag")
+		return err
+	}
+
+	log.Info().
+		Uint("tag_id", assetTag.TagID).
+		Msg("✅ Asset tag deleted successfully")
+	return nil
+}
+
+// GetAssetTagMap fetches the link between an asset and a tag
+func (r *assetTagRepository) GetAssetTagMap(assetID, tagID uint) (*assets.AssetTagMap, error) {
+	var assetTagMap assets.AssetTagMap
+	err := r.db.Table(utils.TableAssetTagMapName).
+		Where("asset_id = ? AND tag_id = ?", assetID, tagID).
+		Take(&assetTagMap).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assetTagMap, nil
+}
+
+// AttachAssetTags attaches the tags to an asset in one transaction, tags already attached are left as they are
+func (r *assetTagRepository) AttachAssetTags(ctx context.Context, assetID uint, tagIDs []uint, attachedBy string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var attached []uint
+		if err := tx.Table(utils.TableAssetTagMapName).
+			Where("asset_id = ? AND tag_id IN ?", assetID, tagIDs).
+			Pluck("tag_id", &attached).Error; err != nil {
+			return err
+		}
+
+		isAttached := make(map[uint]bool, len(attached))
+		for _, tagID := range attached {
+			isAttached[tagID] = true
+		}
+
+		var assetTagMaps []assets.AssetTagMap
+		for _, tagID := range tagIDs {
+			if isAttached[tagID] {
+				continue
+			}
+			assetTagMaps = append(assetTagMaps, assets.AssetTagMap{
+				AssetID:   assetID,
+				TagID:     tagID,
+				CreatedBy: &attachedBy,
+				UpdatedBy: &attachedBy,
+			})
+		}
+		if len(assetTagMaps) == 0 {
+			return nil
+		}
+		return tx.Table(utils.TableAssetTagMapName).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&assetTagMaps).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Uint("asset_id", assetID).Msg("❌ Failed to attach asset tags")
+		return err
+	}
+	return nil
+}
+
+// DeleteAssetTagMap detaches a tag from an asset
+func (r *assetTagRepository) DeleteAssetTagMap(ctx context.Context, assetTagMap *assets.AssetTagMap) error {
+	err := r.db.WithContext(ctx).Table(utils.TableAssetTagMapName).
+		Where("asset_id = ? AND tag_id = ?", assetTagMap.AssetID, assetTagMap.TagID).
+		Unscoped().
+		Delete(&assets.AssetTagMap{}).Error
+	if err != nil {
+		log.Error().Err(err).
+			Uint("asset_id", assetTagMap.AssetID).
+			Uint("tag_id", assetTagMap.TagID).
+			Msg("❌ Failed to detach asset tag")
+		return err
+	}
+	return nil
+}
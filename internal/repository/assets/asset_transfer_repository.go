@@ -0,0 +1,400 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AssetTransferRepository interface {
+	AddTransfer(ctx context.Context, transfer *assets.AssetTransfer) error
+	GetTransferByID(transferID uint) (*assets.AssetTransfer, error)
+	GetCountTransfers(clientID, direction, status string) (int64, error)
+	GetListTransfers(clientID, direction, status string, pageIndex, pageSize int) ([]response.AssetTransferResponse, error)
+	CloseTransfer(ctx context.Context, transfer *assets.AssetTransfer) error
+	AcceptTransfer(ctx context.Context, transfer *assets.AssetTransfer, recipientUserID uint) (*assets.Asset, error)
+}
+
+type assetTransferRepository struct {
+	db gorm.DB
+}
+
+func NewAssetTransferRepository(db gorm.DB) AssetTransferRepository {
+	return assetTransferRepository{db: db}
+}
+
+// transferredTables are the rows that follow the asset to its new owner, keyed by their primary key column
+var transferredTables = []struct {
+	table    string
+	idColumn string
+}{
+	{utils.TableAssetStockName, "stock_id"},
+	{utils.TableAssetStockHistoryName, "stock_history_id"},
+	{utils.TableAssetImageName, "image_id"},
+	{utils.TableAssetMaintenanceName, "id"},
+	{utils.TableAssetMaintenanceRecordName, "maintenance_record_id"},
+	{utils.TableAssetMaintenanceAttachmentName, "attachment_id"},
+}
+
+// AddTransfer stores a pending transfer, an asset can only have one pending transfer at a time
+func (r assetTransferRepository) AddTransfer(ctx context.Context, transfer *assets.AssetTransfer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pending int64
+		if err := tx.Table(utils.TableAssetTransferName).
+			Where("asset_id = ? AND status = ? AND deleted_at IS NULL", transfer.AssetID, utils.TransferStatusPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return errors.New("asset already has a pending transfer")
+		}
+
+		if err := tx.Table(utils.TableAssetTransferName).Create(transfer).Error; err != nil {
+			log.Error().Uint("assetID", transfer.AssetID).Err(err).Msg("❌ Failed to add asset transfer")
+			return err
+		}
+		return nil
+	})
+}
+
+func (r assetTransferRepository) GetTransferByID(transferID uint) (*assets.AssetTransfer, error) {
+	var transfer assets.AssetTransfer
+	err := r.db.Table(utils.TableAssetTransferName).
+		Where("transfer_id = ? AND deleted_at IS NULL", transferID).
+		First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r assetTransferRepository) GetCountTransfers(clientID, direction, status string) (int64, error) {
+	var count int64
+	filter, args := transferFilter(clientID, direction, status)
+	err := r.db.Table(utils.TableAssetTransferName+" t").
+		Where(filter, args...).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r assetTransferRepository) GetListTransfers(clientID, direction, status string, pageIndex, pageSize int) ([]response.AssetTransferResponse, error) {
+	filter, args := transferFilter(clientID, direction, status)
+	args = append(args, pageSize, (pageIndex-1)*pageSize)
+
+	query := `
+		SELECT
+			t.transfer_id, t.asset_id, a.name AS asset_name,
+			t.from_user_client_id, t.to_user_client_id, t.from_asset_group_id, t.to_asset_group_id,
+			t.status, t.message, t.responded_at, t.created_at
+		FROM asset_transfer t
+		JOIN asset a ON t.asset_id = a.asset_id
+		WHERE ` + filter + `
+		ORDER BY t.created_at DESC, t.transfer_id DESC
+		LIMIT ? OFFSET ?`
+
+	var transfers []response.AssetTransferResponse
+	if err := r.db.Raw(query, args...).Scan(&transfers).Error; err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to fetch asset transfers")
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// CloseTransfer declines or cancels a pending transfer
+func (r assetTransferRepository) CloseTransfer(ctx context.Context, transfer *assets.AssetTransfer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockPendingTransfer(tx, transfer.TransferID); err != nil {
+			return err
+		}
+
+		return tx.Table(utils.TableAssetTransferName).
+			Where("transfer_id = ?", transfer.TransferID).
+			Updates(map[string]interface{}{
+				"status":       transfer.Status,
+				"responded_at": transfer.RespondedAt,
+				"updated_by":   transfer.UpdatedBy,
+				"updated_at":   time.Now(),
+			}).Error
+	})
+}
+
+// AcceptTransfer moves the asset with its stock, images, maintenance schedules, records and attachments to the
+// recipient in one transaction. The category and the private maintenance types are matched by name in
+// the recipient's own and created there when missing, group shares of the previous owner are removed and, for a group
+// transfer, the asset is shared into the target group. Every moved row is written to the audit log
+// on behalf of the recipient.
+func (r assetTransferRepository) AcceptTransfer(ctx context.Context, transfer *assets.AssetTransfer, recipientUserID uint) (*assets.Asset, error) {
+	var asset assets.Asset
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := r.lockPendingTransfer(tx, transfer.TransferID); err != nil {
+			return err
+		}
+
+		if err := tx.Table(utils.TableAssetName).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND user_client_id = ? AND deleted_at IS NULL", transfer.AssetID, transfer.FromUserClientID).
+			First(&asset).Error; err != nil {
+			return errors.New("asset is no longer owned by the sender")
+		}
+
+		var openLoans int64
+		if err := tx.Table(utils.TableAssetGroupAssetLoanName).
+			Where("asset_id = ? AND returned_at IS NULL AND deleted_at IS NULL", transfer.AssetID).
+			Count(&openLoans).Error; err != nil {
+			return err
+		}
+		if openLoans > 0 {
+			return errors.New("asset is checked out and must be returned before the transfer")
+		}
+
+		categoryID, err := r.recipientCategory(tx, asset.CategoryID, transfer)
+		if err != nil {
+			return err
+		}
+
+		// the locations of the previous owner are not visible to the recipient, and the asset leaves
+		// the composition it was part of while its components stay with the sender
+		asset.UserClientID = transfer.ToUserClientID
+		asset.CategoryID = categoryID
+		asset.LocationID = nil
+		asset.ParentAssetID = nil
+		asset.UpdatedBy = transfer.UpdatedBy
+		if err := tx.Table(utils.TableAssetName).
+			Where("asset_id = ?", asset.AssetID).
+			Updates(map[string]interface{}{
+				"user_client_id":  asset.UserClientID,
+				"category_id":     asset.CategoryID,
+				"location_id":     nil,
+				"parent_asset_id": nil,
+				"updated_by":      asset.UpdatedBy,
+				"updated_at":      time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Table(utils.TableAssetName).
+			Where("parent_asset_id = ?", asset.AssetID).
+			Updates(map[string]interface{}{"parent_asset_id": nil, "updated_by": asset.UpdatedBy}).Error; err != nil {
+			return err
+		}
+
+		if err := r.moveMaintenanceTypes(tx, transfer); err != nil {
+			return err
+		}
+		for _, t := range transferredTables {
+			if err := r.moveAssetRows(tx, t.table, t.idColumn, transfer); err != nil {
+				return err
+			}
+		}
+
+		if err := r.moveGroupShares(tx, transfer, recipientUserID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		transfer.Status = utils.TransferStatusAccepted
+		transfer.RespondedAt = &now
+		return tx.Table(utils.TableAssetTransferName).
+			Where("transfer_id = ?", transfer.TransferID).
+			Updates(map[string]interface{}{
+				"status":       transfer.Status,
+				"responded_at": transfer.RespondedAt,
+				"updated_by":   transfer.UpdatedBy,
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		log.Error().Uint("transferID", transfer.TransferID).Err(err).Msg("❌ Failed to accept asset transfer")
+		return nil, err
+	}
+
+	log.Info().Uint("transferID", transfer.TransferID).Uint("assetID", asset.AssetID).Msg("✅ Asset transferred successfully")
+	return &asset, nil
+}
+
+func (r assetTransferRepository) lockPendingTransfer(tx *gorm.DB, transferID uint) (*assets.AssetTransfer, error) {
+	var transfer assets.AssetTransfer
+	if err := tx.Table(utils.TableAssetTransferName).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transfer_id = ? AND deleted_at IS NULL", transferID).
+		First(&transfer).Error; err != nil {
+		return nil, err
+	}
+	if transfer.Status != utils.TransferStatusPending {
+		return nil, errors.New("transfer is no longer pending")
+	}
+	return &transfer, nil
+}
+
+// recipientCategory returns the recipient's category with the same name as the asset's category, creating it when missing
+func (r assetTransferRepository) recipientCategory(tx *gorm.DB, categoryID uint, transfer *assets.AssetTransfer) (uint, error) {
+	var category assets.AssetCategory
+	if err := tx.Table(utils.TableAssetCategoryName).
+		Where("asset_category_id = ?", categoryID).
+		First(&category).Error; err != nil {
+		return 0, err
+	}
+
+	var existing assets.AssetCategory
+	err := tx.Table(utils.TableAssetCategoryName).
+		Where("category_name = ? AND user_client_id = ? AND deleted_at IS NULL", category.CategoryName, transfer.ToUserClientID).
+		First(&existing).Error
+	if err == nil {
+		return existing.AssetCategoryID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	created := assets.AssetCategory{
+		UserClientID:       transfer.ToUserClientID,
+		CategoryName:       category.CategoryName,
+		Description:        category.Description,
+		DepreciationMethod: category.DepreciationMethod,
+		UsefulLifeMonths:   category.UsefulLifeMonths,
+		SalvageValue:       category.SalvageValue,
+		CustomFields:       category.CustomFields,
+		CreatedBy:          transfer.UpdatedBy,
+		UpdatedBy:          transfer.UpdatedBy,
+	}
+	if err := tx.Table(utils.TableAssetCategoryName).Create(&created).Error; err != nil {
+		return 0, err
+	}
+	return created.AssetCategoryID, nil
+}
+
+// moveMaintenanceTypes points the asset's maintenance schedules and records at the recipient's type with the
+// same name as the sender's private type, creating it when missing. Default types are shared by every user
+func (r assetTransferRepository) moveMaintenanceTypes(tx *gorm.DB, transfer *assets.AssetTransfer) error {
+	var typeIDs []uint
+	query := `
+		SELECT maintenance_type_id FROM asset_maintenance WHERE asset_id = @asset_id AND user_client_id = @client_id
+		UNION
+		SELECT maintenance_type_id FROM asset_maintenance_record WHERE asset_id = @asset_id AND user_client_id = @client_id`
+	if err := tx.Raw(query, map[string]interface{}{"asset_id": transfer.AssetID, "client_id": transfer.FromUserClientID}).
+		Scan(&typeIDs).Error; err != nil {
+		return err
+	}
+
+	for _, typeID := range typeIDs {
+		var maintenanceType assets.AssetMaintenanceType
+		if err := tx.Table(utils.TableAssetMaintenanceTypeName).
+			Where("maintenance_type_id = ?", typeID).
+			First(&maintenanceType).Error; err != nil {
+			return err
+		}
+		if maintenanceType.IsDefault || maintenanceType.UserClientID == transfer.ToUserClientID {
+			continue
+		}
+
+		recipientTypeID, err := r.recipientMaintenanceType(tx, maintenanceType, transfer)
+		if err != nil {
+			return err
+		}
+		for _, table := range []string{utils.TableAssetMaintenanceName, utils.TableAssetMaintenanceRecordName} {
+			if err = tx.Table(table).
+				Where("asset_id = ? AND user_client_id = ? AND maintenance_type_id = ?", transfer.AssetID, transfer.FromUserClientID, typeID).
+				Update("maintenance_type_id", recipientTypeID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recipientMaintenanceType returns the recipient's private type with the same name, creating it when missing
+func (r assetTransferRepository) recipientMaintenanceType(tx *gorm.DB, maintenanceType assets.AssetMaintenanceType, transfer *assets.AssetTransfer) (uint, error) {
+	var existing assets.AssetMaintenanceType
+	err := tx.Table(utils.TableAssetMaintenanceTypeName).
+		Where("LOWER(maintenance_type_name) = LOWER(?) AND user_client_id = ? AND is_default = FALSE AND deleted_at IS NULL",
+			maintenanceType.MaintenanceTypeName, transfer.ToUserClientID).
+		First(&existing).Error
+	if err == nil {
+		return existing.MaintenanceTypeID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	created := assets.AssetMaintenanceType{
+		UserClientID:        transfer.ToUserClientID,
+		MaintenanceTypeName: maintenanceType.MaintenanceTypeName,
+		Description:         maintenanceType.Description,
+		CreatedBy:           transfer.UpdatedBy,
+		UpdatedBy:           transfer.UpdatedBy,
+	}
+	if err = tx.Table(utils.TableAssetMaintenanceTypeName).Create(&created).Error; err != nil {
+		return 0, err
+	}
+	return created.MaintenanceTypeID, nil
+}
+
+// moveAssetRows hands the rows of one table that belong to the asset over to the recipient
+func (r assetTransferRepository) moveAssetRows(tx *gorm.DB, table, idColumn string, transfer *assets.AssetTransfer) error {
+	var ids []uint
+	if err := tx.Table(table).
+		Where("asset_id = ? AND user_client_id = ?", transfer.AssetID, transfer.FromUserClientID).
+		Pluck(idColumn, &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return tx.Table(table).
+		Where(idColumn+" IN ?", ids).
+		Update("user_client_id", transfer.ToUserClientID).Error
+}
+
+// moveGroupShares removes the asset from the groups it was shared with and shares it into the target group
+func (r assetTransferRepository) moveGroupShares(tx *gorm.DB, transfer *assets.AssetTransfer, recipientUserID uint) error {
+	if err := tx.Unscoped().Table(utils.TableAssetGroupAssetName).
+		Where("asset_id = ?", transfer.AssetID).
+		Delete(&assets.AssetGroupAsset{}).Error; err != nil {
+		return err
+	}
+
+	if transfer.ToAssetGroupID == nil {
+		return nil
+	}
+	share := assets.AssetGroupAsset{
+		AssetID:      transfer.AssetID,
+		AssetGroupID: *transfer.ToAssetGroupID,
+		UserID:       recipientUserID,
+		CreatedBy:    transfer.UpdatedBy,
+		UpdatedBy:    transfer.UpdatedBy,
+	}
+	return tx.Table(utils.TableAssetGroupAssetName).Create(&share).Error
+}
+
+// transferFilter selects the transfers sent or received by clientID, optionally by status
+func transferFilter(clientID, direction, status string) (string, []interface{}) {
+	filter := "t.deleted_at IS NULL"
+	var args []interface{}
+
+	switch direction {
+	case utils.TransferDirectionIncoming:
+		filter += " AND t.to_user_client_id = ?"
+		args = append(args, clientID)
+	case utils.TransferDirectionOutgoing:
+		filter += " AND t.from_user_client_id = ?"
+		args = append(args, clientID)
+	default:
+		filter += " AND (t.to_user_client_id = ? OR t.from_user_client_id = ?)"
+		args = append(args, clientID, clientID)
+	}
+
+	if status != "" {
+		filter += " AND t.status = ?"
+		args = append(args, status)
+	}
+	return filter, args
+}
@@ -0,0 +1,220 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AssetTrashRepository interface {
+	GetCountDeletedAssets(clientID string) (int64, error)
+	GetListDeletedAssets(clientID string, pageIndex, pageSize int) ([]response.AssetTrashResponse, error)
+	GetDeletedAsset(assetID uint, clientID string) (*assets.Asset, error)
+	GetDeletedAssetsBefore(before time.Time) ([]assets.Asset, error)
+	GetAssetFiles(assetID uint) ([]string, error)
+	RestoreAsset(ctx context.Context, asset *assets.Asset, restoredBy string) error
+	PurgeAsset(ctx context.Context, assetID uint) error
+}
+
+type assetTrashRepository struct {
+	db gorm.DB
+}
+
+func NewAssetTrashRepository(db gorm.DB) AssetTrashRepository {
+	return assetTrashRepository{db: db}
+}
+
+func (r assetTrashRepository) GetCountDeletedAssets(clientID string) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Table(utils.TableAssetName).
+		Where("user_client_id = ? AND deleted_at IS NOT NULL", clientID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r assetTrashRepository) GetListDeletedAssets(clientID string, pageIndex, pageSize int) ([]response.AssetTrashResponse, error) {
+	query := `
+		SELECT a.asset_id, a.name, a.serial_number, a.barcode, c.category_name, s.status_name, a.deleted_at, a.deleted_by
+		FROM asset a
+		JOIN asset_category c ON a.category_id = c.asset_category_id
+		JOIN asset_status s ON a.status_id = s.asset_status_id
+		WHERE a.user_client_id = ? AND a.deleted_at IS NOT NULL
+		ORDER BY a.deleted_at DESC, a.asset_id DESC
+		LIMIT ? OFFSET ?`
+
+	var deleted []response.AssetTrashResponse
+	if err := r.db.Raw(query, clientID, pageSize, (pageIndex-1)*pageSize).Scan(&deleted).Error; err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to fetch deleted assets")
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (r assetTrashRepository) GetDeletedAsset(assetID uint, clientID string) (*assets.Asset, error) {
+	var asset assets.Asset
+	err := r.db.Unscoped().Table(utils.TableAssetName).
+		Where("asset_id = ? AND user_client_id = ? AND deleted_at IS NOT NULL", assetID, clientID).
+		First(&asset).Error
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetDeletedAssetsBefore lists the assets of every user deleted before the given time, oldest first
+func (r assetTrashRepository) GetDeletedAssetsBefore(before time.Time) ([]assets.Asset, error) {
+	var deleted []assets.Asset
+	err := r.db.Unscoped().Table(utils.TableAssetName).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC, asset_id ASC").
+		Find(&deleted).Error
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// GetAssetFiles lists the CDN urls of the asset's images and of its maintenance attachments, the cleanup job
+// leaves the attachments of an asset in the trash to its purge
+func (r assetTrashRepository) GetAssetFiles(assetID uint) ([]string, error) {
+	query := `
+		SELECT image_url FROM asset_image WHERE asset_id = ?
+		UNION ALL
+		SELECT file_url FROM asset_maintenance_attachment WHERE asset_id = ?`
+
+	var files []string
+	if err := r.db.Raw(query, assetID, assetID).Scan(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// restoredTables are the maintenance rows deleted along with an asset, they carry the same deleted_at as the
+// asset and come back with it
+var restoredTables = []struct {
+	table string
+	model interface{}
+}{
+	{utils.TableAssetMaintenanceName, &assets.AssetMaintenance{}},
+	{utils.TableAssetMaintenanceRecordName, &assets.AssetMaintenanceRecord{}},
+	{utils.TableAssetMaintenanceAttachmentName, &assets.AssetMaintenanceAttachment{}},
+}
+
+// RestoreAsset brings the asset back together with the maintenance schedules, records and attachments deleted
+// with it, and with the components a cascade delete took along. Its stock, images and group links are left
+// untouched by the delete and come back with the asset
+func (r assetTrashRepository) RestoreAsset(ctx context.Context, asset *assets.Asset, restoredBy string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		restored := tx.Unscoped().Table(utils.TableAssetName).Model(&assets.Asset{}).
+			Where("asset_id = ? AND user_client_id = ? AND deleted_at IS NOT NULL", asset.AssetID, asset.UserClientID).
+			Updates(map[string]interface{}{
+				"deleted_at":      nil,
+				"deleted_by":      nil,
+				"parent_asset_id": asset.ParentAssetID,
+				"updated_by":      restoredBy,
+			})
+		if restored.Error != nil {
+			return fmt.Errorf("failed to restore asset: %w", restored.Error)
+		}
+		if restored.RowsAffected == 0 {
+			return errors.New("asset is not in the trash")
+		}
+
+		return r.restoreDeletedWith(tx, asset.AssetID, asset.DeletedAt.Time, restoredBy)
+	})
+}
+
+// restoreDeletedWith restores the maintenance rows and the components deleted together with the asset at
+// deletedAt, then does the same for each restored component
+func (r assetTrashRepository) restoreDeletedWith(tx *gorm.DB, assetID uint, deletedAt time.Time, restoredBy string) error {
+	for _, restoredTable := range restoredTables {
+		err := tx.Unscoped().Table(restoredTable.table).Model(restoredTable.model).
+			Where("asset_id = ? AND deleted_at = ?", assetID, deletedAt).
+			Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil, "updated_by": restoredBy}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", restoredTable.table, err)
+		}
+	}
+
+	var componentIDs []uint
+	err := tx.Unscoped().Table(utils.TableAssetName).
+		Where("parent_asset_id = ? AND deleted_at = ?", assetID, deletedAt).
+		Pluck("asset_id", &componentIDs).Error
+	if err != nil {
+		return fmt.Errorf("failed to get deleted components: %w", err)
+	}
+	if len(componentIDs) == 0 {
+		return nil
+	}
+
+	err = tx.Unscoped().Table(utils.TableAssetName).Model(&assets.Asset{}).
+		Where("asset_id IN ? AND deleted_at = ?", componentIDs, deletedAt).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil, "updated_by": restoredBy}).Error
+	if err != nil {
+		return fmt.Errorf("failed to restore components: %w", err)
+	}
+	for _, componentID := range componentIDs {
+		if err = r.restoreDeletedWith(tx, componentID, deletedAt, restoredBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeAsset permanently removes a deleted asset and every row referring to it. Each row is deleted through
+// its model so the purge shows in the audit log on behalf of the actor of ctx, components of the asset are
+// detached and kept
+func (r assetTrashRepository) PurgeAsset(ctx context.Context, assetID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Table(utils.TableAssetName).Model(&assets.Asset{}).
+			Where("parent_asset_id = ?", assetID).
+			Update("parent_asset_id", nil).Error
+		if err != nil {
+			return fmt.Errorf("failed to detach components: %w", err)
+		}
+
+		dependents := []struct {
+			table string
+			model interface{}
+		}{
+			{utils.TableAssetMaintenanceNotificationName, &assets.AssetMaintenanceNotification{}},
+			{utils.TableAssetMaintenanceAttachmentName, &assets.AssetMaintenanceAttachment{}},
+			{utils.TableAssetMaintenanceRecordName, &assets.AssetMaintenanceRecord{}},
+			{utils.TableAssetMaintenanceName, &assets.AssetMaintenance{}},
+			{utils.TableAssetExpiryAlertName, &assets.AssetExpiryAlert{}},
+			{utils.TableAssetTagMapName, &assets.AssetTagMap{}},
+			{utils.TableAssetGroupAssetLoanName, &assets.AssetGroupAssetLoan{}},
+			{utils.TableAssetTransferName, &assets.AssetTransfer{}},
+			{utils.TableAssetLocationHistoryName, &assets.AssetLocationHistory{}},
+			{utils.TableAssetGroupAssetName, &assets.AssetGroupAsset{}},
+			{utils.TableAssetStockHistoryName, &assets.AssetStockHistory{}},
+			{utils.TableAssetStockName, &assets.AssetStock{}},
+			{utils.TableAssetImageName, &assets.AssetImage{}},
+		}
+		for _, dependent := range dependents {
+			if err = tx.Unscoped().Table(dependent.table).Where("asset_id = ?", assetID).Delete(dependent.model).Error; err != nil {
+				return fmt.Errorf("failed to purge %s: %w", dependent.table, err)
+			}
+		}
+
+		purged := tx.Unscoped().Table(utils.TableAssetName).
+			Where("asset_id = ? AND deleted_at IS NOT NULL", assetID).
+			Delete(&assets.Asset{})
+		if purged.Error != nil {
+			return fmt.Errorf("failed to purge asset: %w", purged.Error)
+		}
+		if purged.RowsAffected == 0 {
+			return errors.New("asset is not in the trash")
+		}
+		return nil
+	})
+}
@@ -4,6 +4,7 @@ import (
 	response "asset-service/internal/dto/out/assets"
 	"asset-service/internal/models/assets"
 	"asset-service/internal/utils"
+	"context"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -11,22 +12,22 @@ import (
 
 type AssetWishlistRepository interface {
 	AssetWishlistNameExists(assetName string, clientID string) (bool, error)
-	AddAssetWishlist(asset *assets.AssetWishlist) error
+	AddAssetWishlist(ctx context.Context, asset *assets.AssetWishlist) error
 	GetAssetWishlistByID(clientID string, assetWishlistID uint) (*assets.AssetWishlist, error)
 	GetAssetWishlistResponseByID(clientID string, assetWishlistID uint) (*response.AssetWishlistResponse, error)
 	GetListAssetWishlist(clientID string, size, index int) ([]response.AssetWishlistResponse, error)
-	UpdateAssetWishlist(assetWishlist *assets.AssetWishlist) error
-	DeleteAssetWishlist(id uint, clientID string) error
+	UpdateAssetWishlist(ctx context.Context, assetWishlist *assets.AssetWishlist) error
+	DeleteAssetWishlist(ctx context.Context, id uint, clientID string) error
 	GetListAssetWishlistCount(clientID string) (int64, error)
+	GetWishlistEstimate(clientID string) (*response.AssetDashboardWishlistResponse, error)
 }
 
 type assetWishlistRepository struct {
-	db    gorm.DB
-	audit AssetAuditLogRepository
+	db gorm.DB
 }
 
-func NewAssetWishlistRepository(db gorm.DB, audit AssetAuditLogRepository) AssetWishlistRepository {
-	return assetWishlistRepository{db: db, audit: audit}
+func NewAssetWishlistRepository(db gorm.DB) AssetWishlistRepository {
+	return assetWishlistRepository{db: db}
 }
 
 func (r assetWishlistRepository) AssetWishlistNameExists(assetName string, clientID string) (bool, error) {
@@ -49,8 +50,8 @@ func (r assetWishlistRepository) AssetWishlistNameExists(assetName string, clien
 	return false, nil
 }
 
-func (r assetWishlistRepository) AddAssetWishlist(asset *assets.AssetWishlist) error {
-	tx := r.db.Begin()
+func (r assetWishlistRepository) AddAssetWishlist(ctx context.Context, asset *assets.AssetWishlist) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	if err := tx.Table(utils.TableAssetWishlistName).Create(&asset).Error; err != nil {
@@ -220,8 +221,8 @@ func (r assetWishlistRepository) GetListAssetWishlist(clientID string, size, ind
 	return assetWishlists, nil
 }
 
-func (r assetWishlistRepository) UpdateAssetWishlist(assetWishlist *assets.AssetWishlist) error {
-	tx := r.db.Begin()
+func (r assetWishlistRepository) UpdateAssetWishlist(ctx context.Context, assetWishlist *assets.AssetWishlist) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	if err := tx.Table(utils.TableAssetWishlistName).
@@ -248,8 +249,8 @@ func (r assetWishlistRepository) UpdateAssetWishlist(assetWishlist *assets.Asset
 	return nil
 }
 
-func (r assetWishlistRepository) DeleteAssetWishlist(id uint, clientID string) error {
-	tx := r.db.Begin()
+func (r assetWishlistRepository) DeleteAssetWishlist(ctx context.Context, id uint, clientID string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
 	if err := tx.Table(utils.TableAssetWishlistName).
@@ -282,3 +283,17 @@ func (r assetWishlistRepository) GetListAssetWishlistCount(clientID string) (int
 	log.Info().Str("clientID", clientID).Msg("✅ Successfully counted asset wishlists")
 	return count, nil
 }
+
+// GetWishlistEstimate counts the wishlist items of the user and sums their price estimates
+func (r assetWishlistRepository) GetWishlistEstimate(clientID string) (*response.AssetDashboardWishlistResponse, error) {
+	var estimate response.AssetDashboardWishlistResponse
+	err := r.db.Table(utils.TableAssetWishlistName).
+		Select("COUNT(*) AS item_count, COALESCE(SUM(price_estimate), 0) AS total_estimate").
+		Where("user_client_id = ? AND deleted_at IS NULL", clientID).
+		Scan(&estimate).Error
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("❌ Failed to sum asset wishlist estimates")
+		return nil, err
+	}
+	return &estimate, nil
+}
@@ -2,13 +2,16 @@ package transaction
 
 import (
 	"asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"time"
 )
 
 type AssetTransactionRepository interface {
-	DeleteAsset(transactionID uint, clientID, fullName string) error
+	DeleteAsset(ctx context.Context, transactionID uint, clientID, fullName, componentMode string) error
 }
 
 type assetTransactionRepository struct {
@@ -18,7 +21,8 @@ type assetTransactionRepository struct {
 	AssetStatusRepository            assets.AssetStatusRepository
 	AssetMaintenanceRepository       assets.AssetMaintenanceRepository
 	AssetMaintenanceRecordRepository assets.AssetMaintenanceRecordRepository
-	AssetAuditLogRepository          assets.AssetAuditLogRepository
+	AssetComponentRepository         assets.AssetComponentRepository
+	AssetMaintenanceAttachment       assets.AssetMaintenanceAttachmentRepository
 }
 
 func NewAssetTransactionRepository(db gorm.DB, AssetRepository assets.AssetRepository,
@@ -26,7 +30,8 @@ func NewAssetTransactionRepository(db gorm.DB, AssetRepository assets.AssetRepos
 	AssetStatusRepository assets.AssetStatusRepository,
 	AssetMaintenanceRepository assets.AssetMaintenanceRepository,
 	AssetMaintenanceRecordRepository assets.AssetMaintenanceRecordRepository,
-	AssetAuditLogRepository assets.AssetAuditLogRepository) AssetTransactionRepository {
+	AssetComponentRepository assets.AssetComponentRepository,
+	AssetMaintenanceAttachment assets.AssetMaintenanceAttachmentRepository) AssetTransactionRepository {
 	return assetTransactionRepository{
 		db:                               db,
 		AssetRepository:                  AssetRepository,
@@ -34,11 +39,16 @@ func NewAssetTransactionRepository(db gorm.DB, AssetRepository assets.AssetRepos
 		AssetStatusRepository:            AssetStatusRepository,
 		AssetMaintenanceRepository:       AssetMaintenanceRepository,
 		AssetMaintenanceRecordRepository: AssetMaintenanceRecordRepository,
-		AssetAuditLogRepository:          AssetAuditLogRepository}
+		AssetComponentRepository:         AssetComponentRepository,
+		AssetMaintenanceAttachment:       AssetMaintenanceAttachment}
 }
 
-func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fullName string) error {
-	tx := r.db.Begin()
+// DeleteAsset deletes the asset with its maintenance and their attachments, componentMode decides what happens to its components:
+// utils.ComponentDeleteDetach keeps them as standalone assets, utils.ComponentDeleteCascade deletes them too
+// and utils.ComponentDeleteRestrict refuses to delete an asset that still has components. Everything, cascaded
+// components included, is deleted in one transaction
+func (r assetTransactionRepository) DeleteAsset(ctx context.Context, transactionID uint, clientID, fullName, componentMode string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -46,10 +56,53 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 		}
 	}()
 
+	// deleted_at is stored with microsecond precision, the restore matches the rows on the stored value
+	deletedAt := time.Now().Truncate(time.Microsecond)
+	if err := r.withTx(tx).deleteAsset(ctx, transactionID, clientID, fullName, componentMode, deletedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err := tx.Commit().Error
+	if err != nil {
+		log.Error().
+			Str("method", "DeleteAsset").
+			Uint("transactionID", transactionID).
+			Str("clientID", clientID).
+			Err(err).
+			Msg("Transaction commit failed")
+		return err
+	}
+
+	log.Info().
+		Uint("transactionID", transactionID).
+		Str("clientID", clientID).
+		Str("fullName", fullName).
+		Msg("✅ Asset and related records successfully deleted")
+
+	return nil
+}
+
+// withTx returns a copy of the repository whose repositories all run on the given transaction
+func (r assetTransactionRepository) withTx(tx *gorm.DB) assetTransactionRepository {
+	return assetTransactionRepository{
+		db:                               *tx,
+		AssetRepository:                  assets.NewAssetRepository(*tx),
+		AssetCategoryRepository:          assets.NewAssetCategoryRepository(*tx),
+		AssetStatusRepository:            assets.NewAssetStatusRepository(*tx),
+		AssetMaintenanceRepository:       assets.NewAssetMaintenanceRepository(*tx),
+		AssetMaintenanceRecordRepository: assets.NewAssetMaintenanceRecordRepository(*tx),
+		AssetComponentRepository:         assets.NewAssetComponentRepository(*tx),
+		AssetMaintenanceAttachment:       assets.NewAssetMaintenanceAttachmentRepository(*tx)}
+}
+
+// deleteAsset deletes the asset and, depending on componentMode, its components through repositories bound to
+// the caller's transaction. Every row is deleted at deletedAt so restoring the asset brings back exactly those rows
+func (r assetTransactionRepository) deleteAsset(ctx context.Context, transactionID uint, clientID, fullName, componentMode string, deletedAt time.Time) error {
 	// Check if the asset exists
 	checkAsset, err := r.AssetRepository.GetAssetByID(clientID, transactionID)
 	if err != nil {
-		tx.Rollback()
 		log.Error().
 			Str("method", "DeleteAsset").
 			Uint("transactionID", transactionID).
@@ -59,7 +112,6 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 		return err
 	}
 	if checkAsset.AssetID == 0 {
-		tx.Rollback()
 		log.Warn().
 			Str("method", "DeleteAsset").
 			Uint("transactionID", transactionID).
@@ -68,10 +120,67 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 		return gorm.ErrRecordNotFound
 	}
 
+	components, err := r.AssetComponentRepository.GetComponents(transactionID)
+	if err != nil {
+		log.Error().
+			Str("method", "DeleteAsset").
+			Uint("transactionID", transactionID).
+			Str("clientID", clientID).
+			Err(err).
+			Msg("Failed to retrieve components")
+		return err
+	}
+
+	if len(components) > 0 {
+		switch componentMode {
+		case utils.ComponentDeleteRestrict:
+			log.Warn().
+				Str("method", "DeleteAsset").
+				Uint("transactionID", transactionID).
+				Str("clientID", clientID).
+				Int("components", len(components)).
+				Msg("Asset still has components, cannot proceed with deletion")
+			return errors.New("asset still has components, detach them first")
+		case utils.ComponentDeleteCascade:
+			for _, component := range components {
+				if err = r.deleteAsset(ctx, component.AssetID, clientID, fullName, componentMode, deletedAt); err != nil {
+					log.Error().
+						Str("method", "DeleteAsset").
+						Uint("transactionID", transactionID).
+						Uint("componentID", component.AssetID).
+						Str("clientID", clientID).
+						Err(err).
+						Msg("Failed to delete component")
+					return err
+				}
+			}
+		default:
+			if err = r.AssetComponentRepository.DetachAllComponents(ctx, transactionID, fullName); err != nil {
+				log.Error().
+					Str("method", "DeleteAsset").
+					Uint("transactionID", transactionID).
+					Str("clientID", clientID).
+					Err(err).
+					Msg("Failed to detach components")
+				return err
+			}
+		}
+	}
+
+	// Maintenance attachments, records and schedules stay in the trash with the asset until it is restored or purged
+	if err = r.AssetMaintenanceAttachment.DeleteAttachmentsByAssetID(ctx, transactionID, fullName, deletedAt); err != nil {
+		log.Error().
+			Str("method", "DeleteAsset").
+			Uint("transactionID", transactionID).
+			Str("clientID", clientID).
+			Err(err).
+			Msg("Failed to delete maintenance attachments")
+		return err
+	}
+
 	// Check maintenance existence (skip deletion if not found)
 	checkMaintenance, err := r.AssetMaintenanceRepository.GetMaintenanceByAssetID(transactionID, clientID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		tx.Rollback()
 		log.Error().
 			Str("method", "DeleteAsset").
 			Uint("transactionID", transactionID).
@@ -84,7 +193,6 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 	// Check maintenance record existence (skip deletion if not found)
 	checkMaintenanceRecord, err := r.AssetMaintenanceRecordRepository.GetMaintenanceRecordByAssetID(transactionID, clientID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		tx.Rollback()
 		log.Error().
 			Str("method", "DeleteAsset").
 			Uint("transactionID", transactionID).
@@ -96,9 +204,8 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 
 	// If maintenance record exists, delete it
 	if checkMaintenanceRecord.MaintenanceRecordID != 0 {
-		err = r.AssetMaintenanceRecordRepository.Delete(transactionID)
+		err = r.AssetMaintenanceRecordRepository.Delete(ctx, transactionID, fullName, deletedAt)
 		if err != nil {
-			tx.Rollback()
 			log.Error().
 				Str("method", "DeleteAsset").
 				Uint("transactionID", transactionID).
@@ -111,16 +218,12 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 			Uint("transactionID", transactionID).
 			Str("clientID", clientID).
 			Msg("✅ Maintenance record deleted")
-
-		checkMaintenanceRecord.DeletedBy = &fullName
-		err = r.AssetAuditLogRepository.AfterDeleteAssetMaintenanceRecord(checkMaintenanceRecord)
 	}
 
 	// If maintenance exists, delete it
 	if checkMaintenance.ID != 0 {
-		err = r.AssetMaintenanceRepository.Delete(transactionID, fullName)
+		err = r.AssetMaintenanceRepository.Delete(ctx, transactionID, fullName, deletedAt)
 		if err != nil {
-			tx.Rollback()
 			log.Error().
 				Str("method", "DeleteAsset").
 				Uint("transactionID", transactionID).
@@ -133,15 +236,11 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 			Uint("transactionID", transactionID).
 			Str("clientID", clientID).
 			Msg("✅ Maintenance deleted")
-
-		checkMaintenance.DeletedBy = &fullName
-		err = r.AssetAuditLogRepository.AfterDeleteAssetMaintenance(checkMaintenance)
 	}
 
 	// DeleteAsset the asset
-	err = r.AssetRepository.DeleteAsset(transactionID, clientID)
+	err = r.AssetRepository.DeleteAsset(ctx, transactionID, clientID, deletedAt)
 	if err != nil {
-		tx.Rollback()
 		log.Error().
 			Str("method", "DeleteAsset").
 			Uint("transactionID", transactionID).
@@ -150,33 +249,11 @@ func (r assetTransactionRepository) DeleteAsset(transactionID uint, clientID, fu
 			Msg("Failed to delete asset")
 		return err
 	}
-
-	checkAsset.DeletedBy = &fullName
-	err = r.AssetAuditLogRepository.AfterDeleteAsset(checkAsset)
-
-	// Commit the transaction
-	err = tx.Commit().Error
-	if err != nil {
-		log.Error().
-			Str("method", "DeleteAsset").
-			Uint("transactionID", transactionID).
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Transaction commit failed")
-		return err
-	}
-
-	log.Info().
-		Uint("transactionID", transactionID).
-		Str("clientID", clientID).
-		Str("fullName", fullName).
-		Msg("✅ Asset and related records successfully deleted")
-
 	return nil
 }
 
-func (r assetTransactionRepository) DeleteAssetCategory(assetCategoryID uint, clientID, fullName string) error {
-	tx := r.db.Begin()
+func (r assetTransactionRepository) DeleteAssetCategory(ctx context.Context, assetCategoryID uint, clientID, fullName string) error {
+	tx := r.db.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -231,7 +308,7 @@ func (r assetTransactionRepository) DeleteAssetCategory(assetCategoryID uint, cl
 
 	// DeleteAsset the asset category
 	checkAssetCategory.DeletedBy = &fullName
-	err = r.AssetCategoryRepository.DeleteAssetCategory(checkAssetCategory)
+	err = r.AssetCategoryRepository.DeleteAssetCategory(ctx, checkAssetCategory)
 	if err != nil {
 		tx.Rollback()
 		log.Error().
@@ -0,0 +1,24 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetAuditLogRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetAuditLogController) {
+
+	routerGroup := r.Group("/v1/audit")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("", controller.GetListAuditLog)
+		routerGroup.GET("/asset/:id", controller.GetListAssetAuditLog)
+		routerGroup.GET("/verify", controller.VerifyAuditChain)
+	}
+
+	adminGroup := r.Group("/v1/admin/audit")
+	adminGroup.Use(middleware.AdminMiddleware.HandlerAsset())
+	{
+		adminGroup.GET("/verify", controller.VerifyAuditChains)
+	}
+}
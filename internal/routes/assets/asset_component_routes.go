@@ -0,0 +1,18 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetComponentRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetComponentController) {
+
+	routerGroup := r.Group("/v1/asset")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("/:id/components", controller.GetComponents)
+		routerGroup.POST("/:id/components", controller.AttachComponent)
+		routerGroup.DELETE("/:id/components/:component_id", controller.DetachComponent)
+	}
+}
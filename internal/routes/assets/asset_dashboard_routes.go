@@ -0,0 +1,17 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetDashboardRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetDashboardController) {
+
+	routerGroup := r.Group("/v1/asset/dashboard")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("", controller.GetDashboard)
+		routerGroup.GET("/asset-group/:id", controller.GetAssetGroupDashboard)
+	}
+}
@@ -0,0 +1,16 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetDepreciationRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetDepreciationController) {
+
+	routerGroup := r.Group("/v1/asset")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("/:id/depreciation", controller.GetAssetDepreciation)
+	}
+}
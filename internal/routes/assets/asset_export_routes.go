@@ -0,0 +1,18 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetExportRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetExportController) {
+
+	routerGroup := r.Group("/v1/asset/export")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("", controller.ExportAssets)
+		routerGroup.GET("/maintenance", controller.ExportMaintenances)
+		routerGroup.GET("/maintenance-record", controller.ExportMaintenanceRecords)
+	}
+}
@@ -29,6 +29,15 @@ func AssetGroupRoutes(r *gin.Engine, middleware config.Middleware, controller co
 		assetGroupAsset.POST("/reduce-stock", controller.AssetGroupController.ReduceStockAssetGroupAsset)
 	}
 
+	assetGroupLoan := r.Group("/v1/asset-group/loan")
+	assetGroupLoan.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		assetGroupLoan.POST("/check-out", controller.AssetGroupLoanController.CheckOutAsset)
+		assetGroupLoan.POST("/return/:id", controller.AssetGroupLoanController.ReturnAsset)
+		assetGroupLoan.GET("/:id", controller.AssetGroupLoanController.GetListLoan)
+		assetGroupLoan.GET("/:id/overdue", controller.AssetGroupLoanController.GetListOverdueLoan)
+	}
+
 	assetPermission := r.Group("/v1/asset-group/permission")
 	assetPermission.Use(middleware.AssetMiddleware.HandlerAsset())
 	{
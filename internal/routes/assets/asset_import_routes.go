@@ -0,0 +1,16 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetImportRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetImportController) {
+
+	routerGroup := r.Group("/v1/asset")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.POST("/import", controller.ImportAssets)
+	}
+}
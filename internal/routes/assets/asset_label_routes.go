@@ -0,0 +1,17 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetLabelRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetLabelController) {
+
+	routerGroup := r.Group("/v1/asset")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("/labels", controller.GetAssetLabelSheet)
+		routerGroup.GET("/:id/label", controller.GetAssetLabel)
+	}
+}
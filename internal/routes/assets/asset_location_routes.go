@@ -0,0 +1,27 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetLocationRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetLocationController) {
+
+	routerGroup := r.Group("/v1/asset-location")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.POST("/add", controller.AddLocation)
+		routerGroup.POST("/update/:id", controller.UpdateLocation)
+		routerGroup.GET("", controller.GetLocationTree)
+		routerGroup.GET("/:id", controller.GetLocationByID)
+		routerGroup.DELETE("/delete/:id", controller.DeleteLocation)
+	}
+
+	assetGroup := r.Group("/v1/asset")
+	assetGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		assetGroup.POST("/:id/move", controller.MoveAsset)
+		assetGroup.GET("/:id/location-history", controller.GetListLocationHistory)
+	}
+}
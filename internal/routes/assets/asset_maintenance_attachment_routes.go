@@ -0,0 +1,24 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetMaintenanceAttachmentRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetMaintenanceAttachmentController) {
+
+	routerGroup := r.Group("/v1/asset-maintenance")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("/:id/attachments", controller.GetMaintenanceAttachments)
+		routerGroup.POST("/:id/attachments", controller.AddMaintenanceAttachments)
+		routerGroup.DELETE("/attachments/:attachment_id", controller.DeleteAttachment)
+	}
+
+	recordGroup := r.Group("/v1/asset-maintenance-record")
+	recordGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		recordGroup.POST("/:id/attachments", controller.AddRecordAttachments)
+	}
+}
@@ -0,0 +1,17 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetMaintenanceCostRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetMaintenanceCostController) {
+
+	routerGroup := r.Group("/v1/asset-maintenance-cost")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("/summary", controller.GetCostSummary)
+		routerGroup.GET("/series", controller.GetCostSeries)
+	}
+}
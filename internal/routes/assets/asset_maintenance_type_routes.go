@@ -14,5 +14,15 @@ func AssetMaintenanceTypeRoutes(r *gin.Engine, middleware config.Middleware, con
 		routerGroup.POST("/", controller.CreateMaintenanceType)
 		routerGroup.GET("/:id", controller.GetMaintenanceByID)
 		routerGroup.GET("/", controller.GetListMaintenanceType)
+		routerGroup.PUT("/:id", controller.UpdateMaintenanceType)
+		routerGroup.DELETE("/:id", controller.DeleteMaintenanceType)
+	}
+
+	admin := r.Group("/assets-service/v1/assets/maintenance-type")
+	admin.Use(middleware.AdminMiddleware.HandlerAsset())
+	{
+		admin.POST("/add", controller.CreateDefaultMaintenanceType)
+		admin.POST("/update/:id", controller.UpdateDefaultMaintenanceType)
+		admin.DELETE("/delete/:id", controller.DeleteDefaultMaintenanceType)
 	}
 }
@@ -18,7 +18,9 @@ func AssetRoutes(r *gin.Engine, middleware config.Middleware, controller assets.
 		routerGroup.POST("/update-category/:id", controller.UpdateAssetCategory)
 		routerGroup.POST("/add-stock/:id", controller.AddStockAsset)
 		routerGroup.POST("/reduce-stock/:id", controller.ReduceStockAsset)
+		routerGroup.POST("/revert/:id", controller.RevertAsset)
 		routerGroup.GET("", controller.GetListAsset)
+		routerGroup.GET("/lookup", controller.LookupAsset)
 		routerGroup.GET("/:id", controller.GetAssetById)
 		routerGroup.DELETE("/delete/:id", controller.DeleteAsset)
 	}
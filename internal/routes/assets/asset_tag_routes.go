@@ -0,0 +1,28 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetTagRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetTagController) {
+
+	routerGroup := r.Group("/v1/asset-tag")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.POST("/add", controller.AddAssetTag)
+		routerGroup.POST("/update/:id", controller.UpdateAssetTag)
+		routerGroup.GET("", controller.GetListAssetTag)
+		routerGroup.GET("/:id", controller.GetAssetTagByID)
+		routerGroup.DELETE("/delete/:id", controller.DeleteAssetTag)
+	}
+
+	assetGroup := r.Group("/v1/asset")
+	assetGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		assetGroup.GET("/:id/tags", controller.GetAssetTags)
+		assetGroup.POST("/:id/tags", controller.AttachAssetTags)
+		assetGroup.DELETE("/:id/tags/:tag_id", controller.DetachAssetTag)
+	}
+}
@@ -0,0 +1,20 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetTransferRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetTransferController) {
+
+	routerGroup := r.Group("/v1/asset/transfer")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.POST("", controller.InitiateTransfer)
+		routerGroup.GET("", controller.GetListTransfer)
+		routerGroup.POST("/:id/accept", controller.AcceptTransfer)
+		routerGroup.POST("/:id/decline", controller.DeclineTransfer)
+		routerGroup.POST("/:id/cancel", controller.CancelTransfer)
+	}
+}
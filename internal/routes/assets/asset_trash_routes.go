@@ -0,0 +1,18 @@
+package assets
+
+import (
+	"asset-service/config"
+	"asset-service/internal/controller/assets"
+	"github.com/gin-gonic/gin"
+)
+
+func AssetTrashRoutes(r *gin.Engine, middleware config.Middleware, controller assets.AssetTrashController) {
+
+	routerGroup := r.Group("/v1/asset/trash")
+	routerGroup.Use(middleware.AssetMiddleware.HandlerAsset())
+	{
+		routerGroup.GET("", controller.GetListDeletedAsset)
+		routerGroup.POST("/restore/:id", controller.RestoreAsset)
+		routerGroup.DELETE("/purge/:id", controller.PurgeAsset)
+	}
+}
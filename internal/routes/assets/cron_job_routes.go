@@ -0,0 +1,23 @@
+package assets
+
+import (
+	"asset-service/config"
+	controllercron "asset-service/internal/utils/cron/controller"
+	"github.com/gin-gonic/gin"
+)
+
+func CronJobRoutes(r *gin.Engine, middleware config.Middleware, controller controllercron.CronJobController) {
+
+	adminGroup := r.Group("/v1/admin/cron-job")
+	adminGroup.Use(middleware.AdminMiddleware.HandlerAsset())
+	{
+		adminGroup.GET("", controller.GetCronJobs)
+		adminGroup.GET("/:id", controller.GetCronJob)
+		adminGroup.POST("", controller.CreateCronJob)
+		adminGroup.PUT("/:id", controller.UpdateCronJob)
+		adminGroup.POST("/:id/enable", controller.EnableCronJob)
+		adminGroup.POST("/:id/disable", controller.DisableCronJob)
+		adminGroup.POST("/:id/trigger", controller.TriggerCronJob)
+		adminGroup.GET("/:id/runs", controller.GetCronJobRuns)
+	}
+}
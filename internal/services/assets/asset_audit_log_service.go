@@ -0,0 +1,410 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/audit"
+	"asset-service/internal/utils/redis"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// auditChainBatchSize is how many entries of a chain are loaded at a time while it is walked
+const auditChainBatchSize = 500
+
+var errAuditCheckpointKey = errors.New("audit checkpoint key is not configured")
+
+type AssetAuditLogService interface {
+	GetListAuditLog(auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+	GetListAssetAuditLog(assetID uint, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+	VerifyAuditChain(clientID string) (interface{}, error)
+	VerifyAuditChains(tenantID string) ([]response.AuditChainVerificationResponse, error)
+	CreateAuditCheckpoints() error
+}
+
+type assetAuditLogService struct {
+	UserRepository          users.UserRepository
+	AssetRepository         repository.AssetRepository
+	AssetAuditLogRepository repository.AssetAuditLogRepository
+	Redis                   redis.RedisService
+	CheckpointKey           []byte
+}
+
+// NewAssetAuditLogService builds the service, checkpointKey signs the audit chain checkpoints
+func NewAssetAuditLogService(
+	userRepository users.UserRepository,
+	assetRepository repository.AssetRepository,
+	assetAuditLogRepository repository.AssetAuditLogRepository,
+	redis redis.RedisService,
+	checkpointKey string) AssetAuditLogService {
+	return assetAuditLogService{
+		UserRepository:          userRepository,
+		AssetRepository:         assetRepository,
+		AssetAuditLogRepository: assetAuditLogRepository,
+		Redis:                   redis,
+		CheckpointKey:           []byte(checkpointKey),
+	}
+}
+
+// GetListAuditLog lists the entries the caller performed or that touch the caller's rows, newest first
+func (s assetAuditLogService) GetListAuditLog(auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	count, err := s.AssetAuditLogRepository.GetCountAuditLogs(data.ClientID, auditQuery)
+	if err != nil {
+		return logListError("GetCountAuditLogs", clientID, err, "Failed to get count audit logs")
+	}
+
+	logs, err := s.AssetAuditLogRepository.GetListAuditLogs(data.ClientID, auditQuery, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListAuditLogs", clientID, err, "Failed to get audit logs")
+	}
+	return withAuditChanges(logs), count, nil
+}
+
+// GetListAssetAuditLog lists the history of an asset for its owner and the members of the groups it is shared with.
+// Members only see what happened since the asset was shared with their group
+func (s assetAuditLogService) GetListAssetAuditLog(assetID uint, auditQuery request.AuditLogQueryRequest, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	var since *time.Time
+	if _, err = s.AssetRepository.GetAssetByID(data.ClientID, assetID); err != nil {
+		caller, err := s.UserRepository.GetUserByClientID(data.ClientID)
+		if err != nil {
+			return logListError("GetUserByClientID", clientID, err, "Failed to get user by client ID")
+		}
+
+		since, err = s.AssetRepository.GetAssetSharedSince(assetID, caller.UserID)
+		if err != nil {
+			return logListError("GetAssetSharedSince", clientID, err, "Failed to check asset access")
+		}
+		if since == nil {
+			return logListError("GetListAssetAuditLog", clientID, errors.New("asset not found"), "User cannot see this asset")
+		}
+	}
+
+	count, err := s.AssetAuditLogRepository.GetCountAssetAuditLogs(assetID, since, auditQuery)
+	if err != nil {
+		return logListError("GetCountAssetAuditLogs", clientID, err, "Failed to get count asset audit logs")
+	}
+
+	logs, err := s.AssetAuditLogRepository.GetListAssetAuditLogs(assetID, since, auditQuery, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListAssetAuditLogs", clientID, err, "Failed to get asset audit logs")
+	}
+	return withAuditChanges(logs), count, nil
+}
+
+// VerifyAuditChain walks the audit chain of the caller
+func (s assetAuditLogService) VerifyAuditChain(clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	result, err := s.verifyChain(data.ClientID)
+	if err != nil {
+		return logError("VerifyAuditChain", clientID, err, "Failed to verify audit chain")
+	}
+	return result, nil
+}
+
+// VerifyAuditChains walks the audit chain of the tenant, or of every tenant when tenantID is empty
+func (s assetAuditLogService) VerifyAuditChains(tenantID string) ([]response.AuditChainVerificationResponse, error) {
+	tenants := []string{tenantID}
+	if tenantID == "" {
+		var err error
+		if tenants, err = s.AssetAuditLogRepository.GetAuditChainTenants(); err != nil {
+			return nil, logErrorWithNoReturn("GetAuditChainTenants", utils.SystemUser, err, "Failed to get audit chain tenants")
+		}
+	}
+
+	results := make([]response.AuditChainVerificationResponse, 0, len(tenants))
+	for _, tenant := range tenants {
+		result, err := s.verifyChain(tenant)
+		if err != nil {
+			return nil, logErrorWithNoReturn("VerifyAuditChains", tenant, err, "Failed to verify audit chain")
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// CreateAuditCheckpoints signs the head of every chain that moved since its last checkpoint
+func (s assetAuditLogService) CreateAuditCheckpoints() error {
+	if len(s.CheckpointKey) == 0 {
+		return logErrorWithNoReturn("CreateAuditCheckpoints", utils.SystemUser, errAuditCheckpointKey, "Failed to create audit checkpoints")
+	}
+
+	heads, err := s.AssetAuditLogRepository.GetUncheckpointedAuditChainHeads()
+	if err != nil {
+		return logErrorWithNoReturn("GetUncheckpointedAuditChainHeads", utils.SystemUser, err, "Failed to get audit chain heads")
+	}
+	for i := range heads {
+		heads[i].Signature = audit.SignCheckpoint(s.CheckpointKey, heads[i])
+	}
+
+	if err = s.AssetAuditLogRepository.AddAuditCheckpoints(heads); err != nil {
+		return logErrorWithNoReturn("AddAuditCheckpoints", utils.SystemUser, err, "Failed to add audit checkpoints")
+	}
+	log.Info().Str("key", "CreateAuditCheckpoints").Int("checkpoints", len(heads)).Msg("Success to create audit checkpoints")
+	return nil
+}
+
+// verifyChain recomputes every link of the tenant's chain and checks its checkpoints against it, stopping at
+// the first entry or checkpoint that does not match
+func (s assetAuditLogService) verifyChain(tenantID string) (*response.AuditChainVerificationResponse, error) {
+	checkpoints, err := s.AssetAuditLogRepository.GetAuditCheckpoints(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkpoints) > 0 && len(s.CheckpointKey) == 0 {
+		return nil, errAuditCheckpointKey
+	}
+
+	result := &response.AuditChainVerificationResponse{TenantID: tenantID}
+	next := 0
+	prevHash := ""
+	var afterLogID uint
+	for {
+		entries, err := s.AssetAuditLogRepository.GetAuditChainEntries(tenantID, afterLogID, auditChainBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range entries {
+			entry := entries[i]
+			// a checkpoint before this entry points at an entry that is gone
+			if next < len(checkpoints) && checkpoints[next].LogID < entry.LogID {
+				result.BrokenLink = s.checkpointBreak(checkpoints[next], nil)
+				return result, nil
+			}
+			if result.BrokenLink = chainBreak(entry, prevHash); result.BrokenLink != nil {
+				return result, nil
+			}
+			for ; next < len(checkpoints) && checkpoints[next].LogID == entry.LogID; next++ {
+				if result.BrokenLink = s.checkpointBreak(checkpoints[next], &entry); result.BrokenLink != nil {
+					return result, nil
+				}
+				result.CheckpointsChecked++
+			}
+
+			prevHash = *entry.Hash
+			result.EntriesChecked++
+			result.HeadLogID = &entry.LogID
+			result.HeadHash = entry.Hash
+			afterLogID = entry.LogID
+		}
+
+		if len(entries) < auditChainBatchSize {
+			break
+		}
+	}
+
+	// checkpoints past the last entry mean the end of the chain was cut off
+	if next < len(checkpoints) {
+		result.BrokenLink = s.checkpointBreak(checkpoints[next], nil)
+		return result, nil
+	}
+	result.Valid = true
+	return result, nil
+}
+
+// chainBreak reports how the entry fails to follow the entry hashed to prevHash, nil when it does
+func chainBreak(entry assets.AssetAuditLog, prevHash string) *response.AuditChainBreakResponse {
+	if entry.PrevHash == nil && prevHash != "" || entry.PrevHash != nil && *entry.PrevHash != prevHash {
+		var expected *string
+		if prevHash != "" {
+			expected = &prevHash
+		}
+		return &response.AuditChainBreakResponse{
+			LogID:    entry.LogID,
+			Reason:   "prev_hash does not match the previous entry, an entry was removed or reordered",
+			Expected: expected,
+			Actual:   entry.PrevHash,
+		}
+	}
+
+	hash := audit.ChainHash(entry)
+	if entry.Hash == nil || *entry.Hash != hash {
+		return &response.AuditChainBreakResponse{
+			LogID:    entry.LogID,
+			Reason:   "hash does not match the entry, it was edited",
+			Expected: &hash,
+			Actual:   entry.Hash,
+		}
+	}
+	return nil
+}
+
+// checkpointBreak reports how the checkpoint fails to match its entry, nil when it does; entry is nil when the
+// checkpointed entry is missing from the chain
+func (s assetAuditLogService) checkpointBreak(checkpoint assets.AssetAuditCheckpoint, entry *assets.AssetAuditLog) *response.AuditChainBreakResponse {
+	link := &response.AuditChainBreakResponse{
+		LogID:        checkpoint.LogID,
+		CheckpointID: &checkpoint.CheckpointID,
+		Expected:     &checkpoint.Hash,
+	}
+	switch {
+	case !audit.VerifyCheckpoint(s.CheckpointKey, checkpoint):
+		link.Reason = "checkpoint signature is invalid"
+		link.Expected = nil
+	case entry == nil:
+		link.Reason = "checkpointed entry is missing from the chain"
+	case entry.Hash == nil || *entry.Hash != checkpoint.Hash:
+		link.Reason = "entry hash does not match the checkpoint"
+		link.Actual = entry.Hash
+	default:
+		return nil
+	}
+	return link
+}
+
+func withAuditChanges(logs []response.AssetAuditLogResponse) []response.AssetAuditLogResponse {
+	if logs == nil {
+		return []response.AssetAuditLogResponse{}
+	}
+	for i := range logs {
+		logs[i].Changes = auditChanges(logs[i].OldData, logs[i].NewData)
+	}
+	return logs
+}
+
+// auditChanges compares the old and new row field by field, nested objects are walked down to dotted paths
+// while arrays and data that is not an object are compared as a whole
+func auditChanges(oldData, newData *string) []response.AssetAuditFieldChangeResponse {
+	changes := []response.AssetAuditFieldChangeResponse{}
+	diffAuditValues("", decodeAuditData(oldData), decodeAuditData(newData), &changes)
+	return changes
+}
+
+func diffAuditValues(field string, old, new interface{}, changes *[]response.AssetAuditFieldChangeResponse) {
+	oldObject, oldIsObject := old.(map[string]interface{})
+	newObject, newIsObject := new.(map[string]interface{})
+	if (oldIsObject || old == nil) && (newIsObject || new == nil) && (oldIsObject || newIsObject) {
+		keys := make([]string, 0, len(oldObject)+len(newObject))
+		for key := range oldObject {
+			keys = append(keys, key)
+		}
+		for key := range newObject {
+			if _, ok := oldObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			path := key
+			if field != "" {
+				path = field + "." + key
+			}
+			diffAuditValues(path, oldObject[key], newObject[key], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, response.AssetAuditFieldChangeResponse{Field: field, Old: old, New: new})
+	}
+}
+
+// decodeAuditData keeps numbers as written so ids and prices are compared and returned unchanged
+func decodeAuditData(data *string) interface{} {
+	if data == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(*data)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return *data
+	}
+	return value
+}
+
+// auditStateAsOf replays the entries of one row up to asOf. A full row entry replaces the state, since its
+// columns left out of the json are empty, the data of an older partial entry is merged over the state before it
+// and a delete without data removes the row. Without an entry up to asOf the row is the old data of the first
+// later entry, known is false when there is no entry at all to tell
+func auditStateAsOf(entries []response.AssetAuditLogResponse, asOf time.Time) (state map[string]interface{}, known bool) {
+	for i, entry := range entries {
+		if entry.PerformedAt.After(asOf) {
+			if i == 0 {
+				state, _ = decodeAuditData(entry.OldData).(map[string]interface{})
+			}
+			break
+		}
+
+		data, _ := decodeAuditData(entry.NewData).(map[string]interface{})
+		if data == nil {
+			state = nil
+			continue
+		}
+		if entry.FullRow {
+			state = data
+			continue
+		}
+		if state == nil || entry.Action == audit.ActionCreate {
+			state = map[string]interface{}{}
+		}
+		for key, value := range data {
+			state[key] = value
+		}
+	}
+	return state, len(entries) > 0
+}
+
+// rowAsOf fills dest with the row as it was at asOf, replayed from its entries or, when they do not tell, copied
+// from current. It reports whether the row existed at asOf
+func rowAsOf(entries []response.AssetAuditLogResponse, asOf time.Time, current interface{}, dest interface{}) (bool, error) {
+	state, known := auditStateAsOf(entries, asOf)
+	if !known {
+		data, err := json.Marshal(current)
+		if err != nil {
+			return false, err
+		}
+		if err = json.Unmarshal(data, &state); err != nil {
+			return false, err
+		}
+	}
+	if state == nil || state["deleted_at"] != nil {
+		return false, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, dest)
+}
+
+// auditOwner is the user_client_id of the row after its last entry, empty when it has none
+func auditOwner(entries []response.AssetAuditLogResponse) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	last := entries[len(entries)-1]
+	data, _ := decodeAuditData(last.NewData).(map[string]interface{})
+	if data == nil {
+		data, _ = decodeAuditData(last.OldData).(map[string]interface{})
+	}
+	owner, _ := data["user_client_id"].(string)
+	return owner
+}
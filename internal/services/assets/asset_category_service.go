@@ -1,8 +1,11 @@
 package assets
 
 import (
+	"asset-service/internal/utils/customfield"
+	"asset-service/internal/utils/depreciation"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
 
 	request "asset-service/internal/dto/in/assets"
@@ -15,34 +18,31 @@ import (
 )
 
 type AssetCategoryService interface {
-	AddAssetCategory(assetRequest *request.AssetCategoryRequest, credentialKey string, clientID string) (interface{}, error)
-	UpdateAssetCategory(assetCategoryID uint, assetCategoryRequest *request.AssetCategoryRequest, clientID string, credentialKey string) (interface{}, error)
+	AddAssetCategory(ctx context.Context, assetRequest *request.AssetCategoryRequest, credentialKey string, clientID string) (interface{}, error)
+	UpdateAssetCategory(ctx context.Context, assetCategoryID uint, assetCategoryRequest *request.AssetCategoryRequest, clientID string, credentialKey string) (interface{}, error)
 	GetListAssetCategory(clientID string, size int, index int) (interface{}, int64, error)
 	GetAssetCategoryById(categoryID uint, clientID string) (interface{}, error)
-	DeleteAssetCategory(categoryID uint, clientID string) error
+	DeleteAssetCategory(ctx context.Context, categoryID uint, clientID string) error
 }
 
 type assetCategoryService struct {
 	AssetCategoryRepository repository.AssetCategoryRepository
 	AssetRepository         repository.AssetRepository
-	AssetAuditLogRepository repository.AssetAuditLogRepository
 	Redis                   redis.RedisService
 }
 
 func NewAssetCategoryService(
 	assetCategoryRepository repository.AssetCategoryRepository,
 	AssetRepository repository.AssetRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
 	redis redis.RedisService) AssetCategoryService {
 	return &assetCategoryService{
 		AssetCategoryRepository: assetCategoryRepository,
 		AssetRepository:         AssetRepository,
-		AssetAuditLogRepository: AssetAuditLogRepository,
 		Redis:                   redis,
 	}
 }
 
-func (s *assetCategoryService) AddAssetCategory(assetRequest *request.AssetCategoryRequest, credentialKey string, clientID string) (interface{}, error) {
+func (s *assetCategoryService) AddAssetCategory(ctx context.Context, assetRequest *request.AssetCategoryRequest, credentialKey string, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().Str("clientID", clientID).Err(err).Msg("Failed to retrieve data from Redis")
@@ -55,6 +55,20 @@ func (s *assetCategoryService) AddAssetCategory(assetRequest *request.AssetCateg
 		return nil, err
 	}
 
+	settings, err := depreciationSettings(assetRequest, nil)
+	if err != nil {
+		log.Warn().Str("clientID", clientID).Err(err).Msg("Invalid depreciation settings")
+		return nil, err
+	}
+
+	var customFields customfield.Schema
+	if assetRequest.CustomFields != nil {
+		if customFields, err = customfield.NewSchema(*assetRequest.CustomFields); err != nil {
+			log.Warn().Str("clientID", clientID).Err(err).Msg("Invalid custom fields")
+			return nil, err
+		}
+	}
+
 	existingCategory, err := s.AssetCategoryRepository.GetAssetCategoryByNameAndClientID(assetRequest.CategoryName, data.ClientID)
 	if existingCategory != nil {
 		log.Warn().Str("category_name", assetRequest.CategoryName).Msg("Asset category already exists")
@@ -65,32 +79,24 @@ func (s *assetCategoryService) AddAssetCategory(assetRequest *request.AssetCateg
 		UserClientID: clientID,
 		CategoryName: assetRequest.CategoryName,
 		Description:  assetRequest.Description,
+		CustomFields: customFields,
 		CreatedBy:    &data.ClientID,
 		UpdatedBy:    &data.ClientID,
 	}
+	applyDepreciationSettings(assetCategory, settings)
 
-	err = s.AssetCategoryRepository.AddAssetCategory(assetCategory)
+	err = s.AssetCategoryRepository.AddAssetCategory(ctx, assetCategory)
 	if err != nil {
 		log.Error().Err(err).Str("category_name", assetRequest.CategoryName).Msg("Failed to add asset category")
 		return nil, err
 	}
 
-	if auditErr := s.AssetAuditLogRepository.AfterCreateAssetCategory(assetCategory); auditErr != nil {
-		log.Warn().Err(auditErr).
-			Str("category_name", assetCategory.CategoryName).
-			Msg("⚠ Audit log failed after adding asset category")
-	}
-
 	log.Info().Str("category_name", assetRequest.CategoryName).Msg("✅ Asset category added successfully")
 
-	return response.AssetCategoryResponse{
-		AssetCategoryID: assetCategory.AssetCategoryID,
-		CategoryName:    assetCategory.CategoryName,
-		Description:     assetCategory.Description,
-	}, nil
+	return toAssetCategoryResponse(assetCategory), nil
 }
 
-func (s *assetCategoryService) UpdateAssetCategory(assetCategoryID uint, assetCategoryRequest *request.AssetCategoryRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s *assetCategoryService) UpdateAssetCategory(ctx context.Context, assetCategoryID uint, assetCategoryRequest *request.AssetCategoryRequest, clientID string, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().Str("clientID", clientID).Err(err).Msg("Failed to retrieve user from Redis")
@@ -103,8 +109,7 @@ func (s *assetCategoryService) UpdateAssetCategory(assetCategoryID uint, assetCa
 		return nil, err
 	}
 
-	oldAssetCategory, err := s.AssetCategoryRepository.GetAssetCategoryById(assetCategoryID, clientID)
-	if err != nil {
+	if _, err = s.AssetCategoryRepository.GetAssetCategoryById(assetCategoryID, clientID); err != nil {
 		return nil, err
 	}
 
@@ -114,29 +119,33 @@ func (s *assetCategoryService) UpdateAssetCategory(assetCategoryID uint, assetCa
 		return nil, errors.New("asset category not found or already exists")
 	}
 
+	settings, err := depreciationSettings(assetCategoryRequest, assetCategory)
+	if err != nil {
+		log.Warn().Str("clientID", clientID).Err(err).Msg("Invalid depreciation settings")
+		return nil, err
+	}
+
+	if assetCategoryRequest.CustomFields != nil {
+		if assetCategory.CustomFields, err = customfield.NewSchema(*assetCategoryRequest.CustomFields); err != nil {
+			log.Warn().Str("clientID", clientID).Err(err).Msg("Invalid custom fields")
+			return nil, err
+		}
+	}
+
 	assetCategory.CategoryName = assetCategoryRequest.CategoryName
 	assetCategory.Description = assetCategoryRequest.Description
 	assetCategory.UpdatedBy = &data.ClientID
+	applyDepreciationSettings(assetCategory, settings)
 
-	err = s.AssetCategoryRepository.UpdateAssetCategory(assetCategory, clientID)
+	err = s.AssetCategoryRepository.UpdateAssetCategory(ctx, assetCategory, clientID)
 	if err != nil {
 		log.Error().Err(err).Uint("asset_category_id", assetCategoryID).Msg("Failed to update asset category")
 		return nil, err
 	}
 
-	if auditErr := s.AssetAuditLogRepository.AfterUpdateAssetCategory(oldAssetCategory, assetCategory); auditErr != nil {
-		log.Warn().Err(auditErr).
-			Uint("asset_category_id", assetCategory.AssetCategoryID).
-			Msg("⚠ Audit log failed after updating asset category")
-	}
-
 	log.Info().Uint("asset_category_id", assetCategoryID).Msg("✅ Asset category updated successfully")
 
-	return response.AssetCategoryResponse{
-		AssetCategoryID: assetCategory.AssetCategoryID,
-		CategoryName:    assetCategory.CategoryName,
-		Description:     assetCategory.Description,
-	}, nil
+	return toAssetCategoryResponse(assetCategory), nil
 }
 
 func (s *assetCategoryService) GetListAssetCategory(clientID string, size int, index int) (interface{}, int64, error) {
@@ -160,11 +169,7 @@ func (s *assetCategoryService) GetListAssetCategory(clientID string, size int, i
 
 	var assetCategoriesResponse []response.AssetCategoryResponse
 	for _, assetCategory := range assetCategories {
-		assetCategoriesResponse = append(assetCategoriesResponse, response.AssetCategoryResponse{
-			AssetCategoryID: assetCategory.AssetCategoryID,
-			CategoryName:    assetCategory.CategoryName,
-			Description:     assetCategory.Description,
-		})
+		assetCategoriesResponse = append(assetCategoriesResponse, toAssetCategoryResponse(&assetCategory))
 	}
 
 	return assetCategoriesResponse, total, nil
@@ -177,14 +182,10 @@ func (s *assetCategoryService) GetAssetCategoryById(categoryID uint, clientID st
 		return nil, errors.New("asset category not found")
 	}
 
-	return response.AssetCategoryResponse{
-		AssetCategoryID: assetCategory.AssetCategoryID,
-		CategoryName:    assetCategory.CategoryName,
-		Description:     assetCategory.Description,
-	}, nil
+	return toAssetCategoryResponse(assetCategory), nil
 }
 
-func (s *assetCategoryService) DeleteAssetCategory(categoryID uint, clientID string) error {
+func (s *assetCategoryService) DeleteAssetCategory(ctx context.Context, categoryID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().Str("clientID", clientID).Err(err).Msg("Failed to retrieve user from Redis")
@@ -209,16 +210,64 @@ func (s *assetCategoryService) DeleteAssetCategory(categoryID uint, clientID str
 	}
 
 	assetCategory.DeletedBy = &data.ClientID
-	if err = s.AssetCategoryRepository.DeleteAssetCategory(assetCategory); err != nil {
+	if err = s.AssetCategoryRepository.DeleteAssetCategory(ctx, assetCategory); err != nil {
 		log.Error().Err(err).Uint("asset_category_id", categoryID).Msg("Failed to delete asset category")
 		return err
 	}
 
-	if err = s.AssetAuditLogRepository.AfterDeleteAssetCategory(assetCategory); err != nil {
-		log.Error().Err(err).Uint("asset_category_id", categoryID).Msg("Failed to audit log after deleting asset category")
-		return err
-	}
-
 	log.Info().Uint("asset_category_id", categoryID).Msg("✅ Asset category deleted successfully")
 	return nil
 }
+
+// depreciationSettings normalizes and validates the depreciation part of a category request
+// depreciationSettings applies the depreciation fields sent in the request over the settings of the current
+// category, current is nil for a new category
+func depreciationSettings(assetCategoryRequest *request.AssetCategoryRequest, current *assets.AssetCategory) (depreciation.Settings, error) {
+	var settings depreciation.Settings
+	if current != nil {
+		if current.DepreciationMethod != nil {
+			settings.Method = *current.DepreciationMethod
+		}
+		if current.UsefulLifeMonths != nil {
+			settings.UsefulLifeMonths = *current.UsefulLifeMonths
+		}
+		settings.SalvageValue = current.SalvageValue
+	}
+
+	if assetCategoryRequest.DepreciationMethod != nil {
+		settings.Method = depreciation.NormalizeMethod(*assetCategoryRequest.DepreciationMethod)
+	}
+	if assetCategoryRequest.UsefulLifeMonths != nil {
+		settings.UsefulLifeMonths = *assetCategoryRequest.UsefulLifeMonths
+	}
+	if assetCategoryRequest.SalvageValue != nil {
+		settings.SalvageValue = *assetCategoryRequest.SalvageValue
+	}
+	return settings, settings.Validate()
+}
+
+// applyDepreciationSettings stores the settings on the category, an empty method clears them
+func applyDepreciationSettings(assetCategory *assets.AssetCategory, settings depreciation.Settings) {
+	if !settings.Enabled() {
+		assetCategory.DepreciationMethod = nil
+		assetCategory.UsefulLifeMonths = nil
+		assetCategory.SalvageValue = 0
+		return
+	}
+
+	assetCategory.DepreciationMethod = &settings.Method
+	assetCategory.UsefulLifeMonths = &settings.UsefulLifeMonths
+	assetCategory.SalvageValue = settings.SalvageValue
+}
+
+func toAssetCategoryResponse(assetCategory *assets.AssetCategory) response.AssetCategoryResponse {
+	return response.AssetCategoryResponse{
+		AssetCategoryID:    assetCategory.AssetCategoryID,
+		CategoryName:       assetCategory.CategoryName,
+		Description:        assetCategory.Description,
+		DepreciationMethod: assetCategory.DepreciationMethod,
+		UsefulLifeMonths:   assetCategory.UsefulLifeMonths,
+		SalvageValue:       assetCategory.SalvageValue,
+		CustomFields:       assetCategory.CustomFields,
+	}
+}
@@ -0,0 +1,91 @@
+package assets
+
+import (
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"context"
+	"errors"
+)
+
+type AssetComponentService interface {
+	AttachComponent(ctx context.Context, assetID, componentAssetID uint, clientID string) (interface{}, error)
+	DetachComponent(ctx context.Context, assetID, componentAssetID uint, clientID string) (interface{}, error)
+	GetComponents(assetID uint, clientID string) (interface{}, error)
+}
+
+type assetComponentService struct {
+	AssetRepository     repository.AssetRepository
+	ComponentRepository repository.AssetComponentRepository
+	Redis               redis.RedisService
+}
+
+func NewAssetComponentService(
+	assetRepository repository.AssetRepository,
+	componentRepository repository.AssetComponentRepository,
+	redis redis.RedisService) AssetComponentService {
+	return assetComponentService{
+		AssetRepository:     assetRepository,
+		ComponentRepository: componentRepository,
+		Redis:               redis,
+	}
+}
+
+// AttachComponent nests one asset of the caller into another, a component has at most one parent
+func (s assetComponentService) AttachComponent(ctx context.Context, assetID, componentAssetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if assetID == componentAssetID {
+		return logError("AttachComponent", clientID, errors.New("asset cannot be a component of itself"), "Invalid component")
+	}
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, errors.New("asset not found"), "Failed to get asset")
+	}
+	if _, err = s.AssetRepository.GetAsset(componentAssetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, errors.New("component asset not found"), "Failed to get component asset")
+	}
+
+	component, err := s.ComponentRepository.AttachComponent(ctx, assetID, componentAssetID, data.ClientID)
+	if err != nil {
+		return logError("AttachComponent", clientID, err, "Failed to attach component")
+	}
+	return component, nil
+}
+
+func (s assetComponentService) DetachComponent(ctx context.Context, assetID, componentAssetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, errors.New("asset not found"), "Failed to get asset")
+	}
+
+	component, err := s.ComponentRepository.DetachComponent(ctx, assetID, componentAssetID, data.ClientID)
+	if err != nil {
+		return logError("DetachComponent", clientID, err, "Failed to detach component")
+	}
+	return component, nil
+}
+
+// GetComponents lists the direct components of an asset of the caller
+func (s assetComponentService) GetComponents(assetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, errors.New("asset not found"), "Failed to get asset")
+	}
+
+	components, err := s.ComponentRepository.GetComponents(assetID)
+	if err != nil {
+		return logError("GetComponents", clientID, err, "Failed to get components")
+	}
+	return components, nil
+}
@@ -0,0 +1,140 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"fmt"
+)
+
+type AssetDashboardService interface {
+	GetDashboard(days int, clientID string) (*response.AssetDashboardResponse, error)
+	GetAssetGroupDashboard(assetGroupID uint, days int, clientID string) (*response.AssetDashboardResponse, error)
+}
+
+type assetDashboardService struct {
+	UserRepository        users.UserRepository
+	AssetGroupRepository  repository.AssetGroupRepository
+	MemberRepository      repository.AssetGroupMemberRepository
+	AssetRepository       repository.AssetRepository
+	AssetStockRepository  repository.AssetStockRepository
+	MaintenanceRepository repository.AssetMaintenanceRepository
+	WishlistRepository    repository.AssetWishlistRepository
+	Redis                 redis.RedisService
+}
+
+func NewAssetDashboardService(
+	userRepository users.UserRepository,
+	assetGroupRepository repository.AssetGroupRepository,
+	memberRepository repository.AssetGroupMemberRepository,
+	assetRepository repository.AssetRepository,
+	assetStockRepository repository.AssetStockRepository,
+	maintenanceRepository repository.AssetMaintenanceRepository,
+	wishlistRepository repository.AssetWishlistRepository,
+	redis redis.RedisService) AssetDashboardService {
+	return assetDashboardService{
+		UserRepository:        userRepository,
+		AssetGroupRepository:  assetGroupRepository,
+		MemberRepository:      memberRepository,
+		AssetRepository:       assetRepository,
+		AssetStockRepository:  assetStockRepository,
+		MaintenanceRepository: maintenanceRepository,
+		WishlistRepository:    wishlistRepository,
+		Redis:                 redis,
+	}
+}
+
+// GetDashboard summarizes the caller's own inventory, maintenance and warranties are looked up days ahead
+func (s assetDashboardService) GetDashboard(days int, clientID string) (*response.AssetDashboardResponse, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	dashboard, err := s.summarize(data.ClientID, 0, days)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.Wishlist, err = s.WishlistRepository.GetWishlistEstimate(data.ClientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetWishlistEstimate", clientID, err, "Failed to get wishlist estimate")
+	}
+	return dashboard, nil
+}
+
+// GetAssetGroupDashboard summarizes the inventory of an asset group for its owner and members
+func (s assetDashboardService) GetAssetGroupDashboard(assetGroupID uint, days int, clientID string) (*response.AssetDashboardResponse, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if _, _, err = checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, assetGroupID, data.ClientID); err != nil {
+		return nil, err
+	}
+
+	dashboard, err := s.summarize(data.ClientID, assetGroupID, days)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.AssetGroupID = &assetGroupID
+	return dashboard, nil
+}
+
+func (s assetDashboardService) summarize(clientID string, assetGroupID uint, days int) (*response.AssetDashboardResponse, error) {
+	if days == 0 {
+		days = utils.DashboardDefaultDays
+	}
+	if days < 1 || days > utils.DashboardMaxDays {
+		return nil, logErrorWithNoReturn("Dashboard", clientID, fmt.Errorf("days must be between 1 and %d", utils.DashboardMaxDays), "Invalid dashboard window")
+	}
+
+	dashboard := &response.AssetDashboardResponse{Days: days}
+	var err error
+
+	if dashboard.ByStatus, err = s.AssetRepository.GetCountAssetByStatus(clientID, assetGroupID); err != nil {
+		return nil, logErrorWithNoReturn("GetCountAssetByStatus", clientID, err, "Failed to count assets by status")
+	}
+	if dashboard.ByCategory, err = s.AssetRepository.GetCountAssetByCategory(clientID, assetGroupID); err != nil {
+		return nil, logErrorWithNoReturn("GetCountAssetByCategory", clientID, err, "Failed to count assets by category")
+	}
+	for _, status := range dashboard.ByStatus {
+		dashboard.TotalAssets += status.Count
+		dashboard.TotalPurchaseValue += status.TotalValue
+	}
+
+	if dashboard.TotalStock, err = s.AssetStockRepository.GetTotalStock(clientID, assetGroupID); err != nil {
+		return nil, logErrorWithNoReturn("GetTotalStock", clientID, err, "Failed to get total stock")
+	}
+
+	today := utils.GetToday()
+	until := today.AddDate(0, 0, days)
+
+	maintenance, err := s.MaintenanceRepository.GetListMaintenanceDueByScope(clientID, assetGroupID, until)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetListMaintenanceDueByScope", clientID, err, "Failed to get upcoming maintenance")
+	}
+	dashboard.UpcomingMaintenanceCount = len(maintenance)
+	dashboard.UpcomingMaintenance = maintenance[:min(len(maintenance), utils.DashboardListLimit)]
+
+	warranties, err := s.AssetRepository.GetListWarrantyExpiring(clientID, assetGroupID, today, until)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetListWarrantyExpiring", clientID, err, "Failed to get expiring warranties")
+	}
+	dashboard.ExpiringWarrantyCount = len(warranties)
+	dashboard.ExpiringWarranties = warranties[:min(len(warranties), utils.DashboardListLimit)]
+
+	if dashboard.ByStatus == nil {
+		dashboard.ByStatus = []response.AssetDashboardCountResponse{}
+	}
+	if dashboard.ByCategory == nil {
+		dashboard.ByCategory = []response.AssetDashboardCountResponse{}
+	}
+	if dashboard.UpcomingMaintenance == nil {
+		dashboard.UpcomingMaintenance = []response.AssetMaintenanceDueResponse{}
+	}
+	return dashboard, nil
+}
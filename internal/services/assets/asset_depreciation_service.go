@@ -0,0 +1,80 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/depreciation"
+	"asset-service/internal/utils/redis"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssetDepreciationService interface {
+	GetAssetDepreciation(assetID uint, clientID string) (interface{}, error)
+}
+
+type assetDepreciationService struct {
+	AssetRepository repository.AssetRepository
+	Redis           redis.RedisService
+}
+
+func NewAssetDepreciationService(assetRepository repository.AssetRepository, redis redis.RedisService) AssetDepreciationService {
+	return assetDepreciationService{
+		AssetRepository: assetRepository,
+		Redis:           redis,
+	}
+}
+
+// GetAssetDepreciation returns the yearly depreciation schedule of an asset based on the settings of its category
+func (s assetDepreciationService) GetAssetDepreciation(assetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	asset, err := s.AssetRepository.GetAssetResponseByID(data.ClientID, assetID)
+	if err != nil {
+		return logError("GetAssetResponseByID", clientID, errors.New("asset not found"), "Failed to get asset by ID")
+	}
+
+	settings := depreciation.NewSettings(asset.Category.DepreciationMethod, asset.Category.UsefulLifeMonths, asset.Category.SalvageValue)
+	if !settings.Enabled() {
+		return logError("GetAssetDepreciation", clientID, errors.New("asset category has no depreciation settings"), "Asset category does not depreciate")
+	}
+	if asset.PurchaseDate == nil || time.Time(*asset.PurchaseDate).IsZero() {
+		return logError("GetAssetDepreciation", clientID, errors.New("asset has no purchase date"), "Asset cannot be depreciated")
+	}
+
+	purchaseDate := time.Time(*asset.PurchaseDate)
+	schedule := make([]response.AssetDepreciationPeriodResponse, 0, settings.UsefulLifeMonths/12+1)
+	for _, period := range settings.Schedule(asset.Price, purchaseDate) {
+		schedule = append(schedule, response.AssetDepreciationPeriodResponse{
+			Period:                  period.Period,
+			StartDate:               utils.ToDateOnly(&period.StartDate),
+			EndDate:                 utils.ToDateOnly(&period.EndDate),
+			OpeningValue:            period.OpeningValue,
+			Depreciation:            period.Depreciation,
+			ClosingValue:            period.ClosingValue,
+			AccumulatedDepreciation: period.AccumulatedDepreciation,
+		})
+	}
+
+	log.Info().Str("key", "GetAssetDepreciation").Str("clientID", clientID).Uint("assetID", assetID).Msg("Success to get asset depreciation")
+	return response.AssetDepreciationResponse{
+		AssetID:                 asset.AssetID,
+		AssetName:               asset.Name,
+		CategoryName:            asset.Category.CategoryName,
+		DepreciationMethod:      settings.Method,
+		UsefulLifeMonths:        settings.UsefulLifeMonths,
+		PurchaseDate:            asset.PurchaseDate,
+		PurchasePrice:           asset.Price,
+		SalvageValue:            settings.SalvageValue,
+		BookValue:               asset.BookValue,
+		AccumulatedDepreciation: math.Round((asset.Price-asset.BookValue)*100) / 100,
+		Schedule:                schedule,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package assets
+
+import (
+	"asset-service/internal/models/assets"
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	nt "asset-service/internal/utils/nats"
+	"asset-service/internal/utils/redis"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssetExpiryService interface {
+	GetExpiringAssets(withinDays, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+	PerformExpiryCheck() error
+}
+
+type assetExpiryService struct {
+	AssetExpiryRepository repository.AssetExpiryRepository
+	Redis                 redis.RedisService
+	NatsService           nt.Service
+	ExpiryWithinDays      int
+}
+
+func NewAssetExpiryService(
+	assetExpiryRepository repository.AssetExpiryRepository,
+	redis redis.RedisService,
+	natsService nt.Service,
+	expiryWithinDays int) AssetExpiryService {
+	return assetExpiryService{
+		AssetExpiryRepository: assetExpiryRepository,
+		Redis:                 redis,
+		NatsService:           natsService,
+		ExpiryWithinDays:      expiryWithinDays,
+	}
+}
+
+func (s assetExpiryService) GetExpiringAssets(withinDays, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	today := utils.GetToday()
+	until := today.AddDate(0, 0, withinDays)
+
+	count, err := s.AssetExpiryRepository.GetCountExpiringAssets(data.ClientID, today, until)
+	if err != nil {
+		return logListError("GetCountExpiringAssets", clientID, err, "Failed to get count expiring assets")
+	}
+
+	result, err := s.AssetExpiryRepository.GetListExpiringAssets(data.ClientID, today, until, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListExpiringAssets", clientID, err, "Failed to get expiring assets")
+	}
+
+	return result, count, nil
+}
+
+// PerformExpiryCheck alerts owners once per asset, expiry type and date when a warranty or
+// expiry date enters the configured window, grouping the new alerts into one event per user. The alerts of
+// an event that fails to publish are dropped so the next check sends them again
+func (s assetExpiryService) PerformExpiryCheck() error {
+	now := time.Now()
+	today := utils.GetToday()
+
+	expiring, err := s.AssetExpiryRepository.GetAllExpiringAssets(today, today.AddDate(0, 0, s.ExpiryWithinDays))
+	if err != nil {
+		log.Error().Str("key", "GetAllExpiringAssets").Err(err).Msg("Failed to get expiring assets")
+		return err
+	}
+
+	createdBy := utils.SystemUser
+	events := make(map[string]*assets.AssetExpiringEvent)
+	alerted := make(map[string][]uint)
+	var clientIDs []string
+	for _, asset := range expiring {
+		expiryDate := time.Time(*asset.ExpiryDate)
+		alert := assets.AssetExpiryAlert{
+			UserClientID: asset.UserClientID,
+			AssetID:      asset.AssetID,
+			ExpiryType:   asset.ExpiryType,
+			ExpiryDate:   expiryDate,
+			CreatedBy:    &createdBy,
+		}
+		created, err := s.AssetExpiryRepository.AddExpiryAlert(&alert)
+		if err != nil {
+			log.Error().
+				Str("key", "AddExpiryAlert").
+				Str("clientID", asset.UserClientID).
+				Uint("assetID", asset.AssetID).
+				Err(err).
+				Msg("Failed to record expiry alert")
+			continue
+		}
+		if !created {
+			continue
+		}
+		alerted[asset.UserClientID] = append(alerted[asset.UserClientID], alert.AlertID)
+
+		event, ok := events[asset.UserClientID]
+		if !ok {
+			event = &assets.AssetExpiringEvent{ClientID: asset.UserClientID, CheckedAt: now, WithinDays: s.ExpiryWithinDays}
+			events[asset.UserClientID] = event
+			clientIDs = append(clientIDs, asset.UserClientID)
+		}
+		event.Assets = append(event.Assets, assets.AssetExpiringItem{
+			AssetID:         asset.AssetID,
+			AssetName:       asset.Name,
+			ExpiryType:      asset.ExpiryType,
+			ExpiryDate:      expiryDate.Format("2006-01-02"),
+			DaysUntilExpiry: asset.DaysUntilExpiry,
+		})
+	}
+
+	failed := 0
+	for _, clientID := range clientIDs {
+		if err := s.NatsService.PublishAssetExpiring(*events[clientID]); err != nil {
+			failed++
+			log.Error().Str("key", "PublishAssetExpiring").Str("clientID", clientID).Err(err).Msg("Failed to publish asset expiring event")
+			if err = s.AssetExpiryRepository.DeleteExpiryAlerts(alerted[clientID]); err != nil {
+				log.Error().Str("key", "DeleteExpiryAlerts").Str("clientID", clientID).Err(err).Msg("Failed to forget unsent expiry alerts")
+			}
+			continue
+		}
+		log.Info().Str("key", "PublishAssetExpiring").Str("clientID", clientID).Int("count", len(events[clientID].Assets)).Msg("Published asset expiring event")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to publish asset expiring events for %d of %d users", failed, len(clientIDs))
+	}
+	return nil
+}
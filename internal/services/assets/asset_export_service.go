@@ -0,0 +1,150 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	repo "asset-service/internal/repository/assets"
+	repouser "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/sheet"
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExportFunc writes a prepared export to w, it is returned after all checks passed so the
+// controller can send headers before the first row is streamed
+type ExportFunc func(w io.Writer) error
+
+type AssetExportService interface {
+	ExportAssets(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error)
+	ExportMaintenances(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error)
+	ExportMaintenanceRecords(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error)
+}
+
+type assetExportService struct {
+	UserRepository        repouser.UserRepository
+	AssetGroupRepository  repo.AssetGroupRepository
+	AssetExportRepository repo.AssetExportRepository
+	Redis                 redis.RedisService
+}
+
+func NewAssetExportService(
+	userRepository repouser.UserRepository,
+	assetGroupRepository repo.AssetGroupRepository,
+	assetExportRepository repo.AssetExportRepository,
+	redis redis.RedisService) AssetExportService {
+	return assetExportService{
+		UserRepository:        userRepository,
+		AssetGroupRepository:  assetGroupRepository,
+		AssetExportRepository: assetExportRepository,
+		Redis:                 redis,
+	}
+}
+
+func (s assetExportService) ExportAssets(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error) {
+	ownerClientID, err := s.exportScope(assetGroupID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		writer, err := sheet.NewWriter(w, format, response.AssetExportHeader)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		err = s.AssetExportRepository.StreamAssets(ownerClientID, assetGroupID, assetQuery, func(asset response.AssetExportResponse) error {
+			count++
+			return writer.Write(asset.Values(), asset)
+		})
+		return s.finishExport("ExportAssets", clientID, writer, count, err)
+	}, nil
+}
+
+func (s assetExportService) ExportMaintenances(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error) {
+	ownerClientID, err := s.exportScope(assetGroupID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		writer, err := sheet.NewWriter(w, format, response.MaintenanceExportHeader)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		err = s.AssetExportRepository.StreamMaintenances(ownerClientID, assetGroupID, assetQuery, func(maintenance response.MaintenanceExportResponse) error {
+			count++
+			return writer.Write(maintenance.Values(), maintenance)
+		})
+		return s.finishExport("ExportMaintenances", clientID, writer, count, err)
+	}, nil
+}
+
+func (s assetExportService) ExportMaintenanceRecords(format string, assetGroupID uint, assetQuery request.AssetQueryRequest, clientID string) (ExportFunc, error) {
+	ownerClientID, err := s.exportScope(assetGroupID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		writer, err := sheet.NewWriter(w, format, response.MaintenanceExportHeader)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		err = s.AssetExportRepository.StreamMaintenanceRecords(ownerClientID, assetGroupID, assetQuery, func(record response.MaintenanceExportResponse) error {
+			count++
+			return writer.Write(record.Values(), record)
+		})
+		return s.finishExport("ExportMaintenanceRecords", clientID, writer, count, err)
+	}, nil
+}
+
+// exportScope resolves the caller and, for group exports, checks that the caller owns the group
+func (s assetExportService) exportScope(assetGroupID uint, clientID string) (string, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return "", logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if assetGroupID == 0 {
+		return data.ClientID, nil
+	}
+
+	user, err := s.UserRepository.GetUserByClientID(data.ClientID)
+	if err != nil {
+		return "", logErrorWithNoReturn("GetUserByClientID", clientID, err, "Failed to get user by client ID")
+	}
+
+	assetGroup, err := s.AssetGroupRepository.GetAssetGroupByID(assetGroupID)
+	if err != nil || assetGroup == nil {
+		return "", logErrorWithNoReturn("GetAssetGroupByID", clientID, errors.New("asset group not found"), "Failed to get asset group")
+	}
+
+	if assetGroup.OwnerUserID != user.UserID {
+		return "", logErrorWithNoReturn("ExportScope", clientID, errors.New("only the asset group owner can export group data"), "User is not the asset group owner")
+	}
+	return data.ClientID, nil
+}
+
+func (s assetExportService) finishExport(key, clientID string, writer sheet.Writer, count int, err error) error {
+	if err != nil {
+		log.Error().Str("key", key).Str("clientID", clientID).Err(err).Msg("Failed to stream export")
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Error().Str("key", key).Str("clientID", clientID).Err(err).Msg("Failed to finish export")
+		return err
+	}
+
+	log.Info().Str("key", key).Str("clientID", clientID).Int("rows", count).Msg("Success to export")
+	return nil
+}
@@ -17,15 +17,13 @@ type AssetGroupAssetService interface {
 type assetGroupAssetService struct {
 	AssetGroupAssetRepository repository.AssetGroupAssetRepository
 	AssetRepository           repository.AssetRepository
-	AssetAuditLogRepository   repository.AssetAuditLogRepository
 	Redis                     redis.RedisService
 }
 
-func NewAssetGroupAssetService(AssetGroupAssetRepository repository.AssetGroupAssetRepository, AssetRepository repository.AssetRepository, AssetAuditLogRepository repository.AssetAuditLogRepository, redis redis.RedisService) AssetGroupAssetService {
+func NewAssetGroupAssetService(AssetGroupAssetRepository repository.AssetGroupAssetRepository, AssetRepository repository.AssetRepository, redis redis.RedisService) AssetGroupAssetService {
 	return &assetGroupAssetService{
 		AssetGroupAssetRepository: AssetGroupAssetRepository,
 		AssetRepository:           AssetRepository,
-		AssetAuditLogRepository:   AssetAuditLogRepository,
 		Redis:                     redis,
 	}
 }
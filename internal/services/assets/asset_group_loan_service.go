@@ -0,0 +1,231 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssetGroupLoanService interface {
+	CheckOutAsset(ctx context.Context, req *request.AssetLoanCheckOutRequest, clientID string) (interface{}, error)
+	ReturnAsset(ctx context.Context, loanID uint, req *request.AssetLoanReturnRequest, clientID string) (interface{}, error)
+	GetListLoan(assetGroupID uint, status string, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+}
+
+type assetGroupLoanService struct {
+	UserRepository             users.UserRepository
+	AssetGroupRepository       repository.AssetGroupRepository
+	MemberRepository           repository.AssetGroupMemberRepository
+	MemberPermissionRepository repository.AssetGroupMemberPermissionRepository
+	AssetRepository            repository.AssetRepository
+	LoanRepository             repository.AssetGroupAssetLoanRepository
+	Redis                      redis.RedisService
+}
+
+func NewAssetGroupLoanService(
+	userRepository users.UserRepository,
+	assetGroupRepository repository.AssetGroupRepository,
+	memberRepository repository.AssetGroupMemberRepository,
+	memberPermissionRepository repository.AssetGroupMemberPermissionRepository,
+	assetRepository repository.AssetRepository,
+	loanRepository repository.AssetGroupAssetLoanRepository,
+	redis redis.RedisService) AssetGroupLoanService {
+	return assetGroupLoanService{
+		UserRepository:             userRepository,
+		AssetGroupRepository:       assetGroupRepository,
+		MemberRepository:           memberRepository,
+		MemberPermissionRepository: memberPermissionRepository,
+		AssetRepository:            assetRepository,
+		LoanRepository:             loanRepository,
+		Redis:                      redis,
+	}
+}
+
+// CheckOutAsset lends a shared asset of the group to a member, the caller needs the Lend or Admin permission
+func (s assetGroupLoanService) CheckOutAsset(ctx context.Context, req *request.AssetLoanCheckOutRequest, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	userID, _, err := checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, req.AssetGroupID, data.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	canLend, err := s.canLend(userID, req.AssetGroupID)
+	if err != nil {
+		return logError("GetPermissionsByUserIDAndGroupID", clientID, err, "Failed to get member permissions")
+	}
+	if !canLend {
+		return logError("CheckOutAsset", clientID, nil, "User does not have permission to lend assets of this asset group")
+	}
+
+	borrowerUserID := req.BorrowerUserID
+	if borrowerUserID == 0 {
+		borrowerUserID = userID
+	}
+	if borrower, err := s.MemberRepository.GetAssetGroupMemberByUserIDAndGroupID(borrowerUserID, req.AssetGroupID); err != nil || borrower.AssetGroupID == 0 {
+		return logError("GetAssetGroupMemberByUserIDAndGroupID", clientID, nil, "Borrower is not a member of this asset group")
+	}
+
+	if _, err := s.AssetRepository.GetAssetByAssetGroupID(req.AssetID, req.AssetGroupID); err != nil {
+		return logError("GetAssetByAssetGroupID", clientID, errors.New("asset not found in asset group"), "Failed to get asset of asset group")
+	}
+
+	now := time.Now()
+	dueAt, err := parseDueAt(req.DueAt)
+	if err != nil {
+		return logError("CheckOutAsset", clientID, err, "Invalid due_at")
+	}
+	if !dueAt.After(now) {
+		return logError("CheckOutAsset", clientID, errors.New("due_at must be in the future"), "Invalid due_at")
+	}
+
+	loan := &assets.AssetGroupAssetLoan{
+		AssetGroupID:      req.AssetGroupID,
+		AssetID:           req.AssetID,
+		BorrowerUserID:    borrowerUserID,
+		LenderUserID:      userID,
+		CheckedOutAt:      now,
+		DueAt:             dueAt,
+		CheckoutCondition: req.CheckoutCondition,
+		CreatedBy:         &clientID,
+		UpdatedBy:         &clientID,
+	}
+	if err := s.LoanRepository.AddLoan(ctx, loan); err != nil {
+		return logError("AddLoan", clientID, err, "Failed to check out asset")
+	}
+
+	log.Info().Str("key", "CheckOutAsset").Str("clientID", clientID).Uint("loanID", loan.LoanID).Uint("assetID", loan.AssetID).Msg("Asset checked out")
+	return toAssetLoanResponse(loan, now), nil
+}
+
+// ReturnAsset closes an open loan, the borrower can always return, other members need the Lend or Admin permission
+func (s assetGroupLoanService) ReturnAsset(ctx context.Context, loanID uint, req *request.AssetLoanReturnRequest, clientID string) (interface{}, error) {
+	loan, err := s.LoanRepository.GetLoanByID(loanID)
+	if err != nil {
+		return logError("GetLoanByID", clientID, errors.New("loan not found"), "Failed to get loan")
+	}
+	if loan.ReturnedAt != nil {
+		return logError("ReturnAsset", clientID, errors.New("loan is already returned"), "Loan is already returned")
+	}
+
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	userID, _, err := checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, loan.AssetGroupID, data.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != loan.BorrowerUserID {
+		canLend, err := s.canLend(userID, loan.AssetGroupID)
+		if err != nil {
+			return logError("GetPermissionsByUserIDAndGroupID", clientID, err, "Failed to get member permissions")
+		}
+		if !canLend {
+			return logError("ReturnAsset", clientID, nil, "User does not have permission to return this loan")
+		}
+	}
+
+	now := time.Now()
+	loan.ReturnedAt = &now
+	loan.ReturnCondition = req.ReturnCondition
+	loan.UpdatedBy = &clientID
+	if err := s.LoanRepository.ReturnLoan(ctx, loan); err != nil {
+		return logError("ReturnLoan", clientID, err, "Failed to return asset")
+	}
+
+	log.Info().Str("key", "ReturnAsset").Str("clientID", clientID).Uint("loanID", loan.LoanID).Uint("assetID", loan.AssetID).Msg("Asset returned")
+	return toAssetLoanResponse(loan, now), nil
+}
+
+// GetListLoan lists the loans of a group ordered by due date, status is open, returned, overdue or empty for all
+func (s assetGroupLoanService) GetListLoan(assetGroupID uint, status string, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if _, _, err = checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, assetGroupID, data.ClientID); err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	count, err := s.LoanRepository.GetCountLoans(assetGroupID, status, now)
+	if err != nil {
+		return logListError("GetCountLoans", clientID, err, "Failed to get count loans")
+	}
+
+	loans, err := s.LoanRepository.GetListLoans(assetGroupID, status, now, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListLoans", clientID, err, "Failed to get loans")
+	}
+	return loans, count, nil
+}
+
+func (s assetGroupLoanService) canLend(userID, assetGroupID uint) (bool, error) {
+	assetGroup, err := s.AssetGroupRepository.GetAssetGroupByID(assetGroupID)
+	if err != nil {
+		return false, err
+	}
+	if assetGroup != nil && assetGroup.OwnerUserID == userID {
+		return true, nil
+	}
+
+	permissions, err := s.MemberPermissionRepository.GetPermissionsByUserIDAndGroupID(userID, assetGroupID)
+	if err != nil {
+		return false, err
+	}
+	for _, permission := range permissions {
+		if permission.PermissionName == utils.AssetGroupPermissionAdmin || permission.PermissionName == utils.AssetGroupPermissionLend {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseDueAt accepts an RFC 3339 timestamp or a date, a date is due at the end of that day
+func parseDueAt(value string) (time.Time, error) {
+	if dueAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueAt, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("due_at must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+	return date.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+func toAssetLoanResponse(loan *assets.AssetGroupAssetLoan, now time.Time) response.AssetGroupAssetLoanResponse {
+	loanResponse := response.AssetGroupAssetLoanResponse{
+		LoanID:            loan.LoanID,
+		AssetGroupID:      loan.AssetGroupID,
+		AssetID:           loan.AssetID,
+		BorrowerUserID:    loan.BorrowerUserID,
+		LenderUserID:      loan.LenderUserID,
+		CheckedOutAt:      loan.CheckedOutAt,
+		DueAt:             loan.DueAt,
+		ReturnedAt:        loan.ReturnedAt,
+		CheckoutCondition: loan.CheckoutCondition,
+		ReturnCondition:   loan.ReturnCondition,
+	}
+	if loan.ReturnedAt == nil && loan.DueAt.Before(now) {
+		loanResponse.Overdue = true
+		loanResponse.DaysOverdue = utils.DaysBetween(loan.DueAt.In(now.Location()), now)
+	}
+	return loanResponse
+}
@@ -8,14 +8,15 @@ import (
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
 )
 
 type AssetGroupMemberService interface {
-	AddAssetGroupMember(req *request.AssetGroupMemberRequest, clientID string) error
-	RemoveMemberAssetGroup(memberRequest request.AssetGroupMemberRequest, clientID string) error
+	AddAssetGroupMember(ctx context.Context, req *request.AssetGroupMemberRequest, clientID string) error
+	RemoveMemberAssetGroup(ctx context.Context, memberRequest request.AssetGroupMemberRequest, clientID string) error
 	GetListAssetGroupMember(assetGroupID uint, clientID string) (interface{}, error)
-	LeaveMemberAssetGroup(assetGroupID uint, clientID string) error
+	LeaveMemberAssetGroup(ctx context.Context, assetGroupID uint, clientID string) error
 }
 
 type assetGroupMemberService struct {
@@ -26,7 +27,6 @@ type assetGroupMemberService struct {
 	AssetGroupMemberRepository           repository.AssetGroupMemberRepository
 	AssetRepository                      repository.AssetRepository
 	AssetGroupInvitation                 repository.AssetGroupInvitationRepository
-	AssetAuditLogRepository              repository.AssetAuditLogRepository
 	Redis                                redis.RedisService
 }
 
@@ -38,7 +38,6 @@ func NewAssetGroupMemberService(
 	assetCategoryRepository repository.AssetGroupMemberRepository,
 	AssetRepository repository.AssetRepository,
 	AssetGroupInvitation repository.AssetGroupInvitationRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
 	redis redis.RedisService) AssetGroupMemberService {
 	return &assetGroupMemberService{
 		UserRepository:                       userRepository,
@@ -48,12 +47,11 @@ func NewAssetGroupMemberService(
 		AssetGroupMemberRepository:           assetCategoryRepository,
 		AssetRepository:                      AssetRepository,
 		AssetGroupInvitation:                 AssetGroupInvitation,
-		AssetAuditLogRepository:              AssetAuditLogRepository,
 		Redis:                                redis,
 	}
 }
 
-func (s *assetGroupMemberService) AddAssetGroupMember(req *request.AssetGroupMemberRequest, clientID string) error {
+func (s *assetGroupMemberService) AddAssetGroupMember(ctx context.Context, req *request.AssetGroupMemberRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -137,7 +135,7 @@ func (s *assetGroupMemberService) AddAssetGroupMember(req *request.AssetGroupMem
 			InvitedUserToken: inviteToken,
 			InvitedByUserID:  user.UserID,
 		}
-		err = s.AssetGroupInvitation.AddAssetGroupInvitation(invitation)
+		err = s.AssetGroupInvitation.AddAssetGroupInvitation(ctx, invitation)
 		if err != nil {
 			return logErrorWithNoReturn("AddAssetGroupInvitation", clientID, err, "Failed to add asset group invitation")
 		}
@@ -172,7 +170,7 @@ func (s *assetGroupMemberService) AddAssetGroupMember(req *request.AssetGroupMem
 			CreatedBy:    user.ClientID,
 		}
 
-		err = s.AssetGroupMemberRepository.AddAssetGroupMember(groupMember, user.ClientID, member.ClientID)
+		err = s.AssetGroupMemberRepository.AddAssetGroupMember(ctx, groupMember, user.ClientID, member.ClientID)
 		if err != nil {
 			return logErrorWithNoReturn("AddAssetGroupMember", clientID, err, "Failed to add asset group member")
 		}
@@ -180,7 +178,7 @@ func (s *assetGroupMemberService) AddAssetGroupMember(req *request.AssetGroupMem
 	return nil
 }
 
-func (s *assetGroupMemberService) RemoveMemberAssetGroup(memberRequest request.AssetGroupMemberRequest, clientID string) error {
+func (s *assetGroupMemberService) RemoveMemberAssetGroup(ctx context.Context, memberRequest request.AssetGroupMemberRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -240,7 +238,7 @@ func (s *assetGroupMemberService) RemoveMemberAssetGroup(memberRequest request.A
 		return logErrorWithNoReturn("GetAssetGroupMemberByUserIDAndGroupID", clientID, errors.New("user is not a member of this asset group"), "User is not a member of this asset group")
 	}
 
-	err = s.AssetGroupMemberRepository.RemoveAssetGroupMember(memberRequest.AssetGroupID, memberRequest.UserID)
+	err = s.AssetGroupMemberRepository.RemoveAssetGroupMember(ctx, memberRequest.AssetGroupID, memberRequest.UserID)
 	if err != nil {
 		return logErrorWithNoReturn("RemoveAssetGroupMember", clientID, err, "Failed to remove asset group member")
 	}
@@ -248,7 +246,7 @@ func (s *assetGroupMemberService) RemoveMemberAssetGroup(memberRequest request.A
 	return nil
 }
 
-func (s *assetGroupMemberService) LeaveMemberAssetGroup(assetGroupID uint, clientID string) error {
+func (s *assetGroupMemberService) LeaveMemberAssetGroup(ctx context.Context, assetGroupID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -268,7 +266,7 @@ func (s *assetGroupMemberService) LeaveMemberAssetGroup(assetGroupID uint, clien
 		return logErrorWithNoReturn("GetAssetGroupMemberByUserIDAndGroupID", clientID, errors.New("user is not a member of this asset group"), "User is not a member of this asset group")
 	}
 
-	err = s.AssetGroupMemberRepository.RemoveAssetGroupMember(assetGroupID, user.UserID)
+	err = s.AssetGroupMemberRepository.RemoveAssetGroupMember(ctx, assetGroupID, user.UserID)
 	if err != nil {
 		return logErrorWithNoReturn("RemoveAssetGroupMember", clientID, err, "Failed to remove asset group member")
 	}
@@ -6,37 +6,35 @@ import (
 	repository "asset-service/internal/repository/assets"
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
+	"context"
 )
 
 type AssetGroupPermissionService interface {
-	AddAssetGroupPermission(assetGroupPermissionRequest *request.AssetGroupPermissionRequest, clientID string) error
-	UpdateAssetGroupPermission(permissionID uint, assetCategoryRequest *request.AssetGroupPermissionRequest, clientID string) error
+	AddAssetGroupPermission(ctx context.Context, assetGroupPermissionRequest *request.AssetGroupPermissionRequest, clientID string) error
+	UpdateAssetGroupPermission(ctx context.Context, permissionID uint, assetCategoryRequest *request.AssetGroupPermissionRequest, clientID string) error
 	GetListAssetGroupPermission(clientID string) (interface{}, error)
 	GetAssetGroupPermissionById(permissionID uint, clientID string) (interface{}, error)
-	DeleteAssetGroupPermission(permissionID uint, clientID string) error
+	DeleteAssetGroupPermission(ctx context.Context, permissionID uint, clientID string) error
 }
 
 type assetGroupPermissionService struct {
 	AssetGroupPermissionRepository repository.AssetGroupPermissionRepository
 	AssetRepository                repository.AssetRepository
-	AssetAuditLogRepository        repository.AssetAuditLogRepository
 	Redis                          redis.RedisService
 }
 
 func NewAssetGroupPermissionService(
 	assetCategoryRepository repository.AssetGroupPermissionRepository,
 	AssetRepository repository.AssetRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
 	redis redis.RedisService) AssetGroupPermissionService {
 	return &assetGroupPermissionService{
 		AssetGroupPermissionRepository: assetCategoryRepository,
 		AssetRepository:                AssetRepository,
-		AssetAuditLogRepository:        AssetAuditLogRepository,
 		Redis:                          redis,
 	}
 }
 
-func (s *assetGroupPermissionService) AddAssetGroupPermission(req *request.AssetGroupPermissionRequest, clientID string) error {
+func (s *assetGroupPermissionService) AddAssetGroupPermission(ctx context.Context, req *request.AssetGroupPermissionRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -48,14 +46,14 @@ func (s *assetGroupPermissionService) AddAssetGroupPermission(req *request.Asset
 		CreatedBy:      &data.ClientID,
 	}
 
-	if err := s.AssetGroupPermissionRepository.AddAssetGroupPermission(assetPermission); err != nil {
+	if err := s.AssetGroupPermissionRepository.AddAssetGroupPermission(ctx, assetPermission); err != nil {
 		return logErrorWithNoReturn("AddAssetGroupPermission", clientID, err, "Failed to add asset group permission")
 	}
 
 	return nil
 }
 
-func (s *assetGroupPermissionService) UpdateAssetGroupPermission(permissionID uint, assetCategoryRequest *request.AssetGroupPermissionRequest, clientID string) error {
+func (s *assetGroupPermissionService) UpdateAssetGroupPermission(ctx context.Context, permissionID uint, assetCategoryRequest *request.AssetGroupPermissionRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -70,7 +68,7 @@ func (s *assetGroupPermissionService) UpdateAssetGroupPermission(permissionID ui
 	assetPermission.Description = assetCategoryRequest.Description
 	assetPermission.UpdatedBy = &data.ClientID
 
-	if err := s.AssetGroupPermissionRepository.UpdateAssetGroupPermission(assetPermission); err != nil {
+	if err := s.AssetGroupPermissionRepository.UpdateAssetGroupPermission(ctx, assetPermission); err != nil {
 		return logErrorWithNoReturn("UpdateAssetGroupPermission", clientID, err, "Failed to update asset group permission")
 	}
 
@@ -105,7 +103,7 @@ func (s *assetGroupPermissionService) GetAssetGroupPermissionById(permissionID u
 	return permission, nil
 }
 
-func (s *assetGroupPermissionService) DeleteAssetGroupPermission(permissionID uint, clientID string) error {
+func (s *assetGroupPermissionService) DeleteAssetGroupPermission(ctx context.Context, permissionID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -118,7 +116,7 @@ func (s *assetGroupPermissionService) DeleteAssetGroupPermission(permissionID ui
 
 	assetPermission.DeletedBy = &data.ClientID
 
-	if err := s.AssetGroupPermissionRepository.DeleteAssetGroupPermission(assetPermission); err != nil {
+	if err := s.AssetGroupPermissionRepository.DeleteAssetGroupPermission(ctx, assetPermission); err != nil {
 		return logErrorWithNoReturn("DeleteAssetGroupPermission", clientID, err, "Failed to delete asset group permission")
 	}
 
@@ -9,24 +9,25 @@ import (
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 )
 
 type AssetGroupService interface {
-	AddAssetGroup(assetRequest *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error)
-	AddInvitationAssetGroup(assetGroupID uint, clientID string) (interface{}, error)
-	RemoveInvitationAssetGroup(assetGroupID uint, clientID string) error
-	UpdateAssetGroup(assetGroupID uint, req *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error)
+	AddAssetGroup(ctx context.Context, assetRequest *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error)
+	AddInvitationAssetGroup(ctx context.Context, assetGroupID uint, clientID string) (interface{}, error)
+	RemoveInvitationAssetGroup(ctx context.Context, assetGroupID uint, clientID string) error
+	UpdateAssetGroup(ctx context.Context, assetGroupID uint, req *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error)
 	GetAssetGroupDetail(clientID string) (interface{}, error)
 	GetAssetGroupAssetByAssetGroupID(assetGroupID uint, clientID string) (interface{}, error)
-	DeleteAssetGroup(assetGroupID uint, clientID string, credentialKey string) error
-	InviteMemberAssetGroup(req *request.AssetGroupMemberRequest, clientID string) error
-	RemoveMemberAssetGroup(memberRequest request.AssetGroupMemberRequest, clientID string) error
-	AddPermissionMemberAssetGroup(req *request.ChangeAssetGroupPermissionRequest, clientID string) error
-	RemovePermissionMemberAssetGroup(req *request.ChangeAssetGroupPermissionRequest, clientID string) error
-	GetListAssetGroupAsset(assetGroupID uint, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
-	UpdateStockAssetGroupAsset(isAdded bool, req request.ChangeAssetStockRequest, clientID string) (interface{}, error)
+	DeleteAssetGroup(ctx context.Context, assetGroupID uint, clientID string, credentialKey string) error
+	InviteMemberAssetGroup(ctx context.Context, req *request.AssetGroupMemberRequest, clientID string) error
+	RemoveMemberAssetGroup(ctx context.Context, memberRequest request.AssetGroupMemberRequest, clientID string) error
+	AddPermissionMemberAssetGroup(ctx context.Context, req *request.ChangeAssetGroupPermissionRequest, clientID string) error
+	RemovePermissionMemberAssetGroup(ctx context.Context, req *request.ChangeAssetGroupPermissionRequest, clientID string) error
+	GetListAssetGroupAsset(assetGroupID uint, pageIndex, pageSize int, assetQuery request.AssetQueryRequest, clientID string) (interface{}, int64, error)
+	UpdateStockAssetGroupAsset(ctx context.Context, isAdded bool, req request.ChangeAssetStockRequest, clientID string) (interface{}, error)
 }
 
 type assetGroupService struct {
@@ -38,11 +39,10 @@ type assetGroupService struct {
 	assetGroupAssetRepository  repository.AssetGroupAssetRepository
 	AssetRepository            repository.AssetRepository
 	AssetStockRepository       repository.AssetStockRepository
-	AssetAuditLogRepository    repository.AssetAuditLogRepository
 	Redis                      redis.RedisService
 }
 
-func NewAssetGroupService(UserRepository users.UserRepository, AssetGroupRepository repository.AssetGroupRepository, permissionRepository repository.AssetGroupPermissionRepository, memberPermissionRepository repository.AssetGroupMemberPermissionRepository, memberRepository repository.AssetGroupMemberRepository, assetGroupAssetRepository repository.AssetGroupAssetRepository, AssetRepository repository.AssetRepository, AssetStockRepository repository.AssetStockRepository, AssetAuditLogRepository repository.AssetAuditLogRepository, redis redis.RedisService) AssetGroupService {
+func NewAssetGroupService(UserRepository users.UserRepository, AssetGroupRepository repository.AssetGroupRepository, permissionRepository repository.AssetGroupPermissionRepository, memberPermissionRepository repository.AssetGroupMemberPermissionRepository, memberRepository repository.AssetGroupMemberRepository, assetGroupAssetRepository repository.AssetGroupAssetRepository, AssetRepository repository.AssetRepository, AssetStockRepository repository.AssetStockRepository, redis redis.RedisService) AssetGroupService {
 	return &assetGroupService{
 		UserRepository:             UserRepository,
 		AssetGroupRepository:       AssetGroupRepository,
@@ -52,12 +52,11 @@ func NewAssetGroupService(UserRepository users.UserRepository, AssetGroupReposit
 		assetGroupAssetRepository:  assetGroupAssetRepository,
 		AssetRepository:            AssetRepository,
 		AssetStockRepository:       AssetStockRepository,
-		AssetAuditLogRepository:    AssetAuditLogRepository,
 		Redis:                      redis,
 	}
 }
 
-func (s *assetGroupService) AddAssetGroup(assetRequest *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s *assetGroupService) AddAssetGroup(ctx context.Context, assetRequest *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logError("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -102,7 +101,7 @@ func (s *assetGroupService) AddAssetGroup(assetRequest *request.AssetGroupReques
 		UpdatedBy:      &user.ClientID,
 	}
 
-	err = s.AssetGroupRepository.AddAssetGroup(assetGroup, clientID, user)
+	err = s.AssetGroupRepository.AddAssetGroup(ctx, assetGroup, clientID, user)
 	if err != nil {
 		return logError("AddAssetGroup", clientID, err, "Failed to add asset group")
 	}
@@ -117,7 +116,7 @@ func (s *assetGroupService) AddAssetGroup(assetRequest *request.AssetGroupReques
 	}, nil
 }
 
-func (s *assetGroupService) AddInvitationAssetGroup(assetGroupID uint, clientID string) (interface{}, error) {
+func (s *assetGroupService) AddInvitationAssetGroup(ctx context.Context, assetGroupID uint, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logError("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -174,7 +173,7 @@ func (s *assetGroupService) AddInvitationAssetGroup(assetGroupID uint, clientID
 	if err != nil {
 		return logError("GenerateInviteToken", clientID, err, "Failed to generate invitation token")
 	}
-	err = s.AssetGroupRepository.AddInvitationToken(assetGroupID, invitationToken, user.ClientID)
+	err = s.AssetGroupRepository.AddInvitationToken(ctx, assetGroupID, invitationToken, user.ClientID)
 	if err != nil {
 		return logError("AddInvitationToken", clientID, err, "Failed to add invitation token")
 	}
@@ -186,7 +185,7 @@ func (s *assetGroupService) AddInvitationAssetGroup(assetGroupID uint, clientID
 	}, nil
 }
 
-func (s *assetGroupService) RemoveInvitationAssetGroup(assetGroupID uint, clientID string) error {
+func (s *assetGroupService) RemoveInvitationAssetGroup(ctx context.Context, assetGroupID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -239,7 +238,7 @@ func (s *assetGroupService) RemoveInvitationAssetGroup(assetGroupID uint, client
 		return logErrorWithNoReturn("GetAssetGroupPermissionByUserID", clientID, nil, "User does not have permission to add invitation token")
 	}
 
-	err = s.AssetGroupRepository.RemoveInvitationToken(assetGroupID, user.ClientID)
+	err = s.AssetGroupRepository.RemoveInvitationToken(ctx, assetGroupID, user.ClientID)
 	if err != nil {
 		return logErrorWithNoReturn("AddInvitationToken", clientID, err, "Failed to add invitation token")
 	}
@@ -247,7 +246,7 @@ func (s *assetGroupService) RemoveInvitationAssetGroup(assetGroupID uint, client
 	return nil
 }
 
-func (s *assetGroupService) UpdateAssetGroup(assetGroupID uint, req *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s *assetGroupService) UpdateAssetGroup(ctx context.Context, assetGroupID uint, req *request.AssetGroupRequest, clientID string, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return nil, logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -310,7 +309,7 @@ func (s *assetGroupService) UpdateAssetGroup(assetGroupID uint, req *request.Ass
 	assetGroup.AssetGroupName = req.AssetGroupName
 	assetGroup.Description = req.Description
 	assetGroup.UpdatedBy = &user.ClientID
-	err = s.AssetGroupRepository.UpdateAssetGroup(assetGroup)
+	err = s.AssetGroupRepository.UpdateAssetGroup(ctx, assetGroup)
 	if err != nil {
 		return nil, logErrorWithNoReturn("UpdateAssetGroup", clientID, err, "Failed to update asset group")
 	}
@@ -401,7 +400,7 @@ func (s *assetGroupService) GetAssetGroupAssetByAssetGroupID(assetGroupID uint,
 	return assetsList, nil
 }
 
-func (s *assetGroupService) DeleteAssetGroup(assetGroupID uint, clientID string, credentialKey string) error {
+func (s *assetGroupService) DeleteAssetGroup(ctx context.Context, assetGroupID uint, clientID string, credentialKey string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -460,7 +459,7 @@ func (s *assetGroupService) DeleteAssetGroup(assetGroupID uint, clientID string,
 	}
 
 	// Delete asset group
-	err = s.AssetGroupRepository.DeleteAssetGroup(assetGroupID, user.UserID)
+	err = s.AssetGroupRepository.DeleteAssetGroup(ctx, assetGroupID, user.UserID)
 	if err != nil {
 		return logErrorWithNoReturn("DeleteAssetGroup", clientID, err, "Failed to delete asset group")
 	}
@@ -468,7 +467,7 @@ func (s *assetGroupService) DeleteAssetGroup(assetGroupID uint, clientID string,
 	return nil
 }
 
-func (s *assetGroupService) InviteMemberAssetGroup(req *request.AssetGroupMemberRequest, clientID string) error {
+func (s *assetGroupService) InviteMemberAssetGroup(ctx context.Context, req *request.AssetGroupMemberRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -533,14 +532,14 @@ func (s *assetGroupService) InviteMemberAssetGroup(req *request.AssetGroupMember
 		CreatedBy:    user.ClientID,
 	}
 
-	err = s.memberRepository.AddAssetGroupMember(groupMember, user.ClientID, member.ClientID)
+	err = s.memberRepository.AddAssetGroupMember(ctx, groupMember, user.ClientID, member.ClientID)
 	if err != nil {
 		return logErrorWithNoReturn("AddAssetGroupMember", clientID, err, "Failed to add asset group member")
 	}
 	return nil
 }
 
-func (s *assetGroupService) RemoveMemberAssetGroup(memberRequest request.AssetGroupMemberRequest, clientID string) error {
+func (s *assetGroupService) RemoveMemberAssetGroup(ctx context.Context, memberRequest request.AssetGroupMemberRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -600,14 +599,14 @@ func (s *assetGroupService) RemoveMemberAssetGroup(memberRequest request.AssetGr
 		return logErrorWithNoReturn("GetAssetGroupMemberByUserIDAndGroupID", clientID, nil, "User is not a member of this asset group")
 	}
 
-	err = s.memberRepository.RemoveAssetGroupMember(memberRequest.AssetGroupID, memberRequest.UserID)
+	err = s.memberRepository.RemoveAssetGroupMember(ctx, memberRequest.AssetGroupID, memberRequest.UserID)
 	if err != nil {
 		return logErrorWithNoReturn("RemoveAssetGroupMember", clientID, err, "Failed to remove asset group member")
 	}
 	return nil
 }
 
-func (s *assetGroupService) AddPermissionMemberAssetGroup(req *request.ChangeAssetGroupPermissionRequest, clientID string) error {
+func (s *assetGroupService) AddPermissionMemberAssetGroup(ctx context.Context, req *request.ChangeAssetGroupPermissionRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -687,7 +686,7 @@ func (s *assetGroupService) AddPermissionMemberAssetGroup(req *request.ChangeAss
 		CreatedBy:    &user.ClientID,
 	}
 
-	err = s.memberPermissionRepository.AddAssetGroupMemberPermission(groupMemberPermission)
+	err = s.memberPermissionRepository.AddAssetGroupMemberPermission(ctx, groupMemberPermission)
 	if err != nil {
 		return logErrorWithNoReturn("AddAssetGroupMemberPermission", clientID, err, "Failed to add asset group member permission")
 	}
@@ -695,7 +694,7 @@ func (s *assetGroupService) AddPermissionMemberAssetGroup(req *request.ChangeAss
 	return nil
 }
 
-func (s *assetGroupService) RemovePermissionMemberAssetGroup(req *request.ChangeAssetGroupPermissionRequest, clientID string) error {
+func (s *assetGroupService) RemovePermissionMemberAssetGroup(ctx context.Context, req *request.ChangeAssetGroupPermissionRequest, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -772,7 +771,7 @@ func (s *assetGroupService) RemovePermissionMemberAssetGroup(req *request.Change
 		return logErrorWithNoReturn("GetAssetGroupMemberPermissionByUserIDAndGroupID", clientID, nil, "User does not have this permission")
 	}
 
-	err = s.memberPermissionRepository.RemoveAssetGroupMemberPermission(req.UserID, req.AssetGroupID, req.PermissionID)
+	err = s.memberPermissionRepository.RemoveAssetGroupMemberPermission(ctx, req.UserID, req.AssetGroupID, req.PermissionID)
 	if err != nil {
 		return logErrorWithNoReturn("AddAssetGroupMemberPermission", clientID, err, "Failed to add asset group member permission")
 	}
@@ -780,7 +779,7 @@ func (s *assetGroupService) RemovePermissionMemberAssetGroup(req *request.Change
 	return nil
 }
 
-func (s *assetGroupService) GetListAssetGroupAsset(assetGroupID uint, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+func (s *assetGroupService) GetListAssetGroupAsset(assetGroupID uint, pageIndex, pageSize int, assetQuery request.AssetQueryRequest, clientID string) (interface{}, int64, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logListError("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -810,14 +809,20 @@ func (s *assetGroupService) GetListAssetGroupAsset(assetGroupID uint, pageIndex,
 	if member.AssetGroupID == 0 {
 		return logListError("GetAssetGroupMemberByUserIDAndGroupID", clientID, nil, "User is not a member of this asset group")
 	}
-	count, err := s.AssetRepository.GetCountListAssetsByAssetGroup(user.ClientID, assetGroupID)
+	count, err := s.AssetRepository.GetCountListAssetsByAssetGroup(user.ClientID, assetGroupID, assetQuery)
+	if err != nil {
+		return logListError("GetCountListAssetsByAssetGroup", clientID, err, "Failed to get count assets")
+	}
 
-	asset, err := s.AssetRepository.GetListAssetsByAssetGroup(user.ClientID, assetGroupID, pageIndex, pageSize)
+	asset, err := s.AssetRepository.GetListAssetsByAssetGroup(user.ClientID, assetGroupID, pageIndex, pageSize, assetQuery)
+	if err != nil {
+		return logListError("GetListAssetsByAssetGroup", clientID, err, "Failed to get list assets")
+	}
 
 	return asset, count, nil
 }
 
-func (s *assetGroupService) UpdateStockAssetGroupAsset(isAdded bool, req request.ChangeAssetStockRequest, clientID string) (interface{}, error) {
+func (s *assetGroupService) UpdateStockAssetGroupAsset(ctx context.Context, isAdded bool, req request.ChangeAssetStockRequest, clientID string) (interface{}, error) {
 	// Step 1: Fetch user data from Redis
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
@@ -884,7 +889,7 @@ func (s *assetGroupService) UpdateStockAssetGroupAsset(isAdded bool, req request
 	}
 
 	// Step 5: Update stock in a transaction
-	err = s.AssetStockRepository.UpdateAssetStockByAssetGroupID(newAssetStock, req.AssetGroupID, clientID)
+	err = s.AssetStockRepository.UpdateAssetStockByAssetGroupID(ctx, newAssetStock, req.AssetGroupID, clientID)
 	if err != nil {
 		return logError("UpdateAssetStock", clientID, err, "Failed to update asset stock")
 	}
@@ -895,13 +900,7 @@ func (s *assetGroupService) UpdateStockAssetGroupAsset(isAdded bool, req request
 		Str("Change Type", stockType).
 		Msg("Stock updated successfully")
 
-	// Step 6: Log stock change in audit log
-	err = s.AssetAuditLogRepository.AfterUpdateAssetStock(*oldAssetStock, newAssetStock)
-	if err != nil {
-		return logError("AfterUpdateAssetStock", clientID, err, "Failed to update asset stock log")
-	}
-
-	// Step 7: Return updated stock response
+	// Step 6: Return updated stock response
 	return response.AssetStockResponse{
 		StockID:         newAssetStock.StockID,
 		AssetID:         newAssetStock.AssetID,
@@ -7,37 +7,35 @@ import (
 	"asset-service/internal/utils"
 	nt "asset-service/internal/utils/nats"
 	"asset-service/internal/utils/redis"
-	"errors"
-	"fmt"
+	"context"
 	"github.com/rs/zerolog/log"
 	"path/filepath"
 )
 
 type AssetImageService interface {
-	AddAssetImage(assetRequest []response.AssetImageResponse, assetID uint, clientID string) error
+	AddAssetImage(ctx context.Context, assetRequest []response.AssetImageResponse, assetID uint, clientID string) error
 	GetAssetImageByAssetID(assetID uint) (*[]assets.AssetImage, error)
-	DeleteAssetImage(assetID uint, clientID string) error
-	Cleanup() error
+	DeleteAssetImage(ctx context.Context, assetID uint, clientID string) error
 	CleanupUnusedImages() error
 }
 
 type assetImageService struct {
 	AssetImageRepository repository.AssetImageRepository
-	AssetRepository      repository.AssetRepository
+	AttachmentRepository repository.AssetMaintenanceAttachmentRepository
 	Redis                redis.RedisService
 	NatsService          nt.Service
 }
 
-func NewAssetImageService(assetImageRepository repository.AssetImageRepository, assetRepository repository.AssetRepository, redis redis.RedisService, natsService nt.Service) AssetImageService {
+func NewAssetImageService(assetImageRepository repository.AssetImageRepository, attachmentRepository repository.AssetMaintenanceAttachmentRepository, redis redis.RedisService, natsService nt.Service) AssetImageService {
 	return &assetImageService{
 		AssetImageRepository: assetImageRepository,
-		AssetRepository:      assetRepository,
+		AttachmentRepository: attachmentRepository,
 		Redis:                redis,
 		NatsService:          natsService,
 	}
 }
 
-func (s assetImageService) AddAssetImage(assetRequest []response.AssetImageResponse, assetID uint, clientID string) error {
+func (s assetImageService) AddAssetImage(ctx context.Context, assetRequest []response.AssetImageResponse, assetID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().Str("clientID", clientID).Err(err).Msg("Failed to retrieve data from Redis")
@@ -55,7 +53,7 @@ func (s assetImageService) AddAssetImage(assetRequest []response.AssetImageRespo
 				UpdatedBy:    &data.ClientID,
 			})
 		}
-		if err := s.AssetImageRepository.AddAssetImage(assetImages); err != nil {
+		if err := s.AssetImageRepository.AddAssetImage(ctx, assetImages); err != nil {
 			log.Error().
 				Str("key", "AddAssetImage").
 				Str("clientID", clientID).
@@ -81,8 +79,8 @@ func (s assetImageService) GetAssetImageByAssetID(assetID uint) (*[]assets.Asset
 	return assetImage, nil
 }
 
-func (s assetImageService) DeleteAssetImage(assetID uint, clientID string) error {
-	err := s.AssetImageRepository.DeleteAssetImage(assetID, clientID)
+func (s assetImageService) DeleteAssetImage(ctx context.Context, assetID uint, clientID string) error {
+	err := s.AssetImageRepository.DeleteAssetImage(ctx, assetID, clientID)
 	if err != nil {
 		log.Error().
 			Str("key", "DeleteAssetImage").
@@ -95,60 +93,6 @@ func (s assetImageService) DeleteAssetImage(assetID uint, clientID string) error
 	return nil
 }
 
-// Cleanup removes images of deleted assets
-func (s assetImageService) Cleanup() error {
-	deleted, err := s.AssetRepository.GetAssetDeleted()
-	if err != nil {
-		log.Error().Str("key", "GetAssetDeleted").Err(err).Msg("Failed to get deleted assets")
-		return err
-	}
-
-	if len(deleted) == 0 {
-		log.Info().Msg("✅ No deleted assets found, cleanup not needed.")
-		return nil
-	}
-
-	for _, asset := range deleted {
-		// Fetch images associated with the asset
-		assetImages, err := s.AssetImageRepository.GetAssetImageByAssetID(asset.AssetID)
-		if err != nil {
-			log.Error().Str("key", "GetAssetImageByAssetID").Uint("asset_id", asset.AssetID).Err(err).Msg("Failed to get asset images")
-			return err
-		}
-
-		// Collect image filenames
-		var images []string
-		for _, img := range *assetImages {
-			images = append(images, filepath.Base(img.ImageURL))
-		}
-
-		// **VALIDATION: If no images, return an error**
-		if len(images) == 0 {
-			errMsg := fmt.Sprintf("❌ No images found for asset MaintenanceTypeID %d, skipping deletion", asset.AssetID)
-			log.Warn().Str("client_id", asset.UserClientID).Msg(errMsg)
-			return errors.New(errMsg)
-		}
-
-		// Delete asset image metadata from database
-		if err = s.DeleteAssetImage(asset.AssetID, asset.UserClientID); err != nil {
-			log.Error().Str("key", "DeleteAssetImage").Uint("asset_id", asset.AssetID).Err(err).Msg("Failed to delete asset images")
-			return err
-		}
-
-		// Log the number of images being deleted
-		log.Info().Str("client_id", asset.UserClientID).Msgf("🗑️ Images to be deleted: %d", len(images))
-
-		// Request physical file deletion via NATS
-		if err = s.NatsService.RequestImageDeletion(asset.UserClientID, images); err != nil {
-			log.Error().Str("key", "RequestImageDeletion").Err(err).Msg("❌ Failed to request image deletion via NATS")
-			return err
-		}
-	}
-
-	log.Info().Msg("✅ Cleanup process completed successfully.")
-	return nil
-}
-
 func (s assetImageService) CleanupUnusedImages() error {
 	images, err := s.AssetImageRepository.GetAssetImage()
 	if err != nil {
@@ -170,6 +114,15 @@ func (s assetImageService) CleanupUnusedImages() error {
 		for _, image := range *imagesAsset {
 			url = append(url, filepath.Base(image.ImageURL))
 		}
+		// Maintenance attachments live on the same CDN, they must not be reported as unused
+		attachments, err := s.AttachmentRepository.GetAttachmentsByClientID(clientID)
+		if err != nil {
+			log.Error().Str("key", "GetAttachmentsByClientID").Str("client_id", clientID).Err(err).Msg("Failed to get maintenance attachments")
+			return err
+		}
+		for _, attachment := range attachments {
+			url = append(url, filepath.Base(attachment.FileURL))
+		}
 		assetImages = append(assetImages, assets.ImageDeleteRequest{
 			ClientID: clientID,
 			Images:   url,
@@ -0,0 +1,319 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	repo "asset-service/internal/repository/assets"
+	repouser "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/sheet"
+	"asset-service/internal/utils/text"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxImportRows caps how many data rows a single import file may contain
+const maxImportRows = 1000
+
+type AssetImportService interface {
+	ImportAssets(ctx context.Context, reader io.Reader, format string, dryRun bool, clientID, credentialKey string) (*response.AssetImportResponse, error)
+}
+
+type assetImportService struct {
+	UserRepository             repouser.UserRepository
+	AssetRepository            repo.AssetRepository
+	AssetCategoryRepository    repo.AssetCategoryRepository
+	AssetStatusRepository      repo.AssetStatusRepository
+	AssetGroupMemberRepository repo.AssetGroupMemberRepository
+	Redis                      redis.RedisService
+}
+
+func NewAssetImportService(
+	userRepository repouser.UserRepository,
+	assetRepository repo.AssetRepository,
+	assetCategoryRepository repo.AssetCategoryRepository,
+	assetStatusRepository repo.AssetStatusRepository,
+	assetGroupMemberRepository repo.AssetGroupMemberRepository,
+	redis redis.RedisService) AssetImportService {
+	return assetImportService{
+		UserRepository:             userRepository,
+		AssetRepository:            assetRepository,
+		AssetCategoryRepository:    assetCategoryRepository,
+		AssetStatusRepository:      assetStatusRepository,
+		AssetGroupMemberRepository: assetGroupMemberRepository,
+		Redis:                      redis,
+	}
+}
+
+func (s assetImportService) ImportAssets(ctx context.Context, reader io.Reader, format string, dryRun bool, clientID, credentialKey string) (*response.AssetImportResponse, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	user, err := s.UserRepository.GetUserByClientID(data.ClientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserByClientID", clientID, err, "Failed to get user by client ID")
+	}
+
+	if !dryRun {
+		if err := text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID); err != nil {
+			log.Error().Str("clientID", clientID).Err(err).Msg("credential key check failed")
+			return nil, err
+		}
+	}
+
+	rows, err := sheet.ReadRows(reader, format)
+	if err != nil {
+		return nil, logErrorWithNoReturn("ReadRows", clientID, err, "Failed to read import file")
+	}
+
+	if len(rows) < 2 {
+		return nil, logErrorWithNoReturn("ImportAssets", clientID, errors.New("import file has no data rows"), "Import file is empty")
+	}
+
+	if len(rows)-1 > maxImportRows {
+		return nil, logErrorWithNoReturn("ImportAssets", clientID,
+			fmt.Errorf("import file has %d rows, the limit is %d", len(rows)-1, maxImportRows), "Import row limit exceeded")
+	}
+
+	header := sheet.Header(rows[0])
+	for _, column := range []string{"name", "category", "status"} {
+		if _, ok := header[column]; !ok {
+			return nil, logErrorWithNoReturn("ImportAssets", clientID, fmt.Errorf("missing required column: %s", column), "Invalid import header")
+		}
+	}
+
+	result := &response.AssetImportResponse{DryRun: dryRun, Errors: []response.AssetImportRowError{}}
+	importer := assetImporter{
+		service:       s,
+		clientID:      data.ClientID,
+		categories:    map[string]uint{},
+		statuses:      map[string]uint{},
+		names:         map[string]int{},
+		serialNumbers: map[string]int{},
+		barcodes:      map[string]int{},
+	}
+
+	var assetList []*assets.Asset
+	for i, row := range rows[1:] {
+		// row numbers are reported as they appear in the file, the header is row 1
+		rowNumber := i + 2
+		if isEmptyRow(row) {
+			continue
+		}
+		result.TotalRows++
+
+		asset, rowErrors := importer.parseRow(rowNumber, row, header)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		asset.CreatedBy = &data.ClientID
+		asset.UpdatedBy = &data.ClientID
+		assetList = append(assetList, asset)
+	}
+	result.ValidRows = len(assetList)
+
+	if dryRun || len(result.Errors) > 0 {
+		log.Info().Str("key", "ImportAssets").Str("clientID", clientID).
+			Int("total_rows", result.TotalRows).Int("errors", len(result.Errors)).Bool("dry_run", dryRun).
+			Msg("Import validated without writing")
+		return result, nil
+	}
+
+	var assetGroup *assets.AssetGroupAsset
+	assetGroupMember, _ := s.AssetGroupMemberRepository.GetAssetGroupMemberByUserID(user.UserID)
+	if assetGroupMember != nil && assetGroupMember.UserID != 0 {
+		assetGroup = &assets.AssetGroupAsset{
+			AssetGroupID: assetGroupMember.AssetGroupID,
+			UserID:       user.UserID,
+			CreatedBy:    &data.ClientID,
+		}
+	}
+
+	if err := s.AssetRepository.ImportAssets(ctx, assetList, assetGroup); err != nil {
+		return nil, logErrorWithNoReturn("ImportAssets", clientID, err, "Failed to import assets")
+	}
+	result.Imported = len(assetList)
+
+	log.Info().Str("key", "ImportAssets").Str("clientID", clientID).Int("imported", result.Imported).Msg("Success to import assets")
+	return result, nil
+}
+
+// assetImporter holds the lookups shared across the rows of one import file
+type assetImporter struct {
+	service       assetImportService
+	clientID      string
+	categories    map[string]uint
+	statuses      map[string]uint
+	names         map[string]int
+	serialNumbers map[string]int
+	barcodes      map[string]int
+}
+
+func (i *assetImporter) parseRow(rowNumber int, row []string, header map[string]int) (*assets.Asset, []response.AssetImportRowError) {
+	var rowErrors []response.AssetImportRowError
+	addError := func(field, message string) {
+		rowErrors = append(rowErrors, response.AssetImportRowError{Row: rowNumber, Field: field, Message: message})
+	}
+
+	asset := &assets.Asset{
+		UserClientID: i.clientID,
+		Name:         sheet.Value(row, header, "name"),
+		SerialNumber: optionalValue(row, header, "serial_number"),
+		Barcode:      optionalValue(row, header, "barcode"),
+		Description:  optionalValue(row, header, "description"),
+		Notes:        optionalValue(row, header, "notes"),
+		Stock:        1,
+	}
+
+	if asset.Name == "" {
+		addError("name", "name is required")
+	} else if len(asset.Name) > 100 {
+		addError("name", "name must be at most 100 characters")
+	} else if previous, ok := i.names[strings.ToLower(asset.Name)]; ok {
+		addError("name", fmt.Sprintf("duplicate name, already used on row %d", previous))
+	} else {
+		i.names[strings.ToLower(asset.Name)] = rowNumber
+		if exists, err := i.service.AssetRepository.AssetNameExists(asset.Name, i.clientID); err != nil {
+			addError("name", "failed to check asset name")
+		} else if exists {
+			addError("name", "asset name already exists")
+		}
+	}
+
+	if categoryID, err := i.categoryID(sheet.Value(row, header, "category")); err != nil {
+		addError("category", err.Error())
+	} else {
+		asset.CategoryID = categoryID
+	}
+
+	if statusID, err := i.statusID(sheet.Value(row, header, "status")); err != nil {
+		addError("status", err.Error())
+	} else {
+		asset.StatusID = statusID
+	}
+
+	for _, column := range []string{"purchase_date", "expiry_date", "warranty_expiry_date"} {
+		value := sheet.Value(row, header, column)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			addError(column, "date must use the YYYY-MM-DD format")
+			continue
+		}
+		switch column {
+		case "purchase_date":
+			asset.PurchaseDate = &date
+		case "expiry_date":
+			asset.ExpiryDate = &date
+		case "warranty_expiry_date":
+			asset.WarrantyExpiryDate = &date
+		}
+	}
+
+	if value := sheet.Value(row, header, "price"); value != "" {
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price < 0 {
+			addError("price", "price must be a non-negative number")
+		} else {
+			asset.Price = price
+		}
+	}
+
+	if value := sheet.Value(row, header, "stock"); value != "" {
+		stock, err := strconv.Atoi(value)
+		if err != nil || stock < 1 {
+			addError("stock", "stock must be a whole number of at least 1")
+		} else {
+			asset.Stock = stock
+		}
+	}
+
+	if asset.SerialNumber != nil {
+		if previous, ok := i.serialNumbers[*asset.SerialNumber]; ok {
+			addError("serial_number", fmt.Sprintf("duplicate serial number, already used on row %d", previous))
+		} else {
+			i.serialNumbers[*asset.SerialNumber] = rowNumber
+		}
+	}
+
+	if asset.Barcode != nil {
+		if previous, ok := i.barcodes[*asset.Barcode]; ok {
+			addError("barcode", fmt.Sprintf("duplicate barcode, already used on row %d", previous))
+		} else {
+			i.barcodes[*asset.Barcode] = rowNumber
+		}
+	}
+
+	if err := checkAssetIdentifiers(i.service.AssetRepository, i.clientID, asset.SerialNumber, asset.Barcode, 0); err != nil {
+		field := "barcode"
+		if strings.HasPrefix(err.Error(), "serial number") {
+			field = "serial_number"
+		}
+		addError(field, err.Error())
+	}
+
+	return asset, rowErrors
+}
+
+func (i *assetImporter) categoryID(name string) (uint, error) {
+	if name == "" {
+		return 0, errors.New("category is required")
+	}
+	if id, ok := i.categories[name]; ok {
+		return id, nil
+	}
+
+	category, err := i.service.AssetCategoryRepository.GetAssetCategoryByNameAndClientID(name, i.clientID)
+	if err != nil {
+		return 0, fmt.Errorf("category %q not found", name)
+	}
+	i.categories[name] = category.AssetCategoryID
+	return category.AssetCategoryID, nil
+}
+
+func (i *assetImporter) statusID(name string) (uint, error) {
+	if name == "" {
+		return 0, errors.New("status is required")
+	}
+	key := strings.ToLower(name)
+	if id, ok := i.statuses[key]; ok {
+		return id, nil
+	}
+
+	status, err := i.service.AssetStatusRepository.GetAssetStatusByStatusName(name)
+	if err != nil {
+		return 0, fmt.Errorf("status %q not found", name)
+	}
+	i.statuses[key] = status.AssetStatusID
+	return status.AssetStatusID, nil
+}
+
+func optionalValue(row []string, header map[string]int, column string) *string {
+	value := sheet.Value(row, header, column)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/label"
+	"asset-service/internal/utils/redis"
+	"fmt"
+	"strings"
+)
+
+// maxLabelSheetAssets caps how many labels a single PDF sheet request renders
+const maxLabelSheetAssets = 480
+
+type AssetLabelService interface {
+	GetAssetLabel(assetID uint, labelType, format string, size int, clientID string) ([]byte, string, error)
+	GetAssetLabelSheet(assetQuery request.AssetQueryRequest, clientID string) ([]byte, error)
+}
+
+type assetLabelService struct {
+	AssetRepository repository.AssetRepository
+	Redis           redis.RedisService
+	AssetUrl        string
+}
+
+func NewAssetLabelService(
+	assetRepository repository.AssetRepository,
+	redis redis.RedisService,
+	assetUrl string) AssetLabelService {
+	return &assetLabelService{
+		AssetRepository: assetRepository,
+		Redis:           redis,
+		AssetUrl:        strings.TrimRight(assetUrl, "/"),
+	}
+}
+
+func (s *assetLabelService) GetAssetLabel(assetID uint, labelType, format string, size int, clientID string) ([]byte, string, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, "", logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	asset, err := s.AssetRepository.GetAsset(assetID, data.ClientID)
+	if err != nil {
+		return nil, "", logErrorWithNoReturn("GetAsset", clientID, err, "asset not found")
+	}
+
+	content := s.assetURL(asset.AssetID)
+	if labelType == label.TypeBarcode {
+		if asset.Barcode == nil || *asset.Barcode == "" {
+			return nil, "", logErrorWithNoReturn("GetAssetLabel", clientID, nil, "asset has no barcode")
+		}
+		content = *asset.Barcode
+	}
+
+	image, contentType, err := label.Render(content, labelType, format, size)
+	if err != nil {
+		return nil, "", logErrorWithNoReturn("RenderLabel", clientID, err, "Failed to render asset label")
+	}
+
+	return image, contentType, nil
+}
+
+func (s *assetLabelService) GetAssetLabelSheet(assetQuery request.AssetQueryRequest, clientID string) ([]byte, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	total, err := s.AssetRepository.GetCountAsset(data.ClientID, assetQuery)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetCountAsset", clientID, err, "Failed to get count assets")
+	}
+
+	if total > maxLabelSheetAssets {
+		return nil, logErrorWithNoReturn("GetAssetLabelSheet", clientID,
+			fmt.Errorf("too many assets for one label sheet (%d), narrow the filter to %d or fewer", total, maxLabelSheetAssets),
+			"Label sheet limit exceeded")
+	}
+
+	assetList, err := s.AssetRepository.GetListAssets(data.ClientID, 1, maxLabelSheetAssets, assetQuery)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetListAssets", clientID, err, "Failed to get list assets")
+	}
+
+	labels := make([]label.Label, 0, len(assetList))
+	for _, asset := range assetList {
+		labels = append(labels, label.Label{
+			AssetID:      asset.AssetID,
+			Name:         asset.Name,
+			SerialNumber: asset.SerialNumber,
+			Barcode:      asset.Barcode,
+			URL:          s.assetURL(asset.AssetID),
+		})
+	}
+
+	sheet, err := label.Sheet(labels)
+	if err != nil {
+		return nil, logErrorWithNoReturn("LabelSheet", clientID, err, "Failed to render label sheet")
+	}
+
+	return sheet, nil
+}
+
+// assetURL is the stable link encoded in the QR code of an asset
+func (s *assetLabelService) assetURL(assetID uint) string {
+	return fmt.Sprintf("%s/%d", s.AssetUrl, assetID)
+}
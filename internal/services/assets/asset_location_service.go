@@ -0,0 +1,449 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/models/user"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/text"
+	"context"
+	"errors"
+	"strings"
+)
+
+type AssetLocationService interface {
+	AddLocation(ctx context.Context, req *request.AssetLocationRequest, clientID string, credentialKey string) (interface{}, error)
+	UpdateLocation(ctx context.Context, locationID uint, req *request.AssetLocationRequest, clientID string, credentialKey string) (interface{}, error)
+	GetLocationTree(assetGroupID uint, clientID string) (interface{}, error)
+	GetLocationByID(locationID uint, clientID string) (interface{}, error)
+	DeleteLocation(ctx context.Context, locationID uint, clientID string) error
+	MoveAsset(ctx context.Context, assetID uint, req *request.AssetMoveRequest, clientID string) (interface{}, error)
+	GetListLocationHistory(assetID, assetGroupID uint, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+}
+
+type assetLocationService struct {
+	UserRepository             users.UserRepository
+	AssetRepository            repository.AssetRepository
+	AssetGroupRepository       repository.AssetGroupRepository
+	MemberRepository           repository.AssetGroupMemberRepository
+	MemberPermissionRepository repository.AssetGroupMemberPermissionRepository
+	LocationRepository         repository.AssetLocationRepository
+	Redis                      redis.RedisService
+}
+
+func NewAssetLocationService(
+	userRepository users.UserRepository,
+	assetRepository repository.AssetRepository,
+	assetGroupRepository repository.AssetGroupRepository,
+	memberRepository repository.AssetGroupMemberRepository,
+	memberPermissionRepository repository.AssetGroupMemberPermissionRepository,
+	locationRepository repository.AssetLocationRepository,
+	redis redis.RedisService) AssetLocationService {
+	return assetLocationService{
+		UserRepository:             userRepository,
+		AssetRepository:            assetRepository,
+		AssetGroupRepository:       assetGroupRepository,
+		MemberRepository:           memberRepository,
+		MemberPermissionRepository: memberPermissionRepository,
+		LocationRepository:         locationRepository,
+		Redis:                      redis,
+	}
+}
+
+// AddLocation creates a location of the caller, or of an asset group when asset_group_id is set,
+// group locations can only be managed by the group owner and members with the Admin permission
+func (s assetLocationService) AddLocation(ctx context.Context, req *request.AssetLocationRequest, clientID string, credentialKey string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID); err != nil {
+		return logError("CheckCredentialKey", clientID, err, "credential key check failed")
+	}
+
+	location := &assets.AssetLocation{
+		Name:             strings.TrimSpace(req.Name),
+		LocationType:     strings.ToLower(strings.TrimSpace(req.LocationType)),
+		ParentLocationID: zeroToNil(req.ParentLocationID),
+		Description:      req.Description,
+		CreatedBy:        &data.ClientID,
+		UpdatedBy:        &data.ClientID,
+	}
+
+	var assetGroupID uint
+	if req.AssetGroupID != nil && *req.AssetGroupID != 0 {
+		assetGroupID = *req.AssetGroupID
+		if err = s.checkGroupAccess(assetGroupID, data, true); err != nil {
+			return nil, err
+		}
+		location.AssetGroupID = &assetGroupID
+	} else {
+		location.UserClientID = &data.ClientID
+	}
+
+	locations, err := s.LocationRepository.GetLocations(data.ClientID, assetGroupID)
+	if err != nil {
+		return logError("GetLocations", clientID, err, "Failed to get asset locations")
+	}
+	if err = validateLocation(location, locations); err != nil {
+		return logError("ValidateLocation", clientID, err, err.Error())
+	}
+
+	if err = s.LocationRepository.AddLocation(ctx, location); err != nil {
+		return logError("AddLocation", clientID, err, "Failed to add asset location")
+	}
+
+	return toAssetLocationResponse(*location, append(locations, *location), false), nil
+}
+
+func (s assetLocationService) UpdateLocation(ctx context.Context, locationID uint, req *request.AssetLocationRequest, clientID string, credentialKey string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID); err != nil {
+		return logError("CheckCredentialKey", clientID, err, "credential key check failed")
+	}
+
+	oldLocation, err := s.accessibleLocation(locationID, data, true)
+	if err != nil {
+		return nil, err
+	}
+
+	location := *oldLocation
+	location.Name = strings.TrimSpace(req.Name)
+	location.LocationType = strings.ToLower(strings.TrimSpace(req.LocationType))
+	location.ParentLocationID = zeroToNil(req.ParentLocationID)
+	location.Description = req.Description
+	location.UpdatedBy = &data.ClientID
+
+	locations, err := s.LocationRepository.GetLocations(data.ClientID, locationGroupID(location))
+	if err != nil {
+		return logError("GetLocations", clientID, err, "Failed to get asset locations")
+	}
+	if err = validateLocation(&location, locations); err != nil {
+		return logError("ValidateLocation", clientID, err, err.Error())
+	}
+
+	if err = s.LocationRepository.UpdateLocation(ctx, &location); err != nil {
+		return logError("UpdateLocation", clientID, err, "Failed to update asset location")
+	}
+
+	for i := range locations {
+		if locations[i].LocationID == location.LocationID {
+			locations[i] = location
+		}
+	}
+	return toAssetLocationResponse(location, locations, false), nil
+}
+
+// GetLocationTree returns the locations of the caller, or of the asset group, nested under their parents
+func (s assetLocationService) GetLocationTree(assetGroupID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if assetGroupID != 0 {
+		if err = s.checkGroupAccess(assetGroupID, data, false); err != nil {
+			return nil, err
+		}
+	}
+
+	locations, err := s.LocationRepository.GetLocations(data.ClientID, assetGroupID)
+	if err != nil {
+		return logError("GetLocations", clientID, err, "Failed to get asset locations")
+	}
+
+	tree := make([]response.AssetLocationResponse, 0)
+	for _, location := range locations {
+		if location.ParentLocationID == nil {
+			tree = append(tree, toAssetLocationResponse(location, locations, true))
+		}
+	}
+	return tree, nil
+}
+
+func (s assetLocationService) GetLocationByID(locationID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	location, err := s.accessibleLocation(locationID, data, false)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.LocationRepository.GetLocations(data.ClientID, locationGroupID(*location))
+	if err != nil {
+		return logError("GetLocations", clientID, err, "Failed to get asset locations")
+	}
+	return toAssetLocationResponse(*location, locations, true), nil
+}
+
+func (s assetLocationService) DeleteLocation(ctx context.Context, locationID uint, clientID string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	location, err := s.accessibleLocation(locationID, data, true)
+	if err != nil {
+		return err
+	}
+
+	location.DeletedBy = &data.ClientID
+	if err = s.LocationRepository.DeleteLocation(ctx, location); err != nil {
+		return logErrorWithNoReturn("DeleteLocation", clientID, err, "Failed to delete asset location")
+	}
+	return nil
+}
+
+// MoveAsset places an asset at a location of its owner or, for a shared asset, at a location of
+// the group it is shared with. Group locations take the asset owner being a member or a group manager.
+func (s assetLocationService) MoveAsset(ctx context.Context, assetID uint, req *request.AssetMoveRequest, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	toLocationID := zeroToNil(req.LocationID)
+	if toLocationID == nil {
+		if err = s.checkAssetOwnerOrCurrentGroup(assetID, data); err != nil {
+			return nil, err
+		}
+	} else {
+		location, err := s.LocationRepository.GetLocationByID(*toLocationID)
+		if err != nil {
+			return logError("GetLocationByID", clientID, errors.New("location not found"), "Failed to get asset location")
+		}
+
+		if location.AssetGroupID == nil {
+			if *location.UserClientID != data.ClientID {
+				return logError("MoveAsset", clientID, errors.New("location not found"), "Location belongs to another user")
+			}
+			if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+				return logError("GetAsset", clientID, errors.New("asset not found"), "Failed to get asset")
+			}
+		} else {
+			asset, err := s.AssetRepository.GetAssetByAssetGroupID(assetID, *location.AssetGroupID)
+			if err != nil {
+				return logError("GetAssetByAssetGroupID", clientID, errors.New("asset is not shared with the asset group of this location"), "Failed to get asset of asset group")
+			}
+			if err = s.checkGroupAccess(*location.AssetGroupID, data, asset.UserClientID != data.ClientID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	history, err := s.LocationRepository.MoveAsset(ctx, assetID, toLocationID, req.Notes, data.ClientID)
+	if err != nil {
+		return logError("MoveAsset", clientID, err, "Failed to move asset")
+	}
+
+	return response.AssetLocationHistoryResponse{
+		HistoryID:      history.HistoryID,
+		AssetID:        history.AssetID,
+		FromLocationID: history.FromLocationID,
+		ToLocationID:   history.ToLocationID,
+		MovedAt:        history.MovedAt,
+		MovedBy:        history.CreatedBy,
+		Notes:          history.Notes,
+	}, nil
+}
+
+// GetListLocationHistory lists the movements of an asset of the caller or, with assetGroupID, of an asset shared with that group
+func (s assetLocationService) GetListLocationHistory(assetID, assetGroupID uint, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if assetGroupID != 0 {
+		if err = s.checkGroupAccess(assetGroupID, data, false); err != nil {
+			return nil, 0, err
+		}
+		if _, err = s.AssetRepository.GetAssetByAssetGroupID(assetID, assetGroupID); err != nil {
+			return logListError("GetAssetByAssetGroupID", clientID, errors.New("asset not found in asset group"), "Failed to get asset of asset group")
+		}
+	} else if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logListError("GetAsset", clientID, errors.New("asset not found"), "Failed to get asset")
+	}
+
+	count, err := s.LocationRepository.GetCountLocationHistory(assetID)
+	if err != nil {
+		return logListError("GetCountLocationHistory", clientID, err, "Failed to get count asset location history")
+	}
+
+	history, err := s.LocationRepository.GetListLocationHistory(assetID, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListLocationHistory", clientID, err, "Failed to get asset location history")
+	}
+	return history, count, nil
+}
+
+// accessibleLocation loads a location the caller may read, or manage when manage is set
+func (s assetLocationService) accessibleLocation(locationID uint, data *user.UserRedis, manage bool) (*assets.AssetLocation, error) {
+	location, err := s.LocationRepository.GetLocationByID(locationID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetLocationByID", data.ClientID, errors.New("location not found"), "Failed to get asset location")
+	}
+
+	if location.AssetGroupID != nil {
+		if err = s.checkGroupAccess(*location.AssetGroupID, data, manage); err != nil {
+			return nil, err
+		}
+		return location, nil
+	}
+
+	if *location.UserClientID != data.ClientID {
+		return nil, logErrorWithNoReturn("GetLocationByID", data.ClientID, errors.New("location not found"), "Location belongs to another user")
+	}
+	return location, nil
+}
+
+// checkGroupAccess requires the caller to be a member of the group, or its owner or an Admin member when manage is set
+func (s assetLocationService) checkGroupAccess(assetGroupID uint, data *user.UserRedis, manage bool) error {
+	userID, owner, err := checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, assetGroupID, data.ClientID)
+	if err != nil {
+		return err
+	}
+	if owner || !manage {
+		return nil
+	}
+
+	permissions, err := s.MemberPermissionRepository.GetPermissionsByUserIDAndGroupID(userID, assetGroupID)
+	if err != nil {
+		return logErrorWithNoReturn("GetPermissionsByUserIDAndGroupID", data.ClientID, err, "Failed to get member permissions")
+	}
+	for _, permission := range permissions {
+		if permission.PermissionName == utils.AssetGroupPermissionAdmin {
+			return nil
+		}
+	}
+	return logErrorWithNoReturn("CheckGroupAccess", data.ClientID, errors.New("user does not have permission to manage locations of this asset group"), "User cannot manage asset group locations")
+}
+
+// checkAssetOwnerOrCurrentGroup allows taking an asset out of its location to the asset owner and
+// to the managers of the group owning the current location
+func (s assetLocationService) checkAssetOwnerOrCurrentGroup(assetID uint, data *user.UserRedis) error {
+	if _, err := s.AssetRepository.GetAsset(assetID, data.ClientID); err == nil {
+		return nil
+	}
+
+	location, err := s.LocationRepository.GetAssetLocation(assetID)
+	if err != nil || location == nil || location.AssetGroupID == nil {
+		return logErrorWithNoReturn("GetAsset", data.ClientID, errors.New("asset not found"), "Failed to get asset")
+	}
+	return s.checkGroupAccess(*location.AssetGroupID, data, true)
+}
+
+// validateLocation checks the type and the parent of a location against the other locations of the same owner:
+// the parent must exist, must not be the location itself or one of its descendants, and must be of an outer level
+func validateLocation(location *assets.AssetLocation, locations []assets.AssetLocation) error {
+	if location.Name == "" {
+		return errors.New("name is required")
+	}
+
+	level, ok := utils.LocationTypeLevels[location.LocationType]
+	if !ok {
+		return errors.New("location_type must be site, building, room or shelf")
+	}
+
+	byID := make(map[uint]assets.AssetLocation, len(locations))
+	for _, l := range locations {
+		byID[l.LocationID] = l
+	}
+
+	if location.ParentLocationID != nil {
+		parent, ok := byID[*location.ParentLocationID]
+		if !ok {
+			return errors.New("parent location not found")
+		}
+		for ancestor := &parent; ancestor != nil; {
+			if ancestor.LocationID == location.LocationID {
+				return errors.New("a location cannot be moved below itself")
+			}
+			if ancestor.ParentLocationID == nil {
+				break
+			}
+			next, ok := byID[*ancestor.ParentLocationID]
+			if !ok {
+				break
+			}
+			ancestor = &next
+		}
+		if utils.LocationTypeLevels[parent.LocationType] >= level {
+			return errors.New("a " + location.LocationType + " cannot be placed in a " + parent.LocationType)
+		}
+	}
+
+	if location.LocationID != 0 {
+		for _, child := range locations {
+			if child.ParentLocationID != nil && *child.ParentLocationID == location.LocationID &&
+				utils.LocationTypeLevels[child.LocationType] <= level {
+				return errors.New("location contains a " + child.LocationType + " which cannot be placed in a " + location.LocationType)
+			}
+		}
+	}
+	return nil
+}
+
+// toAssetLocationResponse builds the response with the full path of the location and, when
+// withChildren is set, the nested locations below it
+func toAssetLocationResponse(location assets.AssetLocation, locations []assets.AssetLocation, withChildren bool) response.AssetLocationResponse {
+	byID := make(map[uint]assets.AssetLocation, len(locations))
+	for _, l := range locations {
+		byID[l.LocationID] = l
+	}
+
+	path := []string{location.Name}
+	for parentID := location.ParentLocationID; parentID != nil; {
+		parent, ok := byID[*parentID]
+		if !ok || len(path) > len(locations) {
+			break
+		}
+		path = append([]string{parent.Name}, path...)
+		parentID = parent.ParentLocationID
+	}
+
+	locationResponse := response.AssetLocationResponse{
+		LocationID:       location.LocationID,
+		AssetGroupID:     location.AssetGroupID,
+		ParentLocationID: location.ParentLocationID,
+		Name:             location.Name,
+		LocationType:     location.LocationType,
+		Description:      location.Description,
+		Path:             strings.Join(path, " / "),
+	}
+
+	if withChildren {
+		for _, child := range locations {
+			if child.ParentLocationID != nil && *child.ParentLocationID == location.LocationID {
+				locationResponse.Children = append(locationResponse.Children, toAssetLocationResponse(child, locations, true))
+			}
+		}
+	}
+	return locationResponse
+}
+
+func locationGroupID(location assets.AssetLocation) uint {
+	if location.AssetGroupID != nil {
+		return *location.AssetGroupID
+	}
+	return 0
+}
+
+func zeroToNil(id *uint) *uint {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,193 @@
+package assets
+
+import (
+	response "asset-service/internal/dto/out/assets"
+	model "asset-service/internal/models/assets"
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	nt "asset-service/internal/utils/nats"
+	"asset-service/internal/utils/redis"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"path/filepath"
+)
+
+type AssetMaintenanceAttachmentService interface {
+	AddMaintenanceAttachments(ctx context.Context, maintenanceID uint, files []response.AssetMaintenanceAttachmentResponse, clientID string) (interface{}, error)
+	AddRecordAttachments(ctx context.Context, maintenanceRecordID uint, files []response.AssetMaintenanceAttachmentResponse, clientID string) (interface{}, error)
+	GetMaintenanceAttachments(maintenanceID uint, clientID string) (interface{}, error)
+	DeleteAttachment(ctx context.Context, attachmentID uint, clientID string) error
+	Cleanup(ctx context.Context) error
+}
+
+type assetMaintenanceAttachmentService struct {
+	AttachmentRepository        repository.AssetMaintenanceAttachmentRepository
+	MaintenanceRepository       repository.AssetMaintenanceRepository
+	MaintenanceRecordRepository repository.AssetMaintenanceRecordRepository
+	Redis                       redis.RedisService
+	NatsService                 nt.Service
+}
+
+func NewAssetMaintenanceAttachmentService(
+	attachmentRepository repository.AssetMaintenanceAttachmentRepository,
+	maintenanceRepository repository.AssetMaintenanceRepository,
+	maintenanceRecordRepository repository.AssetMaintenanceRecordRepository,
+	redis redis.RedisService,
+	natsService nt.Service) AssetMaintenanceAttachmentService {
+	return assetMaintenanceAttachmentService{
+		AttachmentRepository:        attachmentRepository,
+		MaintenanceRepository:       maintenanceRepository,
+		MaintenanceRecordRepository: maintenanceRecordRepository,
+		Redis:                       redis,
+		NatsService:                 natsService,
+	}
+}
+
+// AddMaintenanceAttachments stores the files uploaded to the CDN against a maintenance schedule of the caller
+func (s assetMaintenanceAttachmentService) AddMaintenanceAttachments(ctx context.Context, maintenanceID uint, files []response.AssetMaintenanceAttachmentResponse, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	maintenance, err := s.MaintenanceRepository.GetMaintenanceResponseByID(maintenanceID, data.ClientID)
+	if err != nil {
+		return logError("GetMaintenanceResponseByID", clientID, errors.New("maintenance not found"), "Failed to get maintenance")
+	}
+
+	return s.addAttachments(ctx, files, data.ClientID, uint(maintenance.AssetID), maintenanceID, nil)
+}
+
+// AddRecordAttachments stores the files uploaded to the CDN against a performed maintenance of the caller
+func (s assetMaintenanceAttachmentService) AddRecordAttachments(ctx context.Context, maintenanceRecordID uint, files []response.AssetMaintenanceAttachmentResponse, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	record, err := s.MaintenanceRecordRepository.GetMaintenanceRecordByID(maintenanceRecordID, data.ClientID)
+	if err != nil {
+		return logError("GetMaintenanceRecordByID", clientID, errors.New("maintenance record not found"), "Failed to get maintenance record")
+	}
+
+	return s.addAttachments(ctx, files, data.ClientID, uint(record.AssetID), uint(record.MaintenanceID), &record.MaintenanceRecordID)
+}
+
+func (s assetMaintenanceAttachmentService) GetMaintenanceAttachments(maintenanceID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	attachments, err := s.AttachmentRepository.GetAttachmentsByMaintenanceID(maintenanceID, data.ClientID)
+	if err != nil {
+		return logError("GetAttachmentsByMaintenanceID", clientID, err, "Failed to get maintenance attachments")
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment removes the attachment and asks the CDN to delete its file, should the request fail the
+// cleanup job retries it
+func (s assetMaintenanceAttachmentService) DeleteAttachment(ctx context.Context, attachmentID uint, clientID string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	attachment, err := s.AttachmentRepository.GetAttachment(attachmentID, data.ClientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetAttachment", clientID, errors.New("attachment not found"), "Failed to get attachment")
+	}
+
+	if err = s.AttachmentRepository.DeleteAttachment(ctx, attachment.AttachmentID, data.ClientID); err != nil {
+		return logErrorWithNoReturn("DeleteAttachment", clientID, err, "Failed to delete attachment")
+	}
+
+	if err = s.purge(ctx, data.ClientID, []model.AssetMaintenanceAttachment{*attachment}); err != nil {
+		log.Warn().Str("clientID", clientID).Uint("attachmentID", attachmentID).Err(err).Msg("Attachment file left for the cleanup job")
+	}
+	return nil
+}
+
+// Cleanup requests the deletion of the files of deleted attachments via NATS, then drops their rows
+func (s assetMaintenanceAttachmentService) Cleanup(ctx context.Context) error {
+	deleted, err := s.AttachmentRepository.GetDeletedAttachments()
+	if err != nil {
+		log.Error().Str("key", "GetDeletedAttachments").Err(err).Msg("Failed to get deleted maintenance attachments")
+		return err
+	}
+
+	if len(deleted) == 0 {
+		log.Info().Msg("✅ No deleted maintenance attachments found, cleanup not needed.")
+		return nil
+	}
+
+	byClient := make(map[string][]model.AssetMaintenanceAttachment)
+	for _, attachment := range deleted {
+		byClient[attachment.UserClientID] = append(byClient[attachment.UserClientID], attachment)
+	}
+
+	for clientID, attachments := range byClient {
+		if err = s.purge(ctx, clientID, attachments); err != nil {
+			log.Error().Str("key", "RequestImageDeletion").Str("client_id", clientID).Err(err).Msg("❌ Failed to purge maintenance attachments")
+			return err
+		}
+	}
+
+	log.Info().Int("count", len(deleted)).Msg("✅ Maintenance attachment cleanup completed successfully.")
+	return nil
+}
+
+func (s assetMaintenanceAttachmentService) addAttachments(ctx context.Context, files []response.AssetMaintenanceAttachmentResponse, clientID string, assetID, maintenanceID uint, maintenanceRecordID *uint) (interface{}, error) {
+	if len(files) == 0 {
+		return logError("AddAttachments", clientID, errors.New("at least one file is required"), "No files uploaded")
+	}
+
+	attachments := make([]model.AssetMaintenanceAttachment, 0, len(files))
+	for _, file := range files {
+		attachments = append(attachments, model.AssetMaintenanceAttachment{
+			UserClientID:        clientID,
+			AssetID:             assetID,
+			MaintenanceID:       maintenanceID,
+			MaintenanceRecordID: maintenanceRecordID,
+			FileURL:             file.FileURL,
+			FileName:            file.FileName,
+			CreatedBy:           &clientID,
+			UpdatedBy:           &clientID,
+		})
+	}
+
+	if err := s.AttachmentRepository.AddAttachments(ctx, attachments); err != nil {
+		return logError("AddAttachments", clientID, err, "Failed to add attachments")
+	}
+
+	result := make([]response.AssetMaintenanceAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, response.AssetMaintenanceAttachmentResponse{
+			AttachmentID:        attachment.AttachmentID,
+			MaintenanceID:       attachment.MaintenanceID,
+			MaintenanceRecordID: attachment.MaintenanceRecordID,
+			FileURL:             attachment.FileURL,
+			FileName:            attachment.FileName,
+			CreatedAt:           attachment.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// purge hands the files over to the CDN for deletion and drops the rows once the request went through
+func (s assetMaintenanceAttachmentService) purge(ctx context.Context, clientID string, attachments []model.AssetMaintenanceAttachment) error {
+	files := make([]string, 0, len(attachments))
+	ids := make([]uint, 0, len(attachments))
+	for _, attachment := range attachments {
+		files = append(files, filepath.Base(attachment.FileURL))
+		ids = append(ids, attachment.AttachmentID)
+	}
+
+	log.Info().Str("client_id", clientID).Msgf("🗑️ Attachments to be deleted: %d", len(files))
+	if err := s.NatsService.RequestImageDeletion(clientID, files); err != nil {
+		return err
+	}
+	return s.AttachmentRepository.PurgeAttachments(ctx, ids)
+}
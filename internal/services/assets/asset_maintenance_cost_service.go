@@ -0,0 +1,165 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"fmt"
+	"time"
+)
+
+type AssetMaintenanceCostService interface {
+	GetCostSummary(costQuery request.MaintenanceCostQueryRequest, clientID string) (*response.MaintenanceCostReportResponse, error)
+	GetCostSeries(costQuery request.MaintenanceCostQueryRequest, clientID string) (*response.MaintenanceCostReportResponse, error)
+}
+
+type assetMaintenanceCostService struct {
+	UserRepository            users.UserRepository
+	AssetGroupRepository      repository.AssetGroupRepository
+	MemberRepository          repository.AssetGroupMemberRepository
+	MaintenanceCostRepository repository.AssetMaintenanceCostRepository
+	Redis                     redis.RedisService
+}
+
+func NewAssetMaintenanceCostService(
+	userRepository users.UserRepository,
+	assetGroupRepository repository.AssetGroupRepository,
+	memberRepository repository.AssetGroupMemberRepository,
+	maintenanceCostRepository repository.AssetMaintenanceCostRepository,
+	redis redis.RedisService) AssetMaintenanceCostService {
+	return assetMaintenanceCostService{
+		UserRepository:            userRepository,
+		AssetGroupRepository:      assetGroupRepository,
+		MemberRepository:          memberRepository,
+		MaintenanceCostRepository: maintenanceCostRepository,
+		Redis:                     redis,
+	}
+}
+
+// GetCostSummary returns the maintenance cost totals of the range, broken down by costQuery.GroupBy
+func (s assetMaintenanceCostService) GetCostSummary(costQuery request.MaintenanceCostQueryRequest, clientID string) (*response.MaintenanceCostReportResponse, error) {
+	costQuery.Period = ""
+	return s.report(costQuery, clientID)
+}
+
+// GetCostSeries returns the maintenance costs per month or quarter, an ungrouped series lists the periods
+// without maintenance with a zero cost
+func (s assetMaintenanceCostService) GetCostSeries(costQuery request.MaintenanceCostQueryRequest, clientID string) (*response.MaintenanceCostReportResponse, error) {
+	if costQuery.Period == "" {
+		costQuery.Period = request.MaintenanceCostPeriodMonth
+	}
+
+	report, err := s.report(costQuery, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if costQuery.GroupBy == "" {
+		report.Rows = fillCostPeriods(report.Rows, costQuery)
+	}
+	return report, nil
+}
+
+func (s assetMaintenanceCostService) report(costQuery request.MaintenanceCostQueryRequest, clientID string) (*response.MaintenanceCostReportResponse, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if costQuery.AssetGroupID != nil {
+		if _, _, err = checkGroupMember(s.UserRepository, s.AssetGroupRepository, s.MemberRepository, *costQuery.AssetGroupID, data.ClientID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.MaintenanceCostRepository.GetMaintenanceCosts(data.ClientID, costQuery)
+	if err != nil {
+		return nil, logErrorWithNoReturn("GetMaintenanceCosts", clientID, err, "Failed to get maintenance costs")
+	}
+
+	report := &response.MaintenanceCostReportResponse{
+		GroupBy: costQuery.GroupBy,
+		Period:  costQuery.Period,
+		Rows:    rows,
+	}
+	if costQuery.DateFrom != nil {
+		dateFrom := response.DateOnly(*costQuery.DateFrom)
+		report.DateFrom = &dateFrom
+	}
+	if costQuery.DateTo != nil {
+		dateTo := response.DateOnly(*costQuery.DateTo)
+		report.DateTo = &dateTo
+	}
+	if report.Rows == nil {
+		report.Rows = []response.MaintenanceCostRowResponse{}
+	}
+	for _, row := range rows {
+		report.RecordCount += row.RecordCount
+		report.TotalCost += row.TotalCost
+	}
+	return report, nil
+}
+
+// fillCostPeriods adds a zero row for every period between the range bounds, or between the first and last
+// period with costs when a bound is missing
+func fillCostPeriods(rows []response.MaintenanceCostRowResponse, costQuery request.MaintenanceCostQueryRequest) []response.MaintenanceCostRowResponse {
+	byPeriod := make(map[string]response.MaintenanceCostRowResponse, len(rows))
+	for _, row := range rows {
+		byPeriod[row.Period] = row
+	}
+
+	var start, end time.Time
+	if costQuery.DateFrom != nil {
+		start = *costQuery.DateFrom
+	} else if len(rows) > 0 {
+		start = parseCostPeriod(rows[0].Period)
+	}
+	if costQuery.DateTo != nil {
+		end = *costQuery.DateTo
+	} else if len(rows) > 0 {
+		end = parseCostPeriod(rows[len(rows)-1].Period)
+	}
+	if start.IsZero() || end.IsZero() {
+		return rows
+	}
+
+	months := 1
+	if costQuery.Period == request.MaintenanceCostPeriodQuarter {
+		months = 3
+	}
+	start = time.Date(start.Year(), start.Month()-(start.Month()-1)%time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+
+	filled := make([]response.MaintenanceCostRowResponse, 0, len(rows))
+	for current := start; !current.After(end); current = current.AddDate(0, months, 0) {
+		label := costPeriodLabel(current, costQuery.Period)
+		if row, ok := byPeriod[label]; ok {
+			filled = append(filled, row)
+			continue
+		}
+		filled = append(filled, response.MaintenanceCostRowResponse{Period: label})
+	}
+	return filled
+}
+
+// costPeriodLabel formats a date the way the cost query labels its period, 2006-01 or 2006-Q1
+func costPeriodLabel(date time.Time, period string) string {
+	if period == request.MaintenanceCostPeriodQuarter {
+		return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())+2)/3)
+	}
+	return date.Format("2006-01")
+}
+
+// parseCostPeriod returns the first day of a period label, the zero time for an unknown label
+func parseCostPeriod(label string) time.Time {
+	var year, quarter int
+	if _, err := fmt.Sscanf(label, "%d-Q%d", &year, &quarter); err == nil {
+		return time.Date(year, time.Month(quarter*3-2), 1, 0, 0, 0, 0, time.UTC)
+	}
+	date, err := time.Parse("2006-01", label)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
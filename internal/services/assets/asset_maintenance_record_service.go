@@ -18,7 +18,6 @@ type assetMaintenanceRecordService struct {
 	AssetMaintenanceRepository       repository.AssetMaintenanceRepository
 	AssetRepository                  repository.AssetRepository
 	AssetMaintenanceRecordRepository repository.AssetMaintenanceRecordRepository
-	AssetAuditLogRepository          repository.AssetAuditLogRepository
 	Redis                            redis.RedisService
 }
 
@@ -26,13 +25,11 @@ func NewAssetMaintenanceRecordService(
 	AssetMaintenance repository.AssetMaintenanceRepository,
 	assetRepository repository.AssetRepository,
 	AssetMaintenanceRecordRecord repository.AssetMaintenanceRecordRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
 	RedisService redis.RedisService) AssetMaintenanceRecordService {
 	return assetMaintenanceRecordService{
 		AssetMaintenanceRepository:       AssetMaintenance,
 		AssetRepository:                  assetRepository,
 		AssetMaintenanceRecordRepository: AssetMaintenanceRecordRecord,
-		AssetAuditLogRepository:          AssetAuditLogRepository,
 		Redis:                            RedisService}
 }
 
@@ -6,24 +6,24 @@ import (
 	"asset-service/internal/models/assets"
 	repository "asset-service/internal/repository/assets"
 	"asset-service/internal/utils"
+	nt "asset-service/internal/utils/nats"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 	"time"
 )
 
 type AssetMaintenanceService interface {
-	AddAssetMaintenance(maintenance request.AssetMaintenanceRequest, clientID string, credentialKey string) (*assets.AssetMaintenance, error)
+	AddAssetMaintenance(ctx context.Context, maintenance request.AssetMaintenanceRequest, clientID string, credentialKey string) (*assets.AssetMaintenance, error)
 	GetMaintenanceByID(maintenanceID uint, clientID string) (interface{}, error)
-	UpdateMaintenance(clientID string, maintenance *request.AssetMaintenanceRequest) error
-	DeleteMaintenance(maintenanceID uint, clientID string) error
+	UpdateMaintenance(ctx context.Context, clientID string, maintenance *request.AssetMaintenanceRequest) error
+	DeleteMaintenance(ctx context.Context, maintenanceID uint, clientID string) error
 	GetMaintenancesByAssetID(assetID uint, clientID string) (interface{}, error)
-	PerformMaintenance(assetPerform request.AssetMaintenancePerformRequest, clientID string) (interface{}, error)
+	PerformMaintenance(ctx context.Context, assetPerform request.AssetMaintenancePerformRequest, clientID string) (interface{}, error)
 	PerformMaintenanceCheck() error
 }
 
@@ -31,25 +31,31 @@ type assetMaintenanceService struct {
 	AssetMaintenanceRepository repository.AssetMaintenanceRepository
 	AssetRepository            repository.AssetRepository
 	AssetMaintenanceRecord     repository.AssetMaintenanceRecordRepository
-	AssetAuditLogRepository    repository.AssetAuditLogRepository
+	NotificationRepository     repository.AssetMaintenanceNotificationRepository
 	Redis                      redis.RedisService
+	NatsService                nt.Service
+	MaintenanceDueWithinDays   int
 }
 
 func NewAssetMaintenanceService(
 	AssetMaintenance repository.AssetMaintenanceRepository,
 	assetRepository repository.AssetRepository,
 	AssetMaintenanceRecord repository.AssetMaintenanceRecordRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
-	RedisService redis.RedisService) AssetMaintenanceService {
+	notificationRepository repository.AssetMaintenanceNotificationRepository,
+	RedisService redis.RedisService,
+	natsService nt.Service,
+	maintenanceDueWithinDays int) AssetMaintenanceService {
 	return assetMaintenanceService{
 		AssetMaintenanceRepository: AssetMaintenance,
 		AssetRepository:            assetRepository,
 		AssetMaintenanceRecord:     AssetMaintenanceRecord,
-		AssetAuditLogRepository:    AssetAuditLogRepository,
-		Redis:                      RedisService}
+		NotificationRepository:     notificationRepository,
+		Redis:                      RedisService,
+		NatsService:                natsService,
+		MaintenanceDueWithinDays:   maintenanceDueWithinDays}
 }
 
-func (s assetMaintenanceService) AddAssetMaintenance(maintenance request.AssetMaintenanceRequest, clientID string, credentialKey string) (*assets.AssetMaintenance, error) {
+func (s assetMaintenanceService) AddAssetMaintenance(ctx context.Context, maintenance request.AssetMaintenanceRequest, clientID string, credentialKey string) (*assets.AssetMaintenance, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -126,7 +132,7 @@ func (s assetMaintenanceService) AddAssetMaintenance(maintenance request.AssetMa
 		maintenances.MaintenanceDetails = nil
 	}
 
-	if err = s.AssetMaintenanceRepository.AddAssetMaintenance(&maintenances); err != nil {
+	if err = s.AssetMaintenanceRepository.AddAssetMaintenance(ctx, &maintenances); err != nil {
 		log.Error().
 			Str("key", "AddAssetMaintenance").
 			Str("clientID", clientID).
@@ -135,15 +141,6 @@ func (s assetMaintenanceService) AddAssetMaintenance(maintenance request.AssetMa
 		return nil, err
 	}
 
-	if auditErr := s.AssetAuditLogRepository.AfterCreateAssetMaintenance(&maintenances); auditErr != nil {
-		log.Error().
-			Str("key", "AfterCreateAssetMaintenance").
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Failed to log audit after create asset maintenance")
-		return nil, err
-	}
-
 	return &maintenances, nil
 }
 
@@ -170,7 +167,7 @@ func (s assetMaintenanceService) GetMaintenanceByID(maintenanceID uint, clientID
 	return maintenance, nil
 }
 
-func (s assetMaintenanceService) UpdateMaintenance(clientID string, maintenance *request.AssetMaintenanceRequest) error {
+func (s assetMaintenanceService) UpdateMaintenance(ctx context.Context, clientID string, maintenance *request.AssetMaintenanceRequest) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -240,7 +237,7 @@ func (s assetMaintenanceService) UpdateMaintenance(clientID string, maintenance
 		maintenances.MaintenanceDetails = nil
 	}
 
-	if err = s.AssetMaintenanceRepository.Update(&maintenances); err != nil {
+	if err = s.AssetMaintenanceRepository.Update(ctx, &maintenances); err != nil {
 		log.Error().
 			Str("key", "Update").
 			Str("clientID", clientID).
@@ -248,19 +245,10 @@ func (s assetMaintenanceService) UpdateMaintenance(clientID string, maintenance
 			Msg("Failed to update maintenance")
 	}
 
-	if auditErr := s.AssetAuditLogRepository.AfterUpdateAssetMaintenance(maintenanceExist, &maintenances); auditErr != nil {
-		log.Error().
-			Str("key", "AfterUpdateAssetMaintenance").
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Failed to log audit after update asset maintenance")
-		return auditErr
-	}
-
 	return nil
 }
 
-func (s assetMaintenanceService) PerformMaintenance(assetPerform request.AssetMaintenancePerformRequest, clientID string) (interface{}, error) {
+func (s assetMaintenanceService) PerformMaintenance(ctx context.Context, assetPerform request.AssetMaintenancePerformRequest, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -326,7 +314,7 @@ func (s assetMaintenanceService) PerformMaintenance(assetPerform request.AssetMa
 		maintenances.MaintenanceDetails = nil
 	}
 
-	if err = s.AssetMaintenanceRepository.Update(&maintenances); err != nil {
+	if err = s.AssetMaintenanceRepository.Update(ctx, &maintenances); err != nil {
 		log.Error().
 			Str("key", "Update").
 			Str("clientID", clientID).
@@ -349,7 +337,7 @@ func (s assetMaintenanceService) PerformMaintenance(assetPerform request.AssetMa
 		UpdatedBy:          &data.ClientID,
 	}
 
-	if err = s.AssetMaintenanceRecord.AddAssetMaintenanceRecord(&assetMaintenanceRecord); err != nil {
+	if err = s.AssetMaintenanceRecord.AddAssetMaintenanceRecord(ctx, &assetMaintenanceRecord); err != nil {
 		log.Error().
 			Str("key", "AddAssetMaintenanceRecord").
 			Str("clientID", clientID).
@@ -358,22 +346,6 @@ func (s assetMaintenanceService) PerformMaintenance(assetPerform request.AssetMa
 		return nil, err
 	}
 
-	if auditErr := s.AssetAuditLogRepository.AfterUpdateAssetMaintenance(*maintenance, &maintenances); auditErr != nil {
-		log.Error().
-			Str("key", "AfterUpdateAssetMaintenance").
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Failed to log audit after update asset maintenance")
-	}
-
-	if auditErr := s.AssetAuditLogRepository.AfterCreateAssetMaintenanceRecord(&assetMaintenanceRecord); auditErr != nil {
-		log.Error().
-			Str("key", "AfterCreateAssetMaintenanceRecord").
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Failed to log audit after create asset maintenance record")
-	}
-
 	return response.AssetMaintenancesResponse{
 		ID:           maintenances.ID,
 		UserClientID: maintenances.UserClientID,
@@ -391,7 +363,7 @@ func (s assetMaintenanceService) PerformMaintenance(assetPerform request.AssetMa
 
 }
 
-func (s assetMaintenanceService) DeleteMaintenance(maintenanceID uint, clientID string) error {
+func (s assetMaintenanceService) DeleteMaintenance(ctx context.Context, maintenanceID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -402,7 +374,7 @@ func (s assetMaintenanceService) DeleteMaintenance(maintenanceID uint, clientID
 		return err
 	}
 
-	return s.AssetMaintenanceRepository.Delete(maintenanceID, data.ClientID)
+	return s.AssetMaintenanceRepository.Delete(ctx, maintenanceID, data.ClientID, time.Now())
 }
 
 func (s assetMaintenanceService) GetMaintenancesByAssetID(assetID uint, clientID string) (interface{}, error) {
@@ -456,31 +428,105 @@ func (s assetMaintenanceService) GetMaintenancesByAssetID(assetID uint, clientID
 	}, nil
 }
 
+// PerformMaintenanceCheck finds overdue schedules and schedules due within the configured window,
+// records a notification for each new due state and publishes one asset.maintenance.due event per user.
+// The notifications of an event that fails to publish are dropped so the next check sends them again
 func (s assetMaintenanceService) PerformMaintenanceCheck() error {
-	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
-	maintenance, err := s.AssetMaintenanceRepository.GetListMaintenance()
+	now := time.Now()
+	today := utils.GetToday()
+	until := today.AddDate(0, 0, s.MaintenanceDueWithinDays)
+
+	maintenance, err := s.AssetMaintenanceRepository.GetListMaintenanceDue(until)
 	if err != nil {
 		log.Error().
-			Str("key", "GetListMaintenance").
+			Str("key", "GetListMaintenanceDue").
 			Err(err).
-			Msg("Failed to get maintenance list")
+			Msg("Failed to get due maintenance list")
 		return err
 	}
 
+	createdBy := utils.SystemUser
+	events := make(map[string]*assets.MaintenanceDueEvent)
+	notified := make(map[string][]uint)
+	var clientIDs []string
 	for _, m := range maintenance {
-		if m.MaintenanceDate == nil || m.NextDueDate == nil {
-			continue
+		dueDate := time.Date(m.NextDueDate.Year(), m.NextDueDate.Month(), m.NextDueDate.Day(), 0, 0, 0, 0, now.Location())
+		daysUntilDue := utils.DaysBetween(today, dueDate)
+
+		dueStatus := utils.MaintenanceDueUpcoming
+		if daysUntilDue < 0 {
+			dueStatus = utils.MaintenanceDueOverdue
 		}
-		jsonPretty, err := json.Marshal(m)
+
+		notification := assets.AssetMaintenanceNotification{
+			UserClientID:  m.UserClientID,
+			MaintenanceID: m.ID,
+			AssetID:       m.AssetID,
+			DueDate:       dueDate,
+			DueStatus:     dueStatus,
+			CreatedBy:     &createdBy,
+		}
+		created, err := s.NotificationRepository.AddMaintenanceNotification(&notification)
 		if err != nil {
 			log.Error().
-				Str("key", "MarshalIndent").
+				Str("key", "AddMaintenanceNotification").
+				Str("clientID", m.UserClientID).
+				Uint("maintenanceID", m.ID).
 				Err(err).
-				Msg("Failed to marshal maintenance data")
-			return err
+				Msg("Failed to record maintenance notification")
+			continue
+		}
+		if !created {
+			// this schedule was already reported for the same due date and status
+			continue
+		}
+		notified[m.UserClientID] = append(notified[m.UserClientID], notification.NotificationID)
+
+		event, ok := events[m.UserClientID]
+		if !ok {
+			event = &assets.MaintenanceDueEvent{ClientID: m.UserClientID, CheckedAt: now, WithinDays: s.MaintenanceDueWithinDays}
+			events[m.UserClientID] = event
+			clientIDs = append(clientIDs, m.UserClientID)
+		}
+		event.Maintenance = append(event.Maintenance, assets.MaintenanceDueItem{
+			MaintenanceID:       m.ID,
+			AssetID:             m.AssetID,
+			AssetName:           m.AssetName,
+			MaintenanceTypeName: m.MaintenanceTypeName,
+			NextDueDate:         dueDate.Format("2006-01-02"),
+			DaysUntilDue:        daysUntilDue,
+			DueStatus:           dueStatus,
+		})
+	}
+
+	failed := 0
+	for _, clientID := range clientIDs {
+		if err := s.NatsService.PublishMaintenanceDue(*events[clientID]); err != nil {
+			failed++
+			log.Error().
+				Str("key", "PublishMaintenanceDue").
+				Str("clientID", clientID).
+				Err(err).
+				Msg("Failed to publish maintenance due event")
+			// the reminders were not sent, forget them so the next check retries
+			if err = s.NotificationRepository.DeleteMaintenanceNotifications(notified[clientID]); err != nil {
+				log.Error().
+					Str("key", "DeleteMaintenanceNotifications").
+					Str("clientID", clientID).
+					Err(err).
+					Msg("Failed to forget unsent maintenance notifications")
+			}
+			continue
 		}
 		log.Info().
-			Str("method", "Get Data").RawJSON("data", jsonPretty).Send()
+			Str("key", "PublishMaintenanceDue").
+			Str("clientID", clientID).
+			Int("count", len(events[clientID].Maintenance)).
+			Msg("Published maintenance due event")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to publish maintenance due events for %d of %d users", failed, len(clientIDs))
 	}
 	return nil
 }
@@ -7,16 +7,22 @@ import (
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
+	"errors"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type AssetMaintenanceTypeService interface {
 	GetMaintenanceTypeByID(maintenanceTypeID uint, clientID string) (interface{}, error)
 	GetListMaintenanceType(clientID string) ([]model.AssetMaintenanceType, error)
-	AddMaintenanceType(maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error)
-	UpdateMaintenanceType(id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error)
-	DeleteMaintenanceType(maintenanceTypeID uint, clientID string) error
+	AddMaintenanceType(ctx context.Context, maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error)
+	UpdateMaintenanceType(ctx context.Context, id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error)
+	DeleteMaintenanceType(ctx context.Context, maintenanceTypeID uint, clientID string, reassignTo *uint) error
+	AddDefaultMaintenanceType(ctx context.Context, maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error)
+	UpdateDefaultMaintenanceType(ctx context.Context, id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error)
+	DeleteDefaultMaintenanceType(ctx context.Context, maintenanceTypeID uint, clientID string, reassignTo *uint) error
 }
 type assetMaintenanceTypeService struct {
 	AssetMaintenanceTypeRepository repo.AssetMaintenanceTypeRepository
@@ -34,7 +40,16 @@ func NewAssetMaintenanceTypeService(
 		Redis:                          redis}
 }
 
-func (s assetMaintenanceTypeService) AddMaintenanceType(maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s assetMaintenanceTypeService) AddMaintenanceType(ctx context.Context, maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error) {
+	return s.addMaintenanceType(ctx, maintenanceType, clientID, credentialKey, false)
+}
+
+// AddDefaultMaintenanceType adds a maintenance type visible to every user, only reachable through the admin routes
+func (s assetMaintenanceTypeService) AddDefaultMaintenanceType(ctx context.Context, maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string) (interface{}, error) {
+	return s.addMaintenanceType(ctx, maintenanceType, clientID, credentialKey, true)
+}
+
+func (s assetMaintenanceTypeService) addMaintenanceType(ctx context.Context, maintenanceType *request.AssetMaintenanceTypeRequest, clientID string, credentialKey string, isDefault bool) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -51,14 +66,20 @@ func (s assetMaintenanceTypeService) AddMaintenanceType(maintenanceType *request
 		return nil, err
 	}
 
+	name := strings.TrimSpace(maintenanceType.MaintenanceTypeName)
+	if err = s.checkMaintenanceTypeName(name, clientID, isDefault, 0); err != nil {
+		return nil, err
+	}
+
 	maintenanceTypeRecord := &model.AssetMaintenanceType{
 		UserClientID:        clientID,
-		MaintenanceTypeName: maintenanceType.MaintenanceTypeName,
+		MaintenanceTypeName: name,
 		Description:         maintenanceType.Description,
+		IsDefault:           isDefault,
 		CreatedBy:           &data.ClientID,
 	}
 
-	if err = s.AssetMaintenanceTypeRepository.AddAssetMaintenanceType(maintenanceTypeRecord, clientID); err != nil {
+	if err = s.AssetMaintenanceTypeRepository.AddAssetMaintenanceType(ctx, maintenanceTypeRecord, clientID); err != nil {
 		log.Error().
 			Str("key", "AddAssetMaintenanceType").
 			Str("clientID", clientID).
@@ -67,7 +88,7 @@ func (s assetMaintenanceTypeService) AddMaintenanceType(maintenanceType *request
 		return nil, err
 	}
 
-	return maintenanceType, nil
+	return maintenanceTypeRecord, nil
 }
 
 func (s assetMaintenanceTypeService) GetMaintenanceTypeByID(maintenanceTypeID uint, clientID string) (interface{}, error) {
@@ -92,7 +113,16 @@ func (s assetMaintenanceTypeService) GetListMaintenanceType(clientID string) ([]
 	return maintenanceTypes, nil
 }
 
-func (s assetMaintenanceTypeService) UpdateMaintenanceType(id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error) {
+// UpdateMaintenanceType renames one of the user's own maintenance types, default types are left to the admins
+func (s assetMaintenanceTypeService) UpdateMaintenanceType(ctx context.Context, id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error) {
+	return s.updateMaintenanceType(ctx, id, clientID, maintenanceType, false)
+}
+
+func (s assetMaintenanceTypeService) UpdateDefaultMaintenanceType(ctx context.Context, id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest) (interface{}, error) {
+	return s.updateMaintenanceType(ctx, id, clientID, maintenanceType, true)
+}
+
+func (s assetMaintenanceTypeService) updateMaintenanceType(ctx context.Context, id uint, clientID string, maintenanceType *request.AssetMaintenanceTypeRequest, isDefault bool) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -103,23 +133,22 @@ func (s assetMaintenanceTypeService) UpdateMaintenanceType(id uint, clientID str
 		return nil, err
 	}
 
-	exist, err := s.AssetMaintenanceTypeRepository.GetAssetMaintenanceTypeByName(maintenanceType.MaintenanceTypeName, clientID)
+	existing, err := s.getManagedMaintenanceType(id, clientID, isDefault)
 	if err != nil {
 		return nil, err
 	}
 
-	if exist.MaintenanceTypeID == 0 {
-		return nil, gorm.ErrRecordNotFound
+	name := strings.TrimSpace(maintenanceType.MaintenanceTypeName)
+	if err = s.checkMaintenanceTypeName(name, clientID, isDefault, id); err != nil {
+		return nil, err
 	}
 
-	maintenanceTypeRecord := &model.AssetMaintenanceType{
-		MaintenanceTypeID:   id,
-		MaintenanceTypeName: maintenanceType.MaintenanceTypeName,
-		Description:         maintenanceType.Description,
-		UpdatedBy:           &data.ClientID,
-	}
+	maintenanceTypeRecord := *existing
+	maintenanceTypeRecord.MaintenanceTypeName = name
+	maintenanceTypeRecord.Description = maintenanceType.Description
+	maintenanceTypeRecord.UpdatedBy = &data.ClientID
 
-	err = s.AssetMaintenanceTypeRepository.UpdateAssetMaintenanceType(maintenanceTypeRecord, clientID)
+	err = s.AssetMaintenanceTypeRepository.UpdateAssetMaintenanceType(ctx, &maintenanceTypeRecord)
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +156,96 @@ func (s assetMaintenanceTypeService) UpdateMaintenanceType(id uint, clientID str
 	return maintenanceTypeRecord, nil
 }
 
-func (s assetMaintenanceTypeService) DeleteMaintenanceType(maintenanceTypeID uint, clientID string) error {
-	if err := s.AssetMaintenanceTypeRepository.DeleteAssetMaintenanceTypeByID(maintenanceTypeID, clientID); err != nil {
+// DeleteMaintenanceType deletes one of the user's own maintenance types. A type still used by maintenance schedules
+// can only be deleted when reassignTo names another type of the user or a default type to move them to.
+func (s assetMaintenanceTypeService) DeleteMaintenanceType(ctx context.Context, maintenanceTypeID uint, clientID string, reassignTo *uint) error {
+	return s.deleteMaintenanceType(ctx, maintenanceTypeID, clientID, reassignTo, false)
+}
+
+// DeleteDefaultMaintenanceType deletes a default type, its schedules can only be reassigned to another default type
+func (s assetMaintenanceTypeService) DeleteDefaultMaintenanceType(ctx context.Context, maintenanceTypeID uint, clientID string, reassignTo *uint) error {
+	return s.deleteMaintenanceType(ctx, maintenanceTypeID, clientID, reassignTo, true)
+}
+
+func (s assetMaintenanceTypeService) deleteMaintenanceType(ctx context.Context, maintenanceTypeID uint, clientID string, reassignTo *uint, isDefault bool) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		log.Error().
+			Str("key", "GetUserRedis").
+			Str("clientID", clientID).
+			Err(err).
+			Msg("Failed to get user redis")
 		return err
 	}
 
+	maintenanceType, err := s.getManagedMaintenanceType(maintenanceTypeID, clientID, isDefault)
+	if err != nil {
+		return err
+	}
+
+	if reassignTo != nil {
+		if *reassignTo == maintenanceTypeID {
+			return errors.New("maintenance type cannot be reassigned to itself")
+		}
+		target, err := s.AssetMaintenanceTypeRepository.GetAssetMaintenanceTypeByID(*reassignTo, clientID)
+		if err != nil || (isDefault && !target.IsDefault) {
+			log.Error().
+				Str("key", "GetAssetMaintenanceTypeByID").
+				Str("clientID", clientID).
+				Uint("reassignTo", *reassignTo).
+				Err(err).
+				Msg("Failed to get maintenance type to reassign to")
+			return errors.New("maintenance type to reassign to not found")
+		}
+	}
+
+	maintenanceType.DeletedBy = &data.ClientID
+	if err = s.AssetMaintenanceTypeRepository.DeleteMaintenanceType(ctx, maintenanceType, reassignTo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getManagedMaintenanceType returns the maintenance type when the caller may change it: their own types on the user
+// routes and the default types on the admin routes
+func (s assetMaintenanceTypeService) getManagedMaintenanceType(maintenanceTypeID uint, clientID string, isDefault bool) (*model.AssetMaintenanceType, error) {
+	maintenanceType, err := s.AssetMaintenanceTypeRepository.GetAssetMaintenanceTypeByID(maintenanceTypeID, clientID)
+	if err != nil {
+		log.Error().
+			Str("key", "GetAssetMaintenanceTypeByID").
+			Str("clientID", clientID).
+			Uint("maintenanceTypeID", maintenanceTypeID).
+			Err(err).
+			Msg("Failed to get maintenance type")
+		return nil, errors.New("maintenance type not found")
+	}
+
+	if maintenanceType.IsDefault != isDefault {
+		if maintenanceType.IsDefault {
+			return nil, errors.New("default maintenance types can only be changed by an admin")
+		}
+		return nil, errors.New("maintenance type not found")
+	}
+	return maintenanceType, nil
+}
+
+func (s assetMaintenanceTypeService) checkMaintenanceTypeName(name string, clientID string, isDefault bool, excludeMaintenanceTypeID uint) error {
+	if name == "" {
+		return errors.New("maintenance type name is required")
+	}
+
+	exists, err := s.AssetMaintenanceTypeRepository.MaintenanceTypeNameExists(name, clientID, isDefault, excludeMaintenanceTypeID)
+	if err != nil {
+		log.Error().
+			Str("key", "MaintenanceTypeNameExists").
+			Str("clientID", clientID).
+			Err(err).
+			Msg("Failed to check maintenance type name")
+		return err
+	}
+	if exists {
+		return errors.New("maintenance type already exists")
+	}
 	return nil
 }
@@ -8,25 +8,34 @@ import (
 	"asset-service/internal/repository/transaction"
 	repouser "asset-service/internal/repository/users"
 	"asset-service/internal/utils"
+	"asset-service/internal/utils/customfield"
+	"asset-service/internal/utils/depreciation"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
+	"fmt"
 	"github.com/rs/zerolog/log"
+	"strconv"
+	"time"
 )
 
 type AssetService interface {
-	AddAsset(assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID, requestHeaderID string) (interface{}, error)
-	UpdateAsset(assetID uint, assetRequest request.UpdateAssetRequest, clientID string, credentialKey string) (interface{}, error)
-	UpdateStockAsset(isAdded bool, assetID uint, stock struct {
+	AddAsset(ctx context.Context, assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID, requestHeaderID string) (interface{}, error)
+	UpdateAsset(ctx context.Context, assetID uint, assetRequest request.UpdateAssetRequest, clientID string, credentialKey string) (interface{}, error)
+	UpdateStockAsset(ctx context.Context, isAdded bool, assetID uint, stock struct {
 		Stock  int     `json:"stock" binding:"required"`
 		Reason *string `json:"reason"`
 	}, clientID string) (interface{}, error)
-	UpdateImageAsset(assetID uint, clientID string, metadata []response.AssetImageResponse) error
-	GetListAsset(clientID string, index int, size int) (interface{}, int64, error)
+	UpdateImageAsset(ctx context.Context, assetID uint, clientID string, metadata []response.AssetImageResponse) error
+	GetListAsset(clientID string, index int, size int, assetQuery request.AssetQueryRequest) (interface{}, int64, error)
 	GetAssetByID(clientID string, assetID uint) (interface{}, error)
-	UpdateAssetStatus(assetID uint, statusID uint, clientID string) error
-	UpdateAssetCategory(assetID uint, categoryID uint, clientID string) error
-	DeleteAsset(assetID uint, clientID string) error
+	LookupAsset(clientID string, barcode, serialNumber string) (interface{}, error)
+	UpdateAssetStatus(ctx context.Context, assetID uint, statusID uint, clientID string) error
+	UpdateAssetCategory(ctx context.Context, assetID uint, categoryID uint, clientID string) error
+	DeleteAsset(ctx context.Context, assetID uint, clientID, componentMode string) error
+	GetAssetAsOf(clientID string, assetID uint, asOf time.Time) (interface{}, error)
+	RevertAsset(ctx context.Context, assetID uint, asOf time.Time, clientID, credentialKey string) (interface{}, error)
 }
 
 type assetService struct {
@@ -36,11 +45,11 @@ type assetService struct {
 	AssetStatusRepository      repo.AssetStatusRepository
 	AssetImageRepository       repo.AssetImageRepository
 	Redis                      redis.RedisService
-	AuditLogRepository         repo.AssetAuditLogRepository
 	AssetGroupMemberRepository repo.AssetGroupMemberRepository
 	AssetGroupAssetRepository  repo.AssetGroupAssetRepository
 	AssetTransaction           transaction.AssetTransactionRepository
 	AssetStockRepository       repo.AssetStockRepository
+	AuditLogRepository         repo.AssetAuditLogRepository
 }
 
 func NewAssetService(userRepository repouser.UserRepository,
@@ -48,27 +57,27 @@ func NewAssetService(userRepository repouser.UserRepository,
 	assetCategoryRepository repo.AssetCategoryRepository,
 	assetStatusRepository repo.AssetStatusRepository,
 	assetImageRepository repo.AssetImageRepository,
-	log repo.AssetAuditLogRepository,
 	assetGroupMemberRepository repo.AssetGroupMemberRepository,
 	assetGroupAssetRepository repo.AssetGroupAssetRepository,
 	redis redis.RedisService,
 	assetTransaction transaction.AssetTransactionRepository,
-	assetStockRepository repo.AssetStockRepository) AssetService {
+	assetStockRepository repo.AssetStockRepository,
+	auditLogRepository repo.AssetAuditLogRepository) AssetService {
 	return assetService{
 		UserRepository:             userRepository,
 		AssetRepository:            assetRepository,
 		AssetCategoryRepository:    assetCategoryRepository,
 		AssetStatusRepository:      assetStatusRepository,
 		AssetImageRepository:       assetImageRepository,
-		AuditLogRepository:         log,
 		AssetGroupMemberRepository: assetGroupMemberRepository,
 		AssetGroupAssetRepository:  assetGroupAssetRepository,
 		Redis:                      redis,
 		AssetTransaction:           assetTransaction,
-		AssetStockRepository:       assetStockRepository}
+		AssetStockRepository:       assetStockRepository,
+		AuditLogRepository:         auditLogRepository}
 }
 
-func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID, credentialKey string) (interface{}, error) {
+func (s assetService) AddAsset(ctx context.Context, assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logError("GetRedisData", clientID, err, "Failed to get data from redis")
@@ -91,10 +100,21 @@ func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []resp
 		return logError("AssetNameExists", clientID, errors.New("assets already exists"), "Asset name already exists")
 	}
 
-	if _, err := s.AssetCategoryRepository.GetAssetCategoryById(uint(assetRequest.CategoryID), clientID); err != nil {
+	assetRequest.ConvertAssetRequestEmptyToNil()
+	if err := checkAssetIdentifiers(s.AssetRepository, clientID, assetRequest.SerialNumber, assetRequest.Barcode, 0); err != nil {
+		return logError("CheckAssetIdentifiers", clientID, err, "Asset identifier already exists")
+	}
+
+	category, err := s.AssetCategoryRepository.GetAssetCategoryById(uint(assetRequest.CategoryID), clientID)
+	if err != nil {
 		return logError("GetAssetCategoryById", clientID, errors.New("category not found"), "Failed to get asset category by MaintenanceTypeID")
 	}
 
+	customFields, err := category.CustomFields.Check(assetRequest.CustomFields)
+	if err != nil {
+		return logError("CheckCustomFields", clientID, err, "Invalid custom fields")
+	}
+
 	if _, err := s.AssetStatusRepository.GetAssetStatusByID(uint(assetRequest.StatusID)); err != nil {
 		return logError("GetAssetStatusByID", clientID, errors.New("status not found"), "Failed to get asset status by MaintenanceTypeID")
 	}
@@ -103,8 +123,6 @@ func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []resp
 	expiryDate, _ := utils.ParseOptionalDate(assetRequest.ExpiryDate)
 	warrantyExpiry, _ := utils.ParseOptionalDate(assetRequest.WarrantyExpiry)
 
-	assetRequest.ConvertAssetRequestEmptyToNil()
-
 	asset := &assets.Asset{
 		UserClientID:       clientID,
 		SerialNumber:       assetRequest.SerialNumber,
@@ -119,11 +137,12 @@ func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []resp
 		Price:              assetRequest.Price,
 		Stock:              assetRequest.Stock,
 		Notes:              assetRequest.Notes,
+		CustomFields:       customFields,
 		CreatedBy:          &data.ClientID,
 		UpdatedBy:          &data.ClientID,
 	}
 
-	if err := s.AssetRepository.AddAsset(asset, images); err != nil {
+	if err := s.AssetRepository.AddAsset(ctx, asset, images); err != nil {
 		return logError("AddAsset", clientID, err, "Failed to add asset")
 	}
 
@@ -137,7 +156,7 @@ func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []resp
 			CreatedBy:    &data.ClientID,
 		}
 
-		if err := s.AssetGroupAssetRepository.AddAssetGroupAsset(assetGroupAsset); err != nil {
+		if err := s.AssetGroupAssetRepository.AddAssetGroupAsset(ctx, assetGroupAsset); err != nil {
 			return logError("AddAssetGroupAsset", clientID, err, "Failed to add asset group asset")
 		}
 	}
@@ -158,7 +177,7 @@ func (s assetService) AddAsset(assetRequest *request.AssetRequest, images []resp
 	return result, nil
 }
 
-func (s assetService) UpdateAsset(assetID uint, assetRequest request.UpdateAssetRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s assetService) UpdateAsset(ctx context.Context, assetID uint, assetRequest request.UpdateAssetRequest, clientID string, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
@@ -175,6 +194,10 @@ func (s assetService) UpdateAsset(assetID uint, assetRequest request.UpdateAsset
 		return logError("GetAsset", clientID, err, "Failed to get asset by MaintenanceTypeID")
 	}
 
+	if err := checkAssetIdentifiers(s.AssetRepository, clientID, assetRequest.SerialNumber, assetRequest.Barcode, assetID); err != nil {
+		return logError("CheckAssetIdentifiers", clientID, err, "Asset identifier already exists")
+	}
+
 	purchaseDate, _ := utils.ParseOptionalDate(assetRequest.PurchaseDate)
 	expiryDate, _ := utils.ParseOptionalDate(assetRequest.ExpiryDate)
 	warrantyExpiry, _ := utils.ParseOptionalDate(assetRequest.WarrantyExpiryDate)
@@ -196,18 +219,40 @@ func (s assetService) UpdateAsset(assetID uint, assetRequest request.UpdateAsset
 		UpdatedBy:          &data.ClientID,
 	}
 
-	if err := s.AssetRepository.UpdateAsset(asset, clientID); err != nil {
-		return logError("UpdateAsset", clientID, err, "Failed to update asset")
+	if assetRequest.CustomFields != nil || (asset.CategoryID != 0 && asset.CategoryID != oldAsset.CategoryID) {
+		customFields, err := s.checkCustomFields(oldAsset, asset.CategoryID, assetRequest.CustomFields, clientID)
+		if err != nil {
+			return logError("CheckCustomFields", clientID, err, "Invalid custom fields")
+		}
+		asset.CustomFields = customFields
 	}
 
-	if err := s.AuditLogRepository.AfterUpdateAsset(*oldAsset, asset); err != nil {
-		return logError("AfterUpdateAsset", clientID, err, "Failed to update asset log")
+	if err := s.AssetRepository.UpdateAsset(ctx, asset, clientID); err != nil {
+		return logError("UpdateAsset", clientID, err, "Failed to update asset")
 	}
 
 	return asset, nil
 }
 
-func (s assetService) UpdateStockAsset(isAdded bool, assetID uint, stock struct {
+// checkCustomFields validates the custom field values of an updated asset against the schema of its
+// category, when no values are sent the stored ones are kept as far as the new category declares them
+func (s assetService) checkCustomFields(oldAsset *assets.Asset, categoryID uint, values map[string]interface{}, clientID string) (customfield.Values, error) {
+	if categoryID == 0 {
+		categoryID = oldAsset.CategoryID
+	}
+
+	category, err := s.AssetCategoryRepository.GetAssetCategoryById(categoryID, clientID)
+	if err != nil {
+		return nil, errors.New("category not found")
+	}
+
+	if values == nil {
+		values = category.CustomFields.Prune(oldAsset.CustomFields)
+	}
+	return category.CustomFields.Check(values)
+}
+
+func (s assetService) UpdateStockAsset(ctx context.Context, isAdded bool, assetID uint, stock struct {
 	Stock  int     `json:"stock" binding:"required"`
 	Reason *string `json:"reason"`
 }, clientID string) (interface{}, error) {
@@ -261,7 +306,7 @@ func (s assetService) UpdateStockAsset(isAdded bool, assetID uint, stock struct
 	}
 
 	// Step 5: Update stock in a transaction
-	err = s.AssetStockRepository.UpdateAssetStock(newAssetStock, clientID)
+	err = s.AssetStockRepository.UpdateAssetStock(ctx, newAssetStock, clientID)
 	if err != nil {
 		return logError("UpdateAssetStock", clientID, err, "Failed to update asset stock")
 	}
@@ -272,13 +317,7 @@ func (s assetService) UpdateStockAsset(isAdded bool, assetID uint, stock struct
 		Str("Change Type", stockType).
 		Msg("Stock updated successfully")
 
-	// Step 6: Log stock change in audit log
-	err = s.AuditLogRepository.AfterUpdateAssetStock(*oldAssetStock, newAssetStock)
-	if err != nil {
-		return logError("AfterUpdateAssetStock", clientID, err, "Failed to update asset stock log")
-	}
-
-	// Step 7: Return updated stock response
+	// Step 6: Return updated stock response
 	return response.AssetStockResponse{
 		StockID:         newAssetStock.StockID,
 		AssetID:         newAssetStock.AssetID,
@@ -289,13 +328,13 @@ func (s assetService) UpdateStockAsset(isAdded bool, assetID uint, stock struct
 	}, nil
 }
 
-func (s assetService) UpdateImageAsset(assetID uint, clientID string, metadata []response.AssetImageResponse) error {
+func (s assetService) UpdateImageAsset(ctx context.Context, assetID uint, clientID string, metadata []response.AssetImageResponse) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
 	}
 
-	err = s.AssetImageRepository.UpdateAssetImage(assetID, metadata, data.ClientID)
+	err = s.AssetImageRepository.UpdateAssetImage(ctx, assetID, metadata, data.ClientID)
 	if err != nil {
 		return logErrorWithNoReturn("UpdateAssetImage", clientID, err, "Failed to update asset image")
 	}
@@ -303,18 +342,18 @@ func (s assetService) UpdateImageAsset(assetID uint, clientID string, metadata [
 	return nil
 }
 
-func (s assetService) GetListAsset(clientID string, index int, size int) (interface{}, int64, error) {
+func (s assetService) GetListAsset(clientID string, index int, size int, assetQuery request.AssetQueryRequest) (interface{}, int64, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logListError("GetUserRedis", clientID, err, "Failed to get user from Redis")
 	}
 
-	result, err := s.AssetRepository.GetListAssets(data.ClientID, index, size)
+	result, err := s.AssetRepository.GetListAssets(data.ClientID, index, size, assetQuery)
 	if err != nil {
 		return logListError("GetListAssets", clientID, err, "Failed to get list assets")
 	}
 
-	assetCount, err := s.AssetRepository.GetCountAsset(data.ClientID)
+	assetCount, err := s.AssetRepository.GetCountAsset(data.ClientID, assetQuery)
 	if err != nil {
 		return logListError("GetCountAssets", clientID, err, "Failed to get count assets")
 	}
@@ -340,60 +379,87 @@ func (s assetService) GetAssetByID(clientID string, assetID uint) (interface{},
 	return *asset, nil
 }
 
-func (s assetService) UpdateAssetStatus(assetID uint, statusID uint, clientID string) error {
+func (s assetService) LookupAsset(clientID string, barcode, serialNumber string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
-		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
 	}
 
-	oldAsset, err := s.AssetRepository.GetAsset(assetID, clientID)
+	user, err := s.UserRepository.GetUserByClientID(data.ClientID)
 	if err != nil {
-		return logErrorWithNoReturn("GetAsset", clientID, err, "Failed to get asset by MaintenanceTypeID")
+		return logError("GetUserByClientID", clientID, err, "Failed to get user by client ID")
 	}
 
-	asset, err := s.AssetRepository.UpdateAssetStatus(assetID, statusID, data.ClientID)
+	asset, err := s.AssetRepository.GetAssetByIdentifier(data.ClientID, user.UserID, barcode, serialNumber)
 	if err != nil {
-		return logErrorWithNoReturn("UpdateAssetStatus", clientID, err, "Failed to update asset status")
+		return logError("GetAssetByIdentifier", clientID, errors.New("asset not found"), "Failed to lookup asset")
 	}
 
-	err = s.AuditLogRepository.AfterUpdateAsset(*oldAsset, asset)
+	result, err := s.AssetRepository.GetAssetResponseByID(asset.UserClientID, asset.AssetID)
 	if err != nil {
-		return logErrorWithNoReturn("AfterUpdateAsset", clientID, err, "Failed to update asset")
+		return logError("GetAssetResponseByID", clientID, err, "Failed to get asset by ID")
 	}
 
-	return nil
+	assetImage, err := s.AssetImageRepository.GetAssetImageResponseByAssetID(asset.AssetID)
+	if err == nil && assetImage != nil {
+		result.Images = *assetImage
+	}
+
+	return *result, nil
 }
 
-func (s assetService) UpdateAssetCategory(assetID uint, categoryID uint, clientID string) error {
+func (s assetService) UpdateAssetStatus(ctx context.Context, assetID uint, statusID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
 	}
 
-	oldAsset, err := s.AssetRepository.GetAsset(assetID, clientID)
-	if err != nil {
+	if _, err = s.AssetRepository.GetAsset(assetID, clientID); err != nil {
 		return logErrorWithNoReturn("GetAsset", clientID, err, "Failed to get asset by MaintenanceTypeID")
 	}
 
-	asset, err := s.AssetRepository.UpdateAssetCategory(assetID, categoryID, data.ClientID)
+	_, err = s.AssetRepository.UpdateAssetStatus(ctx, assetID, statusID, data.ClientID)
 	if err != nil {
-		return logErrorWithNoReturn("UpdateAssetCategory", clientID, err, "Failed to update asset category")
+		return logErrorWithNoReturn("UpdateAssetStatus", clientID, err, "Failed to update asset status")
 	}
 
-	err = s.AuditLogRepository.AfterUpdateAsset(*oldAsset, asset)
+	return nil
+}
+
+func (s assetService) UpdateAssetCategory(ctx context.Context, assetID uint, categoryID uint, clientID string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
-		return logErrorWithNoReturn("AfterUpdateAsset", clientID, err, "Failed to update asset")
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
 	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, clientID); err != nil {
+		return logErrorWithNoReturn("GetAsset", clientID, err, "Failed to get asset by MaintenanceTypeID")
+	}
+
+	_, err = s.AssetRepository.UpdateAssetCategory(ctx, assetID, categoryID, data.ClientID)
+	if err != nil {
+		return logErrorWithNoReturn("UpdateAssetCategory", clientID, err, "Failed to update asset category")
+	}
+
 	return nil
 }
 
-func (s assetService) DeleteAsset(assetID uint, clientID string) error {
+// DeleteAsset deletes the asset, componentMode is one of detach (the default), cascade or restrict
+func (s assetService) DeleteAsset(ctx context.Context, assetID uint, clientID, componentMode string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
 	}
 
-	err = s.AssetTransaction.DeleteAsset(assetID, clientID, data.ClientID)
+	switch componentMode {
+	case "":
+		componentMode = utils.ComponentDeleteDetach
+	case utils.ComponentDeleteDetach, utils.ComponentDeleteCascade, utils.ComponentDeleteRestrict:
+	default:
+		return logErrorWithNoReturn("DeleteAsset", clientID, errors.New("components must be detach, cascade or restrict"), "Invalid component mode")
+	}
+
+	err = s.AssetTransaction.DeleteAsset(ctx, assetID, clientID, data.ClientID, componentMode)
 	if err != nil {
 		return logErrorWithNoReturn("DeleteAsset", clientID, err, "Failed to delete asset")
 	}
@@ -401,6 +467,264 @@ func (s assetService) DeleteAsset(assetID uint, clientID string) error {
 	return nil
 }
 
+// GetAssetAsOf rebuilds the asset as it was at asOf by replaying the audit history of the asset and of its
+// stock, category, status and location. Images, tags and component totals have no history and are left out
+func (s assetService) GetAssetAsOf(clientID string, assetID uint, asOf time.Time) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	asset, err := s.assetAsOf(data.ClientID, assetID, asOf)
+	if err != nil {
+		return logError("AssetAsOf", clientID, err, "Failed to rebuild asset")
+	}
+
+	var stock assets.AssetStock
+	stockEntries, err := s.AuditLogRepository.GetAuditAssetEntries(utils.TableAssetStockName, assetID)
+	if err != nil {
+		return logError("GetAuditAssetEntries", clientID, err, "Failed to get asset stock history")
+	}
+	if len(stockEntries) == 0 {
+		current, _ := s.AssetStockRepository.GetAssetStockByAssetID(assetID, data.ClientID)
+		_, err = rowAsOf(nil, asOf, current, &stock)
+	} else {
+		err = assetStockAsOf(stockEntries, asOf, &stock)
+	}
+	if err != nil {
+		return logError("AssetStockAsOf", clientID, err, "Failed to rebuild asset stock")
+	}
+
+	var category assets.AssetCategory
+	categoryEntries, err := s.AuditLogRepository.GetAuditRecordEntries(utils.TableAssetCategoryName, strconv.FormatUint(uint64(asset.CategoryID), 10))
+	if err != nil {
+		return logError("GetAuditRecordEntries", clientID, err, "Failed to get asset category history")
+	}
+	currentCategory, _ := s.AssetCategoryRepository.GetAssetCategoryById(asset.CategoryID, asset.UserClientID)
+	if _, err = rowAsOf(categoryEntries, asOf, currentCategory, &category); err != nil {
+		return logError("AssetCategoryAsOf", clientID, err, "Failed to rebuild asset category")
+	}
+
+	var status assets.AssetStatus
+	statusEntries, err := s.AuditLogRepository.GetAuditRecordEntries(utils.TableAssetStatusName, strconv.FormatUint(uint64(asset.StatusID), 10))
+	if err != nil {
+		return logError("GetAuditRecordEntries", clientID, err, "Failed to get asset status history")
+	}
+	currentStatus, _ := s.AssetStatusRepository.GetAssetStatusByID(asset.StatusID)
+	if _, err = rowAsOf(statusEntries, asOf, currentStatus, &status); err != nil {
+		return logError("AssetStatusAsOf", clientID, err, "Failed to rebuild asset status")
+	}
+
+	result := response.AssetResponse{
+		AssetID:            asset.AssetID,
+		UserClientID:       asset.UserClientID,
+		SerialNumber:       asset.SerialNumber,
+		Name:               asset.Name,
+		Barcode:            asset.Barcode,
+		PurchaseDate:       (*response.DateOnly)(asset.PurchaseDate),
+		ExpiryDate:         (*response.DateOnly)(asset.ExpiryDate),
+		WarrantyExpiryDate: (*response.DateOnly)(asset.WarrantyExpiryDate),
+		Price:              asset.Price,
+		TotalPrice:         asset.Price,
+		Notes:              asset.Notes,
+		CustomFields:       asset.CustomFields,
+		ParentAssetID:      asset.ParentAssetID,
+		Category: response.AssetCategoryResponse{
+			AssetCategoryID:    category.AssetCategoryID,
+			CategoryName:       category.CategoryName,
+			Description:        category.Description,
+			DepreciationMethod: category.DepreciationMethod,
+			UsefulLifeMonths:   category.UsefulLifeMonths,
+			SalvageValue:       category.SalvageValue,
+		},
+		Status: response.AssetStatusResponse{
+			AssetStatusID: status.AssetStatusID,
+			StatusName:    status.StatusName,
+			Description:   status.Description,
+		},
+		Stock: response.AssetStockResponse{
+			StockID:         stock.StockID,
+			InitialQuantity: stock.InitialQuantity,
+			LatestQuantity:  stock.LatestQuantity,
+		},
+	}
+	if asset.Description != nil {
+		result.Description = *asset.Description
+	}
+
+	result.BookValue = asset.Price
+	settings := depreciation.NewSettings(category.DepreciationMethod, category.UsefulLifeMonths, category.SalvageValue)
+	if asset.PurchaseDate != nil && settings.Enabled() {
+		year, month, day := asOf.Date()
+		result.BookValue = settings.BookValue(asset.Price, *asset.PurchaseDate, time.Date(year, month, day, 0, 0, 0, 0, asOf.Location()))
+	}
+
+	if asset.LocationID != nil {
+		location := assets.AssetLocation{LocationID: *asset.LocationID}
+		locationEntries, err := s.AuditLogRepository.GetAuditRecordEntries(utils.TableAssetLocationName, strconv.FormatUint(uint64(*asset.LocationID), 10))
+		if err != nil {
+			return logError("GetAuditRecordEntries", clientID, err, "Failed to get asset location history")
+		}
+		if _, err = rowAsOf(locationEntries, asOf, nil, &location); err != nil {
+			return logError("AssetLocationAsOf", clientID, err, "Failed to rebuild asset location")
+		}
+		result.Location = &response.AssetLocationResponse{
+			LocationID:   location.LocationID,
+			Name:         location.Name,
+			LocationType: location.LocationType,
+		}
+	}
+
+	return result, nil
+}
+
+// RevertAsset writes the fields the asset had at asOf back as a new update, so the revert is audited like any
+// other change. Location, parent and stock are left as they are, they change through their own endpoints
+func (s assetService) RevertAsset(ctx context.Context, assetID uint, asOf time.Time, clientID, credentialKey string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID)
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("credential key check failed")
+		return nil, err
+	}
+
+	current, err := s.AssetRepository.GetAsset(assetID, data.ClientID)
+	if err != nil {
+		return logError("GetAsset", clientID, err, "Failed to get asset")
+	}
+
+	asset, err := s.assetAsOf(data.ClientID, assetID, asOf)
+	if err != nil {
+		return logError("AssetAsOf", clientID, err, "Failed to rebuild asset")
+	}
+
+	if err = checkAssetIdentifiers(s.AssetRepository, data.ClientID, asset.SerialNumber, asset.Barcode, assetID); err != nil {
+		return logError("CheckAssetIdentifiers", clientID, err, "Asset identifier already exists")
+	}
+	if _, err = s.AssetStatusRepository.GetAssetStatusByID(asset.StatusID); err != nil {
+		return logError("GetAssetStatusByID", clientID, errors.New("status of that version no longer exists"), "Failed to revert asset")
+	}
+	if asset.CustomFields != nil {
+		if asset.CustomFields, err = s.checkCustomFields(current, asset.CategoryID, asset.CustomFields, data.ClientID); err != nil {
+			return logError("CheckCustomFields", clientID, err, "Custom fields of that version are no longer valid")
+		}
+	} else if _, err = s.AssetCategoryRepository.GetAssetCategoryById(asset.CategoryID, data.ClientID); err != nil {
+		return logError("GetAssetCategoryById", clientID, errors.New("category of that version no longer exists"), "Failed to revert asset")
+	}
+
+	asset.AssetID = assetID
+	asset.UpdatedBy = &data.ClientID
+	if err = s.AssetRepository.RevertAsset(ctx, asset, data.ClientID); err != nil {
+		return logError("RevertAsset", clientID, err, "Failed to revert asset")
+	}
+
+	log.Info().Str("key", "RevertAsset").Str("clientID", clientID).Uint("assetID", assetID).Time("asOf", asOf).Msg("Success to revert asset")
+	return s.GetAssetByID(clientID, assetID)
+}
+
+// assetAsOf rebuilds the asset row at asOf for its current owner, or for its last owner once it is deleted
+func (s assetService) assetAsOf(clientID string, assetID uint, asOf time.Time) (*assets.Asset, error) {
+	entries, err := s.AuditLogRepository.GetAuditRecordEntries(utils.TableAssetName, strconv.FormatUint(uint64(assetID), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.AssetRepository.GetAsset(assetID, clientID)
+	if err != nil {
+		if auditOwner(entries) != clientID {
+			return nil, errors.New("asset not found")
+		}
+		current = nil
+	}
+
+	var asset assets.Asset
+	exists, err := rowAsOf(entries, asOf, current, &asset)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("asset did not exist at %s", asOf.Format(time.RFC3339))
+	}
+	return &asset, nil
+}
+
+// assetStockAsOf rebuilds the stock the asset has outside of any asset group at asOf
+func assetStockAsOf(entries []response.AssetAuditLogResponse, asOf time.Time, stock *assets.AssetStock) error {
+	byRecord := map[string][]response.AssetAuditLogResponse{}
+	var records []string
+	for _, entry := range entries {
+		if entry.RecordID == nil {
+			continue
+		}
+		if _, ok := byRecord[*entry.RecordID]; !ok {
+			records = append(records, *entry.RecordID)
+		}
+		byRecord[*entry.RecordID] = append(byRecord[*entry.RecordID], entry)
+	}
+
+	for _, record := range records {
+		state, _ := auditStateAsOf(byRecord[record], asOf)
+		if state == nil || state["deleted_at"] != nil || state["asset_group_id"] != nil {
+			continue
+		}
+		_, err := rowAsOf(byRecord[record], asOf, nil, stock)
+		return err
+	}
+	return nil
+}
+
+// checkAssetIdentifiers makes sure the serial number and barcode are not used by another asset of the same owner
+func checkAssetIdentifiers(assetRepository repo.AssetRepository, clientID string, serialNumber, barcode *string, excludeAssetID uint) error {
+	if serialNumber != nil && *serialNumber != "" {
+		exists, err := assetRepository.AssetSerialNumberExists(*serialNumber, clientID, excludeAssetID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("serial number already exists")
+		}
+	}
+
+	if barcode != nil && *barcode != "" {
+		exists, err := assetRepository.AssetBarcodeExists(*barcode, clientID, excludeAssetID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("barcode already exists")
+		}
+	}
+	return nil
+}
+
+// checkGroupMember lets the owner and the members of the asset group through. It returns the caller's user ID
+// and whether the caller owns the group
+func checkGroupMember(userRepository repouser.UserRepository, assetGroupRepository repo.AssetGroupRepository,
+	memberRepository repo.AssetGroupMemberRepository, assetGroupID uint, clientID string) (uint, bool, error) {
+	caller, err := userRepository.GetUserByClientID(clientID)
+	if err != nil {
+		return 0, false, logErrorWithNoReturn("GetUserByClientID", clientID, err, "Failed to get user by client ID")
+	}
+
+	assetGroup, err := assetGroupRepository.GetAssetGroupByID(assetGroupID)
+	if err != nil || assetGroup == nil {
+		return 0, false, logErrorWithNoReturn("GetAssetGroupByID", clientID, errors.New("asset group not found"), "Failed to get asset group")
+	}
+	if assetGroup.OwnerUserID == caller.UserID {
+		return caller.UserID, true, nil
+	}
+
+	member, err := memberRepository.GetAssetGroupMemberByUserIDAndGroupID(caller.UserID, assetGroupID)
+	if err != nil || member.AssetGroupID == 0 {
+		return 0, false, logErrorWithNoReturn("GetAssetGroupMemberByUserIDAndGroupID", clientID, errors.New("user is not a member of this asset group"), "User is not a member of this asset group")
+	}
+	return caller.UserID, false, nil
+}
+
 func logError(key, clientID string, err error, msg string) (interface{}, error) {
 	log.Error().Str("key", key).Str("clientID", clientID).Err(err).Msg(msg)
 	if err == nil {
@@ -8,35 +8,33 @@ import (
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
 	"asset-service/internal/utils/text"
+	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 )
 
 type AssetStatusService interface {
-	AddAssetStatus(assetStatusRequest *request.AssetStatusRequest, clientID string, credentialKey string) (interface{}, error)
+	AddAssetStatus(ctx context.Context, assetStatusRequest *request.AssetStatusRequest, clientID string, credentialKey string) (interface{}, error)
 	GetAssetStatus(clientID string, size, index int) (interface{}, int64, error)
 	GetAssetStatusByID(assetStatusID uint) (interface{}, error)
-	UpdateAssetStatus(assetStatusID uint, assetStatusRequest *request.AssetStatusRequest, clientID string) (interface{}, error)
-	DeleteAssetStatus(assetStatusID uint, clientID string) error
+	UpdateAssetStatus(ctx context.Context, assetStatusID uint, assetStatusRequest *request.AssetStatusRequest, clientID string) (interface{}, error)
+	DeleteAssetStatus(ctx context.Context, assetStatusID uint, clientID string) error
 }
 
 type assetStatusService struct {
-	AssetStatusRepository   repository.AssetStatusRepository
-	AssetAuditLogRepository repository.AssetAuditLogRepository
-	Redis                   redis.RedisService
+	AssetStatusRepository repository.AssetStatusRepository
+	Redis                 redis.RedisService
 }
 
 func NewAssetStatusService(
 	assetStatusRepository repository.AssetStatusRepository,
-	AssetAuditLogRepository repository.AssetAuditLogRepository,
 	redis redis.RedisService) AssetStatusService {
 	return assetStatusService{
-		AssetStatusRepository:   assetStatusRepository,
-		AssetAuditLogRepository: AssetAuditLogRepository,
-		Redis:                   redis}
+		AssetStatusRepository: assetStatusRepository,
+		Redis:                 redis}
 }
 
-func (s assetStatusService) AddAssetStatus(assetStatusRequest *request.AssetStatusRequest, clientID string, credentialKey string) (interface{}, error) {
+func (s assetStatusService) AddAssetStatus(ctx context.Context, assetStatusRequest *request.AssetStatusRequest, clientID string, credentialKey string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 
 	err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID)
@@ -60,7 +58,7 @@ func (s assetStatusService) AddAssetStatus(assetStatusRequest *request.AssetStat
 		return nil, errors.New("assets status already exists")
 	}
 
-	if err = s.AssetStatusRepository.AddAssetStatus(&assetStatus); err != nil {
+	if err = s.AssetStatusRepository.AddAssetStatus(ctx, &assetStatus); err != nil {
 		log.Error().
 			Str("key", "AddAssetStatus").
 			Str("clientID", clientID).
@@ -69,15 +67,6 @@ func (s assetStatusService) AddAssetStatus(assetStatusRequest *request.AssetStat
 		return nil, err
 	}
 
-	if err = s.AssetAuditLogRepository.AfterCreateAssetStatus(assetStatus); err != nil {
-		log.Error().
-			Str("key", "AfterCreateAssetStatus").
-			Str("clientID", clientID).
-			Err(err).
-			Msg("Failed to log audit after create asset status")
-		return nil, err
-	}
-
 	return response.AssetStatusResponse{
 		AssetStatusID: assetStatus.AssetStatusID,
 		StatusName:    assetStatus.StatusName,
@@ -132,7 +121,7 @@ func (s assetStatusService) GetAssetStatusByID(assetStatusID uint) (interface{},
 	}, nil
 }
 
-func (s assetStatusService) UpdateAssetStatus(assetStatusID uint, assetStatusRequest *request.AssetStatusRequest, clientID string) (interface{}, error) {
+func (s assetStatusService) UpdateAssetStatus(ctx context.Context, assetStatusID uint, assetStatusRequest *request.AssetStatusRequest, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 
 	assetStatus, err := s.AssetStatusRepository.GetAssetStatusByID(assetStatusID)
@@ -143,12 +132,11 @@ func (s assetStatusService) UpdateAssetStatus(assetStatusID uint, assetStatusReq
 			Msg("Failed to get asset status by MaintenanceTypeID")
 		return nil, err
 	}
-	var oldAsset = assetStatus
 	assetStatus.StatusName = assetStatusRequest.StatusName
 	assetStatus.Description = assetStatusRequest.Description
 	assetStatus.UpdatedBy = &data.ClientID
 
-	if err = s.AssetStatusRepository.UpdateAssetStatus(assetStatus); err != nil {
+	if err = s.AssetStatusRepository.UpdateAssetStatus(ctx, assetStatus); err != nil {
 		log.Error().
 			Str("key", "UpdateAssetStatus").
 			Err(err).
@@ -156,15 +144,6 @@ func (s assetStatusService) UpdateAssetStatus(assetStatusID uint, assetStatusReq
 		return nil, err
 	}
 
-	err = s.AssetAuditLogRepository.AfterUpdateAssetStatus(*oldAsset, assetStatus)
-	if err != nil {
-		log.Error().
-			Str("key", "AfterUpdateAssetStatus").
-			Err(err).
-			Msg("Failed to log audit after update asset status")
-		return nil, err
-	}
-
 	return response.AssetStatusResponse{
 		AssetStatusID: assetStatus.AssetStatusID,
 		StatusName:    assetStatus.StatusName,
@@ -172,7 +151,7 @@ func (s assetStatusService) UpdateAssetStatus(assetStatusID uint, assetStatusReq
 	}, nil
 }
 
-func (s assetStatusService) DeleteAssetStatus(assetStatusID uint, clientID string) error {
+func (s assetStatusService) DeleteAssetStatus(ctx context.Context, assetStatusID uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	assetStatus, err := s.AssetStatusRepository.GetAssetStatusByID(assetStatusID)
 	if err != nil {
@@ -185,7 +164,7 @@ func (s assetStatusService) DeleteAssetStatus(assetStatusID uint, clientID strin
 
 	assetStatus.DeletedBy = &data.ClientID
 
-	if err = s.AssetStatusRepository.DeleteAssetStatus(assetStatus); err != nil {
+	if err = s.AssetStatusRepository.DeleteAssetStatus(ctx, assetStatus); err != nil {
 		log.Error().
 			Str("key", "DeleteAssetStatus").
 			Err(err).
@@ -193,12 +172,5 @@ func (s assetStatusService) DeleteAssetStatus(assetStatusID uint, clientID strin
 		return err
 	}
 
-	if err = s.AssetAuditLogRepository.AfterDeleteAssetStatus(assetStatus); err != nil {
-		log.Error().
-			Str("key", "AfterDeleteAssetStatus").
-			Err(err).
-			Msg("Failed to log audit after delete asset status")
-		return err
-	}
 	return nil
 }
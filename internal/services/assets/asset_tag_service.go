@@ -0,0 +1,251 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/text"
+	"context"
+)
+
+type AssetTagService interface {
+	AddAssetTag(ctx context.Context, tagRequest *request.AssetTagRequest, credentialKey string, clientID string) (interface{}, error)
+	UpdateAssetTag(ctx context.Context, tagID uint, tagRequest *request.AssetTagRequest, clientID string, credentialKey string) (interface{}, error)
+	GetListAssetTag(clientID string, size int, index int) (interface{}, int64, error)
+	GetAssetTagByID(tagID uint, clientID string) (interface{}, error)
+	DeleteAssetTag(ctx context.Context, tagID uint, clientID string) error
+	GetAssetTags(assetID uint, clientID string) (interface{}, error)
+	AttachAssetTags(ctx context.Context, assetID uint, tagRequest *request.AssetTagMapRequest, clientID string) (interface{}, error)
+	DetachAssetTag(ctx context.Context, assetID uint, tagID uint, clientID string) error
+}
+
+type assetTagService struct {
+	AssetTagRepository repository.AssetTagRepository
+	AssetRepository    repository.AssetRepository
+	Redis              redis.RedisService
+}
+
+func NewAssetTagService(
+	assetTagRepository repository.AssetTagRepository,
+	assetRepository repository.AssetRepository,
+	redis redis.RedisService) AssetTagService {
+	return &assetTagService{
+		AssetTagRepository: assetTagRepository,
+		AssetRepository:    assetRepository,
+		Redis:              redis,
+	}
+}
+
+func (s *assetTagService) AddAssetTag(ctx context.Context, tagRequest *request.AssetTagRequest, credentialKey string, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID)
+	if err != nil {
+		return logError("CheckCredentialKey", clientID, err, "credential key check failed")
+	}
+
+	existingTag, _ := s.AssetTagRepository.GetAssetTagByNameAndClientID(tagRequest.TagName, data.ClientID)
+	if existingTag != nil {
+		return logError("GetAssetTagByNameAndClientID", clientID, nil, "asset tag already exists")
+	}
+
+	assetTag := &assets.AssetTag{
+		UserClientID: data.ClientID,
+		TagName:      tagRequest.TagName,
+		Description:  tagRequest.Description,
+		CreatedBy:    &data.ClientID,
+		UpdatedBy:    &data.ClientID,
+	}
+
+	if err = s.AssetTagRepository.AddAssetTag(ctx, assetTag); err != nil {
+		return logError("AddAssetTag", clientID, err, "Failed to add asset tag")
+	}
+
+	return toAssetTagResponse(*assetTag), nil
+}
+
+func (s *assetTagService) UpdateAssetTag(ctx context.Context, tagID uint, tagRequest *request.AssetTagRequest, clientID string, credentialKey string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID)
+	if err != nil {
+		return logError("CheckCredentialKey", clientID, err, "credential key check failed")
+	}
+
+	oldAssetTag, err := s.AssetTagRepository.GetAssetTagByID(tagID, data.ClientID)
+	if err != nil {
+		return logError("GetAssetTagByID", clientID, err, "asset tag not found")
+	}
+
+	existingTag, _ := s.AssetTagRepository.GetAssetTagByNameAndClientID(tagRequest.TagName, data.ClientID)
+	if existingTag != nil && existingTag.TagID != tagID {
+		return logError("GetAssetTagByNameAndClientID", clientID, nil, "asset tag already exists")
+	}
+
+	assetTag := *oldAssetTag
+	assetTag.TagName = tagRequest.TagName
+	assetTag.Description = tagRequest.Description
+	assetTag.UpdatedBy = &data.ClientID
+
+	if err = s.AssetTagRepository.UpdateAssetTag(ctx, &assetTag, data.ClientID); err != nil {
+		return logError("UpdateAssetTag", clientID, err, "Failed to update asset tag")
+	}
+
+	return toAssetTagResponse(assetTag), nil
+}
+
+func (s *assetTagService) GetListAssetTag(clientID string, size int, index int) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	total, err := s.AssetTagRepository.GetCountAssetTag(data.ClientID)
+	if err != nil {
+		return logListError("GetCountAssetTag", clientID, err, "Failed to get count asset tags")
+	}
+
+	assetTags, err := s.AssetTagRepository.GetListAssetTag(data.ClientID, size, index)
+	if err != nil {
+		return logListError("GetListAssetTag", clientID, err, "Failed to get list asset tags")
+	}
+
+	var assetTagsResponse []response.AssetTagResponse
+	for _, assetTag := range assetTags {
+		assetTagsResponse = append(assetTagsResponse, toAssetTagResponse(assetTag))
+	}
+
+	return assetTagsResponse, total, nil
+}
+
+func (s *assetTagService) GetAssetTagByID(tagID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	assetTag, err := s.AssetTagRepository.GetAssetTagByID(tagID, data.ClientID)
+	if err != nil {
+		return logError("GetAssetTagByID", clientID, err, "asset tag not found")
+	}
+
+	return toAssetTagResponse(*assetTag), nil
+}
+
+func (s *assetTagService) DeleteAssetTag(ctx context.Context, tagID uint, clientID string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	assetTag, err := s.AssetTagRepository.GetAssetTagByID(tagID, data.ClientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetAssetTagByID", clientID, err, "asset tag not found")
+	}
+
+	assetTag.DeletedBy = &data.ClientID
+	if err = s.AssetTagRepository.DeleteAssetTag(ctx, assetTag); err != nil {
+		return logErrorWithNoReturn("DeleteAssetTag", clientID, err, "Failed to delete asset tag")
+	}
+
+	return nil
+}
+
+func (s *assetTagService) GetAssetTags(assetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, err, "asset not found")
+	}
+
+	assetTags, err := s.AssetTagRepository.GetAssetTagsByAssetID(assetID, data.ClientID)
+	if err != nil {
+		return logError("GetAssetTagsByAssetID", clientID, err, "Failed to get asset tags")
+	}
+
+	assetTagsResponse := make([]response.AssetTagResponse, 0, len(assetTags))
+	for _, assetTag := range assetTags {
+		assetTagsResponse = append(assetTagsResponse, toAssetTagResponse(assetTag))
+	}
+
+	return assetTagsResponse, nil
+}
+
+func (s *assetTagService) AttachAssetTags(ctx context.Context, assetID uint, tagRequest *request.AssetTagMapRequest, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logError("GetAsset", clientID, err, "asset not found")
+	}
+
+	// a tag repeated in the request is attached once
+	tagIDs := make([]uint, 0, len(tagRequest.TagIDs))
+	seen := make(map[uint]bool, len(tagRequest.TagIDs))
+	for _, tagID := range tagRequest.TagIDs {
+		if !seen[tagID] {
+			seen[tagID] = true
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	assetTags, err := s.AssetTagRepository.GetAssetTagsByIDs(tagIDs, data.ClientID)
+	if err != nil {
+		return logError("GetAssetTagsByIDs", clientID, err, "Failed to get asset tags")
+	}
+
+	if len(assetTags) != len(tagIDs) {
+		return logError("GetAssetTagsByIDs", clientID, nil, "one or more asset tags not found")
+	}
+
+	if err = s.AssetTagRepository.AttachAssetTags(ctx, assetID, tagIDs, data.ClientID); err != nil {
+		return logError("AttachAssetTags", clientID, err, "Failed to attach asset tags")
+	}
+
+	return s.GetAssetTags(assetID, clientID)
+}
+
+func (s *assetTagService) DetachAssetTag(ctx context.Context, assetID uint, tagID uint, clientID string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get data from redis")
+	}
+
+	if _, err = s.AssetRepository.GetAsset(assetID, data.ClientID); err != nil {
+		return logErrorWithNoReturn("GetAsset", clientID, err, "asset not found")
+	}
+
+	assetTagMap, err := s.AssetTagRepository.GetAssetTagMap(assetID, tagID)
+	if err != nil {
+		return logErrorWithNoReturn("GetAssetTagMap", clientID, err, "asset tag is not attached to this asset")
+	}
+
+	assetTagMap.DeletedBy = &data.ClientID
+	if err = s.AssetTagRepository.DeleteAssetTagMap(ctx, assetTagMap); err != nil {
+		return logErrorWithNoReturn("DeleteAssetTagMap", clientID, err, "Failed to detach asset tag")
+	}
+
+	return nil
+}
+
+func toAssetTagResponse(assetTag assets.AssetTag) response.AssetTagResponse {
+	return response.AssetTagResponse{
+		TagID:       assetTag.TagID,
+		TagName:     assetTag.TagName,
+		Description: assetTag.Description,
+	}
+}
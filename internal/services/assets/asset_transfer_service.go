@@ -0,0 +1,239 @@
+package assets
+
+import (
+	request "asset-service/internal/dto/in/assets"
+	response "asset-service/internal/dto/out/assets"
+	"asset-service/internal/models/assets"
+	"asset-service/internal/models/user"
+	repository "asset-service/internal/repository/assets"
+	users "asset-service/internal/repository/users"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/text"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssetTransferService interface {
+	InitiateTransfer(ctx context.Context, req *request.AssetTransferRequest, clientID string, credentialKey string) (interface{}, error)
+	AcceptTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error)
+	DeclineTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error)
+	CancelTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error)
+	GetListTransfer(direction, status string, pageIndex, pageSize int, clientID string) (interface{}, int64, error)
+}
+
+type assetTransferService struct {
+	UserRepository          users.UserRepository
+	AssetRepository         repository.AssetRepository
+	AssetGroupRepository    repository.AssetGroupRepository
+	AssetTransferRepository repository.AssetTransferRepository
+	Redis                   redis.RedisService
+}
+
+func NewAssetTransferService(
+	userRepository users.UserRepository,
+	assetRepository repository.AssetRepository,
+	assetGroupRepository repository.AssetGroupRepository,
+	assetTransferRepository repository.AssetTransferRepository,
+	redis redis.RedisService) AssetTransferService {
+	return assetTransferService{
+		UserRepository:          userRepository,
+		AssetRepository:         assetRepository,
+		AssetGroupRepository:    assetGroupRepository,
+		AssetTransferRepository: assetTransferRepository,
+		Redis:                   redis,
+	}
+}
+
+// InitiateTransfer offers an asset owned by the caller to another user or, with to_asset_group_id, to the owner
+// of that asset group. Nothing moves until the recipient accepts.
+func (s assetTransferService) InitiateTransfer(ctx context.Context, req *request.AssetTransferRequest, clientID string, credentialKey string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	if err := text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID); err != nil {
+		return nil, logErrorWithNoReturn("CheckCredentialKey", clientID, err, "credential key check failed")
+	}
+
+	if (req.ToUserID == 0) == (req.ToAssetGroupID == 0) {
+		return logError("InitiateTransfer", clientID, errors.New("exactly one of to_user_id or to_asset_group_id is required"), "Invalid transfer recipient")
+	}
+
+	asset, err := s.AssetRepository.GetAssetByID(data.ClientID, req.AssetID)
+	if err != nil {
+		return logError("GetAssetByID", clientID, errors.New("asset not found"), "Failed to get asset")
+	}
+
+	transfer := &assets.AssetTransfer{
+		AssetID:          asset.AssetID,
+		FromUserClientID: data.ClientID,
+		Status:           utils.TransferStatusPending,
+		Message:          req.Message,
+		CreatedBy:        &data.ClientID,
+		UpdatedBy:        &data.ClientID,
+	}
+
+	if req.FromAssetGroupID != 0 {
+		if _, err := s.AssetRepository.GetAssetByAssetGroupID(asset.AssetID, req.FromAssetGroupID); err != nil {
+			return logError("GetAssetByAssetGroupID", clientID, errors.New("asset is not shared with the source asset group"), "Invalid source asset group")
+		}
+		transfer.FromAssetGroupID = &req.FromAssetGroupID
+	}
+
+	recipientUserID := req.ToUserID
+	if req.ToAssetGroupID != 0 {
+		assetGroup, err := s.AssetGroupRepository.GetAssetGroupByID(req.ToAssetGroupID)
+		if err != nil || assetGroup == nil {
+			return logError("GetAssetGroupByID", clientID, errors.New("asset group not found"), "Failed to get target asset group")
+		}
+		if transfer.FromAssetGroupID != nil && *transfer.FromAssetGroupID == assetGroup.AssetGroupID {
+			return logError("InitiateTransfer", clientID, errors.New("source and target asset group are the same"), "Invalid target asset group")
+		}
+		recipientUserID = assetGroup.OwnerUserID
+		transfer.ToAssetGroupID = &req.ToAssetGroupID
+	}
+
+	recipient, err := s.UserRepository.GetUserByID(recipientUserID)
+	if err != nil || recipient == nil {
+		return logError("GetUserByID", clientID, errors.New("recipient not found"), "Failed to get recipient")
+	}
+	if recipient.ClientID == data.ClientID && transfer.ToAssetGroupID == nil {
+		return logError("InitiateTransfer", clientID, errors.New("cannot transfer an asset to yourself"), "Invalid transfer recipient")
+	}
+	transfer.ToUserClientID = recipient.ClientID
+
+	if err := s.AssetTransferRepository.AddTransfer(ctx, transfer); err != nil {
+		return logError("AddTransfer", clientID, err, "Failed to initiate asset transfer")
+	}
+
+	log.Info().Str("key", "InitiateTransfer").Str("clientID", clientID).Uint("transferID", transfer.TransferID).Uint("assetID", asset.AssetID).Msg("Asset transfer initiated")
+	return toAssetTransferResponse(transfer, asset.Name), nil
+}
+
+// AcceptTransfer moves the asset to the caller, only the recipient of a pending transfer can accept it
+func (s assetTransferService) AcceptTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error) {
+	data, transfer, err := s.pendingTransfer(transferID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserClientID != data.ClientID {
+		return logError("AcceptTransfer", clientID, errors.New("only the recipient can accept this transfer"), "User is not the transfer recipient")
+	}
+
+	recipient, err := s.UserRepository.GetUserByClientID(data.ClientID)
+	if err != nil {
+		return logError("GetUserByClientID", clientID, err, "Failed to get user by client ID")
+	}
+
+	asset, err := s.AssetRepository.GetAssetByID(transfer.FromUserClientID, transfer.AssetID)
+	if err != nil {
+		return logError("GetAssetByID", clientID, errors.New("asset is no longer owned by the sender"), "Failed to get asset")
+	}
+	if transfer.ToUserClientID != transfer.FromUserClientID {
+		if err := checkAssetIdentifiers(s.AssetRepository, data.ClientID, asset.SerialNumber, asset.Barcode, asset.AssetID); err != nil {
+			return logError("CheckAssetIdentifiers", clientID, err, "Asset identifiers conflict with an asset of the recipient")
+		}
+	}
+
+	transfer.UpdatedBy = &data.ClientID
+	asset, err = s.AssetTransferRepository.AcceptTransfer(ctx, transfer, recipient.UserID)
+	if err != nil {
+		return logError("AcceptTransfer", clientID, err, "Failed to accept asset transfer")
+	}
+
+	log.Info().Str("key", "AcceptTransfer").Str("clientID", clientID).Uint("transferID", transfer.TransferID).Uint("assetID", asset.AssetID).Msg("Asset transfer accepted")
+	return toAssetTransferResponse(transfer, asset.Name), nil
+}
+
+// DeclineTransfer rejects a pending transfer, the asset stays with the sender
+func (s assetTransferService) DeclineTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error) {
+	data, transfer, err := s.pendingTransfer(transferID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserClientID != data.ClientID {
+		return logError("DeclineTransfer", clientID, errors.New("only the recipient can decline this transfer"), "User is not the transfer recipient")
+	}
+	return s.closeTransfer(ctx, transfer, utils.TransferStatusDeclined, data.ClientID)
+}
+
+// CancelTransfer withdraws a pending transfer, only the sender can cancel it
+func (s assetTransferService) CancelTransfer(ctx context.Context, transferID uint, clientID string) (interface{}, error) {
+	data, transfer, err := s.pendingTransfer(transferID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserClientID != data.ClientID {
+		return logError("CancelTransfer", clientID, errors.New("only the sender can cancel this transfer"), "User is not the transfer sender")
+	}
+	return s.closeTransfer(ctx, transfer, utils.TransferStatusCancelled, data.ClientID)
+}
+
+func (s assetTransferService) GetListTransfer(direction, status string, pageIndex, pageSize int, clientID string) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	count, err := s.AssetTransferRepository.GetCountTransfers(data.ClientID, direction, status)
+	if err != nil {
+		return logListError("GetCountTransfers", clientID, err, "Failed to get count asset transfers")
+	}
+
+	transfers, err := s.AssetTransferRepository.GetListTransfers(data.ClientID, direction, status, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListTransfers", clientID, err, "Failed to get asset transfers")
+	}
+	return transfers, count, nil
+}
+
+func (s assetTransferService) pendingTransfer(transferID uint, clientID string) (*user.UserRedis, *assets.AssetTransfer, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return nil, nil, logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	transfer, err := s.AssetTransferRepository.GetTransferByID(transferID)
+	if err != nil {
+		return nil, nil, logErrorWithNoReturn("GetTransferByID", clientID, errors.New("transfer not found"), "Failed to get asset transfer")
+	}
+	if transfer.Status != utils.TransferStatusPending {
+		return nil, nil, logErrorWithNoReturn("PendingTransfer", clientID, errors.New("transfer is no longer pending"), "Asset transfer is not pending")
+	}
+	return data, transfer, nil
+}
+
+func (s assetTransferService) closeTransfer(ctx context.Context, transfer *assets.AssetTransfer, status string, clientID string) (interface{}, error) {
+	now := time.Now()
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	transfer.UpdatedBy = &clientID
+
+	if err := s.AssetTransferRepository.CloseTransfer(ctx, transfer); err != nil {
+		return logError("CloseTransfer", clientID, err, "Failed to close asset transfer")
+	}
+
+	log.Info().Str("key", "CloseTransfer").Str("clientID", clientID).Uint("transferID", transfer.TransferID).Str("status", status).Msg("Asset transfer closed")
+	return toAssetTransferResponse(transfer, ""), nil
+}
+
+func toAssetTransferResponse(transfer *assets.AssetTransfer, assetName string) response.AssetTransferResponse {
+	return response.AssetTransferResponse{
+		TransferID:       transfer.TransferID,
+		AssetID:          transfer.AssetID,
+		AssetName:        assetName,
+		FromUserClientID: transfer.FromUserClientID,
+		ToUserClientID:   transfer.ToUserClientID,
+		FromAssetGroupID: transfer.FromAssetGroupID,
+		ToAssetGroupID:   transfer.ToAssetGroupID,
+		Status:           transfer.Status,
+		Message:          transfer.Message,
+		RespondedAt:      transfer.RespondedAt,
+		CreatedAt:        transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,208 @@
+package assets
+
+import (
+	repository "asset-service/internal/repository/assets"
+	"asset-service/internal/utils"
+	nt "asset-service/internal/utils/nats"
+	"asset-service/internal/utils/redis"
+	"asset-service/internal/utils/text"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"path/filepath"
+	"time"
+)
+
+type AssetTrashService interface {
+	GetListDeletedAsset(clientID string, pageIndex, pageSize int) (interface{}, int64, error)
+	RestoreAsset(ctx context.Context, assetID uint, clientID string) (interface{}, error)
+	PurgeAsset(ctx context.Context, assetID uint, clientID, credentialKey string) error
+	PurgeExpiredAssets(ctx context.Context) error
+}
+
+type assetTrashService struct {
+	AssetTrashRepository    repository.AssetTrashRepository
+	AssetRepository         repository.AssetRepository
+	AssetCategoryRepository repository.AssetCategoryRepository
+	AssetStatusRepository   repository.AssetStatusRepository
+	AssetImageRepository    repository.AssetImageRepository
+	Redis                   redis.RedisService
+	NatsService             nt.Service
+	RetentionDays           int
+}
+
+func NewAssetTrashService(
+	assetTrashRepository repository.AssetTrashRepository,
+	assetRepository repository.AssetRepository,
+	assetCategoryRepository repository.AssetCategoryRepository,
+	assetStatusRepository repository.AssetStatusRepository,
+	assetImageRepository repository.AssetImageRepository,
+	redis redis.RedisService,
+	natsService nt.Service,
+	retentionDays int) AssetTrashService {
+	return assetTrashService{
+		AssetTrashRepository:    assetTrashRepository,
+		AssetRepository:         assetRepository,
+		AssetCategoryRepository: assetCategoryRepository,
+		AssetStatusRepository:   assetStatusRepository,
+		AssetImageRepository:    assetImageRepository,
+		Redis:                   redis,
+		NatsService:             natsService,
+		RetentionDays:           retentionDays,
+	}
+}
+
+func (s assetTrashService) GetListDeletedAsset(clientID string, pageIndex, pageSize int) (interface{}, int64, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logListError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	count, err := s.AssetTrashRepository.GetCountDeletedAssets(data.ClientID)
+	if err != nil {
+		return logListError("GetCountDeletedAssets", clientID, err, "Failed to get count deleted assets")
+	}
+
+	result, err := s.AssetTrashRepository.GetListDeletedAssets(data.ClientID, pageIndex, pageSize)
+	if err != nil {
+		return logListError("GetListDeletedAssets", clientID, err, "Failed to get deleted assets")
+	}
+
+	if s.RetentionDays > 0 {
+		for i := range result {
+			purgeAt := result[i].DeletedAt.AddDate(0, 0, s.RetentionDays)
+			result[i].PurgeAt = &purgeAt
+		}
+	}
+	return result, count, nil
+}
+
+// RestoreAsset takes the asset out of the trash. Its category and status must still exist, a parent that is
+// deleted or purged in the meantime is dropped so the asset comes back standalone. The components a cascade
+// delete took along come back attached to it
+func (s assetTrashService) RestoreAsset(ctx context.Context, assetID uint, clientID string) (interface{}, error) {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logError("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	asset, err := s.AssetTrashRepository.GetDeletedAsset(assetID, data.ClientID)
+	if err != nil {
+		return logError("GetDeletedAsset", clientID, errors.New("asset not found in trash"), "Failed to get deleted asset")
+	}
+
+	if err = checkAssetIdentifiers(s.AssetRepository, data.ClientID, asset.SerialNumber, asset.Barcode, assetID); err != nil {
+		return logError("CheckAssetIdentifiers", clientID, err, "Asset identifier already exists")
+	}
+	if _, err = s.AssetCategoryRepository.GetAssetCategoryById(asset.CategoryID, data.ClientID); err != nil {
+		return logError("GetAssetCategoryById", clientID, errors.New("category of the asset no longer exists"), "Failed to restore asset")
+	}
+	if _, err = s.AssetStatusRepository.GetAssetStatusByID(asset.StatusID); err != nil {
+		return logError("GetAssetStatusByID", clientID, errors.New("status of the asset no longer exists"), "Failed to restore asset")
+	}
+	if asset.ParentAssetID != nil {
+		if _, err = s.AssetRepository.GetAsset(*asset.ParentAssetID, data.ClientID); err != nil {
+			asset.ParentAssetID = nil
+		}
+	}
+
+	if err = s.AssetTrashRepository.RestoreAsset(ctx, asset, data.ClientID); err != nil {
+		return logError("RestoreAsset", clientID, err, "Failed to restore asset")
+	}
+
+	result, err := s.AssetRepository.GetAssetResponseByID(data.ClientID, assetID)
+	if err != nil {
+		return logError("GetAssetResponseByID", clientID, err, "Failed to get restored asset")
+	}
+	if images, err := s.AssetImageRepository.GetAssetImageResponseByAssetID(assetID); err == nil {
+		result.Images = *images
+	}
+
+	log.Info().Str("key", "RestoreAsset").Str("clientID", clientID).Uint("assetID", assetID).Msg("Success to restore asset")
+	return *result, nil
+}
+
+// PurgeAsset permanently removes an asset from the trash before its retention ends
+func (s assetTrashService) PurgeAsset(ctx context.Context, assetID uint, clientID, credentialKey string) error {
+	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
+	if err != nil {
+		return logErrorWithNoReturn("GetUserRedis", clientID, err, "Failed to get user redis")
+	}
+
+	err = text.CheckCredentialKey(s.Redis, credentialKey, data.ClientID)
+	if err != nil {
+		log.Error().Str("clientID", clientID).Err(err).Msg("credential key check failed")
+		return err
+	}
+
+	if _, err = s.AssetTrashRepository.GetDeletedAsset(assetID, data.ClientID); err != nil {
+		return logErrorWithNoReturn("GetDeletedAsset", clientID, errors.New("asset not found in trash"), "Failed to get deleted asset")
+	}
+
+	if err = s.purge(ctx, assetID, data.ClientID); err != nil {
+		return logErrorWithNoReturn("PurgeAsset", clientID, err, "Failed to purge asset")
+	}
+	return nil
+}
+
+// PurgeExpiredAssets permanently removes the assets deleted longer ago than the retention period, nothing is
+// purged when the retention is not positive. A failing asset does not stop the others
+func (s assetTrashService) PurgeExpiredAssets(ctx context.Context) error {
+	if s.RetentionDays <= 0 {
+		log.Info().Msg("✅ Trash retention disabled, purge not needed.")
+		return nil
+	}
+
+	expired, err := s.AssetTrashRepository.GetDeletedAssetsBefore(time.Now().AddDate(0, 0, -s.RetentionDays))
+	if err != nil {
+		log.Error().Str("key", "GetDeletedAssetsBefore").Err(err).Msg("Failed to get expired deleted assets")
+		return err
+	}
+
+	if len(expired) == 0 {
+		log.Info().Msg("✅ No expired deleted assets found, purge not needed.")
+		return nil
+	}
+
+	failed := 0
+	for _, asset := range expired {
+		if err = s.purge(ctx, asset.AssetID, asset.UserClientID); err != nil {
+			failed++
+			log.Error().Str("key", "PurgeAsset").Str("client_id", asset.UserClientID).Uint("asset_id", asset.AssetID).Err(err).Msg("❌ Failed to purge deleted asset")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to purge %d of %d deleted assets", failed, len(expired))
+	}
+	log.Info().Int("count", len(expired)).Msg("✅ Trash purge completed successfully.")
+	return nil
+}
+
+// purge removes the rows of the asset on behalf of the actor of ctx, then asks the CDN to delete its files.
+// Files left behind when the request fails are no longer in use and are reclaimed by the unused image cleanup
+func (s assetTrashService) purge(ctx context.Context, assetID uint, clientID string) error {
+	files, err := s.AssetTrashRepository.GetAssetFiles(assetID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.AssetTrashRepository.PurgeAsset(ctx, assetID); err != nil {
+		return err
+	}
+
+	log.Info().Str("client_id", clientID).Uint("asset_id", assetID).Msgf("🗑️ Asset purged, files to be deleted: %d", len(files))
+	if len(files) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	if err = s.NatsService.RequestImageDeletion(clientID, names); err != nil {
+		log.Error().Str("key", "RequestImageDeletion").Str("client_id", clientID).Err(err).Msg("❌ Failed to request file deletion via NATS")
+	}
+	return nil
+}
@@ -8,17 +8,18 @@ import (
 	repouser "asset-service/internal/repository/users"
 	"asset-service/internal/utils"
 	"asset-service/internal/utils/redis"
+	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 )
 
 type AssetWishlistService interface {
-	AddAssetWishlist(assetRequest *request.AssetWishlistRequest, clientID string) (interface{}, error)
+	AddAssetWishlist(ctx context.Context, assetRequest *request.AssetWishlistRequest, clientID string) (interface{}, error)
 	GetListAssetWishlist(clientID string, size, index int) (interface{}, int64, error)
 	GetAssetWishlistByID(id uint, clientID string) (interface{}, error)
-	UpdateAssetWishlist(id uint, req *request.AssetWishlistRequest, clientID string) (interface{}, error)
-	DeleteAssetWishlist(id uint, clientID string) error
-	AddAssetWishlistToAsset(id uint, req *request.AssetRequest, metadata []response.AssetImageResponse, clientID string, headerID string) (interface{}, error)
+	UpdateAssetWishlist(ctx context.Context, id uint, req *request.AssetWishlistRequest, clientID string) (interface{}, error)
+	DeleteAssetWishlist(ctx context.Context, id uint, clientID string) error
+	AddAssetWishlistToAsset(ctx context.Context, id uint, req *request.AssetRequest, metadata []response.AssetImageResponse, clientID string, headerID string) (interface{}, error)
 }
 
 type assetWishlistService struct {
@@ -28,7 +29,6 @@ type assetWishlistService struct {
 	AssetCategoryRepository    repo.AssetCategoryRepository
 	AssetStatusRepository      repo.AssetStatusRepository
 	AssetImageRepository       repo.AssetImageRepository
-	AuditLogRepository         repo.AssetAuditLogRepository
 	AssetGroupMemberRepository repo.AssetGroupMemberRepository
 	AssetGroupAssetRepository  repo.AssetGroupAssetRepository
 	Redis                      redis.RedisService
@@ -41,7 +41,6 @@ func NewAssetWishlistService(
 	AssetStatusRepository repo.AssetStatusRepository,
 	AssetImageRepository repo.AssetImageRepository,
 	assetRepository repo.AssetRepository,
-	AuditLogRepository repo.AssetAuditLogRepository,
 	AssetGroupMemberRepository repo.AssetGroupMemberRepository,
 	AssetGroupAssetRepository repo.AssetGroupAssetRepository,
 	redis redis.RedisService) AssetWishlistService {
@@ -52,14 +51,13 @@ func NewAssetWishlistService(
 		AssetCategoryRepository:    AssetCategoryRepository,
 		AssetStatusRepository:      AssetStatusRepository,
 		AssetImageRepository:       AssetImageRepository,
-		AuditLogRepository:         AuditLogRepository,
 		AssetGroupMemberRepository: AssetGroupMemberRepository,
 		AssetGroupAssetRepository:  AssetGroupAssetRepository,
 		Redis:                      redis,
 	}
 }
 
-func (s assetWishlistService) AddAssetWishlist(assetRequest *request.AssetWishlistRequest, clientID string) (interface{}, error) {
+func (s assetWishlistService) AddAssetWishlist(ctx context.Context, assetRequest *request.AssetWishlistRequest, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -102,7 +100,7 @@ func (s assetWishlistService) AddAssetWishlist(assetRequest *request.AssetWishli
 		UpdatedBy:     &data.ClientID,
 	}
 
-	err = s.AssetWishlistRepository.AddAssetWishlist(&assetWishlist)
+	err = s.AssetWishlistRepository.AddAssetWishlist(ctx, &assetWishlist)
 	if err != nil {
 		log.Error().
 			Str("key", "AddAssetWishlist").
@@ -183,7 +181,7 @@ func (s assetWishlistService) GetAssetWishlistByID(id uint, clientID string) (in
 	return assetWishlist, nil
 }
 
-func (s assetWishlistService) UpdateAssetWishlist(id uint, req *request.AssetWishlistRequest, clientID string) (interface{}, error) {
+func (s assetWishlistService) UpdateAssetWishlist(ctx context.Context, id uint, req *request.AssetWishlistRequest, clientID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -226,7 +224,7 @@ func (s assetWishlistService) UpdateAssetWishlist(id uint, req *request.AssetWis
 		UpdatedBy:     &data.ClientID,
 	}
 
-	err = s.AssetWishlistRepository.UpdateAssetWishlist(&assetWishlist)
+	err = s.AssetWishlistRepository.UpdateAssetWishlist(ctx, &assetWishlist)
 	if err != nil {
 		log.Error().
 			Str("key", "UpdateAssetWishlist").
@@ -239,7 +237,7 @@ func (s assetWishlistService) UpdateAssetWishlist(id uint, req *request.AssetWis
 	return assetWishlist, nil
 }
 
-func (s assetWishlistService) DeleteAssetWishlist(id uint, clientID string) error {
+func (s assetWishlistService) DeleteAssetWishlist(ctx context.Context, id uint, clientID string) error {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -250,7 +248,7 @@ func (s assetWishlistService) DeleteAssetWishlist(id uint, clientID string) erro
 		return err
 	}
 
-	err = s.AssetWishlistRepository.DeleteAssetWishlist(id, data.ClientID)
+	err = s.AssetWishlistRepository.DeleteAssetWishlist(ctx, id, data.ClientID)
 	if err != nil {
 		log.Error().
 			Str("key", "DeleteAssetWishlist").
@@ -263,7 +261,7 @@ func (s assetWishlistService) DeleteAssetWishlist(id uint, clientID string) erro
 	return nil
 }
 
-func (s assetWishlistService) AddAssetWishlistToAsset(id uint, assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID string, headerID string) (interface{}, error) {
+func (s assetWishlistService) AddAssetWishlistToAsset(ctx context.Context, id uint, assetRequest *request.AssetRequest, images []response.AssetImageResponse, clientID string, headerID string) (interface{}, error) {
 	data, err := redis.GetUserRedis(s.Redis, utils.User, clientID)
 	if err != nil {
 		log.Error().
@@ -298,6 +296,11 @@ func (s assetWishlistService) AddAssetWishlistToAsset(id uint, assetRequest *req
 		return logError("AssetNameExists", clientID, errors.New("assets already exists"), "Asset name already exists")
 	}
 
+	assetRequest.ConvertAssetRequestEmptyToNil()
+	if err := checkAssetIdentifiers(s.AssetRepository, clientID, assetRequest.SerialNumber, assetRequest.Barcode, 0); err != nil {
+		return logError("CheckAssetIdentifiers", clientID, err, "Asset identifier already exists")
+	}
+
 	if _, err := s.AssetCategoryRepository.GetAssetCategoryById(uint(assetRequest.CategoryID), clientID); err != nil {
 		return logError("GetAssetCategoryById", clientID, errors.New("category not found"), "Failed to get asset category by MaintenanceTypeID")
 	}
@@ -310,8 +313,6 @@ func (s assetWishlistService) AddAssetWishlistToAsset(id uint, assetRequest *req
 	expiryDate, _ := utils.ParseOptionalDate(assetRequest.ExpiryDate)
 	warrantyExpiry, _ := utils.ParseOptionalDate(assetRequest.WarrantyExpiry)
 
-	assetRequest.ConvertAssetRequestEmptyToNil()
-
 	asset := &assets.Asset{
 		UserClientID:       clientID,
 		SerialNumber:       assetRequest.SerialNumber,
@@ -330,7 +331,7 @@ func (s assetWishlistService) AddAssetWishlistToAsset(id uint, assetRequest *req
 		UpdatedBy:          &data.ClientID,
 	}
 
-	if err := s.AssetRepository.AddAssetFromWishlist(asset, assetWishlist, images); err != nil {
+	if err := s.AssetRepository.AddAssetFromWishlist(ctx, asset, assetWishlist, images); err != nil {
 		return logError("AddAsset", clientID, err, "Failed to add asset")
 	}
 
@@ -344,7 +345,7 @@ func (s assetWishlistService) AddAssetWishlistToAsset(id uint, assetRequest *req
 			CreatedBy:    &data.ClientID,
 		}
 
-		if err := s.AssetGroupAssetRepository.AddAssetGroupAsset(assetGroupAsset); err != nil {
+		if err := s.AssetGroupAssetRepository.AddAssetGroupAsset(ctx, assetGroupAsset); err != nil {
 			return logError("AddAssetGroupAsset", clientID, err, "Failed to add asset group asset")
 		}
 	}
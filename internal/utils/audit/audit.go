@@ -0,0 +1,392 @@
+package audit
+
+import (
+	"asset-service/internal/models/assets"
+	"asset-service/internal/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ActionCreate  = "CREATE"
+	ActionUpdate  = "UPDATE"
+	ActionDelete  = "DELETE"
+	ActionRestore = "RESTORE"
+
+	oldRowsKey = "audit:old_rows"
+)
+
+// Auditable is implemented by the models whose writes are recorded in the audit log, AuditTable returns the
+// table the repositories write the model to
+type Auditable interface {
+	AuditTable() string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the client id of whoever performs the writes made with it. The
+// middleware stores the caller on the request context, the cron jobs the system user, and the repositories write
+// with db.WithContext so every audit entry is credited to the actor of its statement
+func WithActor(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, clientID)
+}
+
+// Actor returns the client id stored by WithActor, empty when there is none
+func Actor(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	clientID, _ := ctx.Value(actorKey{}).(string)
+	return clientID
+}
+
+type target struct {
+	table  string
+	schema *schema.Schema
+}
+
+type auditor struct {
+	byType  map[reflect.Type]*target
+	byTable map[string]*target
+}
+
+// Register installs the create, update and delete callbacks writing an audit row for every row of the given
+// models that is changed through db. The row is written with the connection of the change, so inside its
+// transaction, and a failure to write it fails the change. Every row is chained to the previous one of its
+// tenant, see ChainHash
+func Register(db *gorm.DB, models ...Auditable) error {
+	a := auditor{byType: map[reflect.Type]*target{}, byTable: map[string]*target{}}
+	cache := &sync.Map{}
+	for _, model := range models {
+		modelSchema, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return fmt.Errorf("parse audited model %T: %w", model, err)
+		}
+		t := &target{table: model.AuditTable(), schema: modelSchema}
+		a.byType[modelSchema.ModelType] = t
+		a.byTable[t.table] = t
+	}
+
+	create := db.Callback().Create()
+	if err := create.After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("audit:after_create", a.afterCreate); err != nil {
+		return err
+	}
+
+	update := db.Callback().Update()
+	if err := update.After("gorm:before_update").Before("gorm:update").Register("audit:before_update", a.loadOldRows); err != nil {
+		return err
+	}
+	if err := update.After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("audit:after_update", a.afterUpdate); err != nil {
+		return err
+	}
+
+	remove := db.Callback().Delete()
+	if err := remove.After("gorm:before_delete").Before("gorm:delete").Register("audit:before_delete", a.loadOldRows); err != nil {
+		return err
+	}
+	return remove.After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("audit:after_delete", a.afterDelete)
+}
+
+// resolve finds the audited model of the statement, by its model type or by the table it names
+func (a auditor) resolve(db *gorm.DB) *target {
+	if db.Error != nil {
+		return nil
+	}
+	if db.Statement.Schema != nil {
+		if t, ok := a.byType[db.Statement.Schema.ModelType]; ok {
+			return t
+		}
+	}
+	return a.byTable[db.Statement.Table]
+}
+
+func (a auditor) afterCreate(db *gorm.DB) {
+	t := a.resolve(db)
+	if t == nil || db.RowsAffected == 0 {
+		return
+	}
+
+	var rows []reflect.Value
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	switch value.Kind() {
+	case reflect.Struct:
+		rows = append(rows, value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			rows = append(rows, reflect.Indirect(value.Index(i)))
+		}
+	default:
+		// maps carry no primary key to reload the row with
+		return
+	}
+
+	logs := make([]assets.AssetAuditLog, 0, len(rows))
+	for _, row := range rows {
+		if row.Type() != t.schema.ModelType {
+			return
+		}
+		entry, err := t.entry(db.Statement.Context, ActionCreate, nil, &row)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		logs = append(logs, *entry)
+	}
+	a.write(db, logs)
+}
+
+// loadOldRows keeps the rows the statement is about to change, matched by its conditions and the primary key
+// of the model it was given
+func (a auditor) loadOldRows(db *gorm.DB) {
+	t := a.resolve(db)
+	if t == nil {
+		return
+	}
+
+	var conditions []clause.Expression
+	if where, ok := db.Statement.Clauses["WHERE"].Expression.(clause.Where); ok {
+		conditions = append(conditions, where.Exprs...)
+	}
+	conditions = append(conditions, t.keyConditions(db.Statement.Context, db.Statement.ReflectValue)...)
+	if len(conditions) == 0 {
+		return
+	}
+
+	rows, err := t.find(db, conditions)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(oldRowsKey, rows)
+}
+
+func (a auditor) afterUpdate(db *gorm.DB) {
+	t := a.resolve(db)
+	oldRows := oldRows(db)
+	if t == nil || len(oldRows) == 0 || db.RowsAffected == 0 {
+		return
+	}
+
+	var keys []clause.Expression
+	for i := range oldRows {
+		if key := t.keyConditions(db.Statement.Context, oldRows[i]); len(key) > 0 {
+			keys = append(keys, clause.And(key...))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	newRows, err := t.find(db, []clause.Expression{clause.Or(keys...)})
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	byKey := make(map[string]reflect.Value, len(newRows))
+	for _, row := range newRows {
+		byKey[t.recordID(db.Statement.Context, row)] = row
+	}
+
+	var logs []assets.AssetAuditLog
+	for i := range oldRows {
+		newRow, ok := byKey[t.recordID(db.Statement.Context, oldRows[i])]
+		if !ok {
+			continue
+		}
+
+		action := ActionUpdate
+		if deletedAt := t.schema.LookUpField("deleted_at"); deletedAt != nil {
+			_, wasLive := deletedAt.ValueOf(db.Statement.Context, oldRows[i])
+			_, isLive := deletedAt.ValueOf(db.Statement.Context, newRow)
+			if wasLive && !isLive {
+				action = ActionDelete
+			} else if !wasLive && isLive {
+				action = ActionRestore
+			}
+		}
+
+		entry, err := t.entry(db.Statement.Context, action, &oldRows[i], &newRow)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		if entry.OldData != nil && entry.NewData != nil && *entry.OldData == *entry.NewData {
+			continue
+		}
+		logs = append(logs, *entry)
+	}
+	a.write(db, logs)
+}
+
+func (a auditor) afterDelete(db *gorm.DB) {
+	t := a.resolve(db)
+	oldRows := oldRows(db)
+	if t == nil || len(oldRows) == 0 || db.RowsAffected == 0 {
+		return
+	}
+
+	logs := make([]assets.AssetAuditLog, 0, len(oldRows))
+	for i := range oldRows {
+		entry, err := t.entry(db.Statement.Context, ActionDelete, &oldRows[i], nil)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		logs = append(logs, *entry)
+	}
+	a.write(db, logs)
+}
+
+func (a auditor) write(db *gorm.DB, logs []assets.AssetAuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+	tx := db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true})
+	if err := chain(tx, logs); err != nil {
+		db.AddError(err)
+		return
+	}
+	if err := tx.Table(utils.TableAssetAuditLogName).Create(&logs).Error; err != nil {
+		db.AddError(fmt.Errorf("write audit log: %w", err))
+	}
+}
+
+func oldRows(db *gorm.DB) []reflect.Value {
+	rows, _ := db.InstanceGet(oldRowsKey)
+	values, _ := rows.([]reflect.Value)
+	return values
+}
+
+// find loads the rows matching the conditions, soft deleted ones included, with the connection of the statement
+func (t *target) find(db *gorm.DB, conditions []clause.Expression) ([]reflect.Value, error) {
+	query := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped()
+	switch {
+	case db.Statement.TableExpr != nil:
+		query.Statement.TableExpr = db.Statement.TableExpr
+		query.Statement.Table = db.Statement.Table
+	case db.Statement.Table != "":
+		query = query.Table(db.Statement.Table)
+	default:
+		query = query.Table(t.table)
+	}
+
+	rows := reflect.New(reflect.SliceOf(t.schema.ModelType))
+	if err := query.Clauses(clause.Where{Exprs: conditions}).Find(rows.Interface()).Error; err != nil {
+		return nil, fmt.Errorf("load audited rows of %s: %w", t.table, err)
+	}
+
+	values := make([]reflect.Value, rows.Elem().Len())
+	for i := range values {
+		values[i] = rows.Elem().Index(i)
+	}
+	return values, nil
+}
+
+// keyConditions matches the primary key of a model struct, or of every element of a slice, nothing when unset
+func (t *target) keyConditions(ctx context.Context, value reflect.Value) []clause.Expression {
+	value = reflect.Indirect(value)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		var rows []clause.Expression
+		for i := 0; i < value.Len(); i++ {
+			if keys := t.keyConditions(ctx, value.Index(i)); len(keys) > 0 {
+				rows = append(rows, clause.And(keys...))
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return []clause.Expression{clause.Or(rows...)}
+	}
+	if value.Kind() != reflect.Struct || value.Type() != t.schema.ModelType {
+		return nil
+	}
+
+	var keys []clause.Expression
+	for _, field := range t.schema.PrimaryFields {
+		key, zero := field.ValueOf(ctx, value)
+		if zero {
+			return nil
+		}
+		keys = append(keys, clause.Eq{Column: clause.Column{Name: field.DBName}, Value: key})
+	}
+	return keys
+}
+
+// recordID joins the primary key values of the row, composite keys are separated by commas
+func (t *target) recordID(ctx context.Context, row reflect.Value) string {
+	keys := make([]string, 0, len(t.schema.PrimaryFields))
+	for _, field := range t.schema.PrimaryFields {
+		key, zero := field.ValueOf(ctx, row)
+		if zero {
+			keys = append(keys, "")
+			continue
+		}
+		keys = append(keys, fmt.Sprint(reflect.Indirect(reflect.ValueOf(key)).Interface()))
+	}
+	return strings.Join(keys, ",")
+}
+
+func (t *target) entry(ctx context.Context, action string, oldRow, newRow *reflect.Value) (*assets.AssetAuditLog, error) {
+	// performed_at is stored with microsecond precision, the hash is computed over the stored value
+	entry := assets.AssetAuditLog{
+		TableName:   t.table,
+		Action:      action,
+		PerformedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	row := newRow
+	if row == nil {
+		row = oldRow
+	}
+	recordID := t.recordID(ctx, *row)
+	entry.RecordID = &recordID
+
+	for _, side := range []struct {
+		row  *reflect.Value
+		data **string
+	}{{oldRow, &entry.OldData}, {newRow, &entry.NewData}} {
+		if side.row == nil {
+			continue
+		}
+		value := *side.row
+		if value.CanAddr() {
+			value = value.Addr()
+		}
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("marshal audited row of %s: %w", t.table, err)
+		}
+		text := string(data)
+		*side.data = &text
+	}
+
+	if actor := Actor(ctx); actor != "" {
+		entry.PerformedBy = &actor
+	}
+	entry.TenantID = t.tenant(ctx, *row, entry.PerformedBy)
+	return &entry, nil
+}
+
+// tenant is the client owning the row, or whoever performed the change when the row carries no owner
+func (t *target) tenant(ctx context.Context, row reflect.Value, performedBy *string) *string {
+	if field := t.schema.LookUpField("user_client_id"); field != nil {
+		if value, zero := field.ValueOf(ctx, row); !zero {
+			if text, ok := reflect.Indirect(reflect.ValueOf(value)).Interface().(string); ok && text != "" {
+				return &text
+			}
+		}
+	}
+	if performedBy != nil && *performedBy != "" {
+		return performedBy
+	}
+	system := utils.SystemUser
+	return &system
+}
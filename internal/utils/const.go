@@ -6,6 +6,7 @@ const (
 	CredentialKey = "credential_key"
 	PageIndex     = "page_index"
 	PageSize      = "page_size"
+	SystemUser    = "system"
 )
 
 const (
@@ -15,25 +16,42 @@ const (
 )
 
 const (
-	TableAssetAuditLogName              = "asset_audit_log"
-	TableAssetCategoryName              = "asset_category"
-	TableAssetMaintenanceRecordName     = "asset_maintenance_record"
-	TableAssetMaintenanceName           = "asset_maintenance"
-	TableAssetMaintenanceTypeName       = "asset_maintenance_type"
-	TableAssetName                      = "asset"
-	TableAssetWishlistName              = "asset_wishlist"
-	TableAssetStatusName                = "asset_status"
-	TableAssetImageName                 = "asset_image"
-	TableAssetStockName                 = "asset_stock"
-	TableAssetStockHistoryName          = "asset_stock_history"
-	TableAssetGroupName                 = "asset_group"
-	TableAssetGroupPermissionName       = "asset_group_permission"
-	TableAssetGroupMemberName           = "asset_group_member"
-	TableAssetGroupMemberPermissionName = "asset_group_member_permission"
-	TableAssetGroupAssetName            = "asset_group_asset"
-	TableAssetGroupInvitationName       = "asset_group_invitation"
+	TableAssetAuditLogName                = "asset_audit_log"
+	TableAssetAuditCheckpointName         = "asset_audit_checkpoint"
+	TableAssetCategoryName                = "asset_category"
+	TableAssetMaintenanceRecordName       = "asset_maintenance_record"
+	TableAssetMaintenanceName             = "asset_maintenance"
+	TableAssetMaintenanceTypeName         = "asset_maintenance_type"
+	TableAssetMaintenanceAttachmentName   = "asset_maintenance_attachment"
+	TableAssetName                        = "asset"
+	TableAssetWishlistName                = "asset_wishlist"
+	TableAssetStatusName                  = "asset_status"
+	TableAssetImageName                   = "asset_image"
+	TableAssetStockName                   = "asset_stock"
+	TableAssetStockHistoryName            = "asset_stock_history"
+	TableAssetGroupName                   = "asset_group"
+	TableAssetGroupPermissionName         = "asset_group_permission"
+	TableAssetGroupMemberName             = "asset_group_member"
+	TableAssetGroupMemberPermissionName   = "asset_group_member_permission"
+	TableAssetGroupAssetName              = "asset_group_asset"
+	TableAssetGroupInvitationName         = "asset_group_invitation"
+	TableAssetTagName                     = "asset_tags"
+	TableAssetTagMapName                  = "asset_tag_map"
+	TableAssetMaintenanceNotificationName = "asset_maintenance_notification"
+	TableAssetExpiryAlertName             = "asset_expiry_alert"
+	TableAssetGroupAssetLoanName          = "asset_group_asset_loan"
+	TableAssetTransferName                = "asset_transfer"
+	TableAssetLocationName                = "asset_location"
+	TableAssetLocationHistoryName         = "asset_location_history"
 
 	TableUserSettingName = "user_settings"
+	TableCronJobName     = "cron_jobs"
+	TableCronJobRunName  = "cron_job_runs"
+)
+
+const (
+	AssetGroupPermissionAdmin = "Admin"
+	AssetGroupPermissionLend  = "Lend"
 )
 
 const (
@@ -46,4 +64,60 @@ const (
 const (
 	NatsAssetImageDelete = "asset.image.delete"
 	NatsAssetImageUsage  = "asset.image.usage"
+	NatsMaintenanceDue   = "asset.maintenance.due"
+	NatsAssetExpiring    = "asset.expiry.due"
+)
+
+const (
+	MaintenanceDueOverdue  = "overdue"
+	MaintenanceDueUpcoming = "upcoming"
+)
+
+const (
+	// DashboardDefaultDays is the look-ahead window of the dashboard when days is not given
+	DashboardDefaultDays = 30
+	DashboardMaxDays     = 365
+	// DashboardListLimit caps the upcoming maintenance and expiring warranty lists, the counts cover everything
+	DashboardListLimit = 10
+)
+
+const (
+	LoanStatusOpen     = "open"
+	LoanStatusReturned = "returned"
+	LoanStatusOverdue  = "overdue"
 )
+
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+
+	TransferDirectionIncoming = "incoming"
+	TransferDirectionOutgoing = "outgoing"
+)
+
+// Location types from the outermost to the innermost level, a child location must be of a deeper level than its parent
+const (
+	LocationTypeSite     = "site"
+	LocationTypeBuilding = "building"
+	LocationTypeRoom     = "room"
+	LocationTypeShelf    = "shelf"
+)
+
+var LocationTypeLevels = map[string]int{
+	LocationTypeSite:     1,
+	LocationTypeBuilding: 2,
+	LocationTypeRoom:     3,
+	LocationTypeShelf:    4,
+}
+
+// What happens to the components of an asset when the asset is deleted
+const (
+	ComponentDeleteDetach   = "detach"
+	ComponentDeleteCascade  = "cascade"
+	ComponentDeleteRestrict = "restrict"
+)
+
+// MaxComponentDepth bounds how deep assets can be nested into each other
+const MaxComponentDepth = 5
@@ -1,12 +1,25 @@
 package controller
 
 import (
+	"asset-service/internal/utils"
 	"asset-service/internal/utils/cron/model"
 	"asset-service/internal/utils/cron/service"
+	"asset-service/internal/utils/jwt"
+	"asset-service/package/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
 type CronJobController interface {
 	AddCronJob(cronJob model.CronJob)
+	GetCronJobs(context *gin.Context)
+	GetCronJob(context *gin.Context)
+	CreateCronJob(context *gin.Context)
+	UpdateCronJob(context *gin.Context)
+	EnableCronJob(context *gin.Context)
+	DisableCronJob(context *gin.Context)
+	TriggerCronJob(context *gin.Context)
+	GetCronJobRuns(context *gin.Context)
 }
 
 type cronJobController struct {
@@ -20,3 +33,151 @@ func NewCronJobController(cronJobService service.CronService) CronJobController
 func (h cronJobController) AddCronJob(cronJob model.CronJob) {
 	h.cronJobService.AddCronJob(cronJob)
 }
+
+func (h cronJobController) GetCronJobs(context *gin.Context) {
+	cronJobs, err := h.cronJobService.GetCronJobs()
+	if err != nil {
+		response.SendResponse(context, http.StatusInternalServerError, "Failed to get cron jobs", nil, err.Error())
+		return
+	}
+
+	response.SendResponse(context, http.StatusOK, "List of cron jobs", gin.H{
+		"jobs":           cronJobs,
+		"supported_jobs": h.cronJobService.GetSupportedJobs(),
+	}, nil)
+}
+
+func (h cronJobController) GetCronJob(context *gin.Context) {
+	id, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Cron job ID must be a number", nil, err.Error())
+		return
+	}
+
+	cronJob, err := h.cronJobService.GetCronJob(id)
+	if err != nil {
+		response.SendResponse(context, http.StatusNotFound, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Cron job retrieved successfully", cronJob, nil)
+}
+
+func (h cronJobController) CreateCronJob(context *gin.Context) {
+	var req model.CronJobRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	cronJob, err := h.cronJobService.CreateCronJob(req, actor(context))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Cron job created successfully", cronJob, nil)
+}
+
+func (h cronJobController) UpdateCronJob(context *gin.Context) {
+	var req model.UpdateCronJobRequest
+	id, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Cron job ID must be a number", nil, err.Error())
+		return
+	}
+
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Invalid request", nil, err.Error())
+		return
+	}
+
+	cronJob, err := h.cronJobService.UpdateCronJob(id, req, actor(context))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusOK, "Cron job updated successfully", cronJob, nil)
+}
+
+func (h cronJobController) EnableCronJob(context *gin.Context) {
+	h.setActive(context, true)
+}
+
+func (h cronJobController) DisableCronJob(context *gin.Context) {
+	h.setActive(context, false)
+}
+
+func (h cronJobController) setActive(context *gin.Context, active bool) {
+	id, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Cron job ID must be a number", nil, err.Error())
+		return
+	}
+
+	cronJob, err := h.cronJobService.SetCronJobActive(id, active, actor(context))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+
+	message := "Cron job disabled successfully"
+	if active {
+		message = "Cron job enabled successfully"
+	}
+	response.SendResponse(context, http.StatusOK, message, cronJob, nil)
+}
+
+func (h cronJobController) TriggerCronJob(context *gin.Context) {
+	id, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Cron job ID must be a number", nil, err.Error())
+		return
+	}
+
+	run, err := h.cronJobService.TriggerCronJob(id, actor(context))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Error", nil, err.Error())
+		return
+	}
+	response.SendResponse(context, http.StatusAccepted, "Cron job triggered", run, nil)
+}
+
+func (h cronJobController) GetCronJobRuns(context *gin.Context) {
+	id, err := utils.ConvertToUint(context.Param("id"))
+	if err != nil {
+		response.SendResponse(context, http.StatusBadRequest, "Cron job ID must be a number", nil, err.Error())
+		return
+	}
+
+	pageIndex, pageSize, err := utils.GetPageIndexPageSize(context)
+	if err != nil {
+		response.SendResponse(context, 400, "Invalid page index or page size", nil, err.Error())
+		return
+	}
+
+	runs, total, err := h.cronJobService.GetCronJobRuns(id, pageIndex, pageSize)
+	if err != nil {
+		response.SendResponseList(context, 500, "Failed to get cron job runs", response.PagedData{
+			Total:     total,
+			PageIndex: pageIndex,
+			PageSize:  pageSize,
+			Items:     nil,
+		}, err.Error())
+		return
+	}
+
+	response.SendResponseList(context, 200, "List of cron job runs", response.PagedData{
+		Total:     total,
+		PageIndex: pageIndex,
+		PageSize:  pageSize,
+		Items:     runs,
+	}, nil)
+}
+
+// actor returns the admin client ID recorded as created_by / updated_by
+func actor(context *gin.Context) string {
+	token, exist := jwt.ExtractTokenClaims(context)
+	if !exist {
+		return utils.SystemUser
+	}
+	return token.ClientID
+}
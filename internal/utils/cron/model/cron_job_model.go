@@ -2,15 +2,47 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type CronJob struct {
-	ID             uint      `gorm:"primaryKey" json:"id"`
-	Name           string    `gorm:"type:varchar(100);not null" json:"name"`
-	Schedule       string    `gorm:"type:varchar(100);not null" json:"schedule"`
-	IsActive       bool      `gorm:"not null" json:"is_active"`
-	Description    string    `gorm:"type:text" json:"description"`
-	LastExecutedAt time.Time `gorm:"type:datetime" json:"last_executed_at"`
-	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	Name           string          `gorm:"type:varchar(100);not null" json:"name"`
+	Schedule       string          `gorm:"type:varchar(100);not null" json:"schedule"`
+	IsActive       bool            `gorm:"not null" json:"is_active"`
+	Description    string          `gorm:"type:text" json:"description"`
+	LastExecutedAt *time.Time      `gorm:"type:timestamp" json:"last_executed_at"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy      *string         `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	UpdatedBy      *string         `gorm:"type:varchar(255)" json:"updated_by,omitempty"`
+	DeletedAt      *gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// CronJobRun is one execution of a cron job, scheduled or triggered manually
+type CronJobRun struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	CronJobID  uint       `gorm:"not null" json:"cron_job_id"`
+	JobName    string     `gorm:"type:varchar(255);not null" json:"job_name"`
+	Trigger    string     `gorm:"column:trigger_type;type:varchar(20);not null" json:"trigger"`
+	Status     string     `gorm:"type:varchar(20);not null" json:"status"`
+	StartedAt  time.Time  `gorm:"not null" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs *int64     `json:"duration_ms,omitempty"`
+	Error      *string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedBy  *string    `gorm:"type:varchar(255)" json:"created_by,omitempty"`
+
+	LockKey      *string `gorm:"type:varchar(255)" json:"lock_key,omitempty"`
+	LockOwner    *string `gorm:"type:varchar(255)" json:"lock_owner,omitempty"`
+	LockRenewals int     `gorm:"default:0" json:"lock_renewals"`
 }
+
+const (
+	RunTriggerSchedule = "schedule"
+	RunTriggerManual   = "manual"
+
+	RunStatusRunning = "running"
+	RunStatusSuccess = "success"
+	RunStatusFailed  = "failed"
+)
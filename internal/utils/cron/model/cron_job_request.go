@@ -0,0 +1,14 @@
+package model
+
+type CronJobRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Schedule    string `json:"schedule" binding:"required"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type UpdateCronJobRequest struct {
+	Schedule    *string `json:"schedule"`
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"is_active"`
+}
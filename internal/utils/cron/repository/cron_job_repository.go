@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"asset-service/internal/utils"
 	"asset-service/internal/utils/cron/model"
 	"gorm.io/gorm"
+	"time"
 )
 
 type CronRepository interface {
@@ -14,6 +16,11 @@ type CronRepository interface {
 	GetCronJobByJobName(jobName string) (model.CronJob, error)
 	deleteCronJobByID(id uint) error
 	Create(m *model.CronJob) interface{}
+	UpdateLastExecutedAt(id uint, executedAt time.Time) error
+	CreateCronJobRun(run *model.CronJobRun) error
+	UpdateCronJobRun(run *model.CronJobRun) error
+	GetCountCronJobRuns(cronJobID uint) (int64, error)
+	GetListCronJobRuns(cronJobID uint, pageIndex, pageSize int) ([]model.CronJobRun, error)
 }
 type cronRepository struct {
 	db gorm.DB
@@ -25,7 +32,7 @@ func NewCronRepository(db gorm.DB) CronRepository {
 
 func (r cronRepository) GetCronJobs() ([]model.CronJob, error) {
 	var cronJobs []model.CronJob
-	err := r.db.Find(&cronJobs).Error
+	err := r.db.Table(utils.TableCronJobName).Order("id ASC").Find(&cronJobs).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +41,7 @@ func (r cronRepository) GetCronJobs() ([]model.CronJob, error) {
 
 func (r cronRepository) GetCronJobByID(id uint) (model.CronJob, error) {
 	var cronJob model.CronJob
-	err := r.db.Where("id = ?", id).First(&cronJob).Error
+	err := r.db.Table(utils.TableCronJobName).Where("id = ?", id).First(&cronJob).Error
 	if err != nil {
 		return model.CronJob{}, err
 	}
@@ -42,7 +49,7 @@ func (r cronRepository) GetCronJobByID(id uint) (model.CronJob, error) {
 }
 
 func (r cronRepository) CreateCronJob(cronJob *model.CronJob) error {
-	err := r.db.Create(&cronJob).Error
+	err := r.db.Table(utils.TableCronJobName).Create(cronJob).Error
 	if err != nil {
 		return err
 	}
@@ -50,7 +57,7 @@ func (r cronRepository) CreateCronJob(cronJob *model.CronJob) error {
 }
 
 func (r cronRepository) UpdateCronJob(cronJob *model.CronJob) error {
-	err := r.db.Table("cron_job").Save(&cronJob).Error
+	err := r.db.Table(utils.TableCronJobName).Save(cronJob).Error
 	if err != nil {
 		return err
 	}
@@ -58,7 +65,7 @@ func (r cronRepository) UpdateCronJob(cronJob *model.CronJob) error {
 }
 
 func (r cronRepository) DeleteCronJob(id uint) error {
-	err := r.db.Table("cron_job").Delete(&model.CronJob{}, id).Error
+	err := r.db.Table(utils.TableCronJobName).Delete(&model.CronJob{}, id).Error
 	if err != nil {
 		return err
 	}
@@ -67,7 +74,7 @@ func (r cronRepository) DeleteCronJob(id uint) error {
 
 func (r cronRepository) GetCronJobByJobName(jobName string) (model.CronJob, error) {
 	var cronJob model.CronJob
-	err := r.db.Table("cron_job").Where("job_name = ?", jobName).First(&cronJob).Error
+	err := r.db.Table(utils.TableCronJobName).Where("name = ?", jobName).First(&cronJob).Error
 	if err != nil {
 		return model.CronJob{}, err
 	}
@@ -75,7 +82,7 @@ func (r cronRepository) GetCronJobByJobName(jobName string) (model.CronJob, erro
 }
 
 func (r cronRepository) deleteCronJobByID(id uint) error {
-	err := r.db.Table("cron_job").Delete(&model.CronJob{}, id).Error
+	err := r.db.Table(utils.TableCronJobName).Delete(&model.CronJob{}, id).Error
 	if err != nil {
 		return err
 	}
@@ -83,9 +90,44 @@ func (r cronRepository) deleteCronJobByID(id uint) error {
 }
 
 func (r cronRepository) Create(m *model.CronJob) interface{} {
-	err := r.db.Create(m).Error
+	err := r.db.Table(utils.TableCronJobName).Create(m).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func (r cronRepository) UpdateLastExecutedAt(id uint, executedAt time.Time) error {
+	return r.db.Table(utils.TableCronJobName).Where("id = ?", id).UpdateColumn("last_executed_at", executedAt).Error
+}
+
+func (r cronRepository) CreateCronJobRun(run *model.CronJobRun) error {
+	return r.db.Table(utils.TableCronJobRunName).Create(run).Error
+}
+
+func (r cronRepository) UpdateCronJobRun(run *model.CronJobRun) error {
+	return r.db.Table(utils.TableCronJobRunName).Save(run).Error
+}
+
+func (r cronRepository) GetCountCronJobRuns(cronJobID uint) (int64, error) {
+	var count int64
+	err := r.db.Table(utils.TableCronJobRunName).Where("cron_job_id = ?", cronJobID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r cronRepository) GetListCronJobRuns(cronJobID uint, pageIndex, pageSize int) ([]model.CronJobRun, error) {
+	var runs []model.CronJobRun
+	err := r.db.Table(utils.TableCronJobRunName).
+		Where("cron_job_id = ?", cronJobID).
+		Order("started_at DESC").
+		Limit(pageSize).
+		Offset((pageIndex - 1) * pageSize).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
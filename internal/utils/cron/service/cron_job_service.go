@@ -2,9 +2,17 @@ package service
 
 import (
 	"asset-service/internal/services/assets"
+	"asset-service/internal/utils"
+	"asset-service/internal/utils/audit"
 	"asset-service/internal/utils/cron/model"
 	"asset-service/internal/utils/cron/repository"
+	"asset-service/internal/utils/redis"
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -16,8 +24,27 @@ type CronService interface {
 	Start()
 	Stop()
 	AddCronJob(job model.CronJob)
+	GetCronJobs() ([]model.CronJob, error)
+	GetCronJob(id uint) (*model.CronJob, error)
+	CreateCronJob(req model.CronJobRequest, createdBy string) (*model.CronJob, error)
+	UpdateCronJob(id uint, req model.UpdateCronJobRequest, updatedBy string) (*model.CronJob, error)
+	SetCronJobActive(id uint, active bool, updatedBy string) (*model.CronJob, error)
+	TriggerCronJob(id uint, triggeredBy string) (*model.CronJobRun, error)
+	GetCronJobRuns(id uint, pageIndex, pageSize int) ([]model.CronJobRun, int64, error)
+	GetSupportedJobs() []string
 }
 
+const (
+	// lockLease is how long a job lock lives without renewal, a crashed replica frees it after this
+	lockLease = 30 * time.Second
+	// lockRenewInterval keeps the lease alive well before it expires
+	lockRenewInterval = 10 * time.Second
+	// tickGuardTTL keeps a scheduled tick claimed long enough for every replica to see it, even with clock skew
+	tickGuardTTL = 2 * time.Minute
+	// syncSchedule is how often every replica reloads the jobs, picking up changes made through another replica
+	syncSchedule = "@every 1m"
+)
+
 // cronService implements CronService
 type cronService struct {
 	db                      gorm.DB
@@ -25,27 +52,49 @@ type cronService struct {
 	scheduler               *cron.Cron
 	mu                      sync.Mutex
 	jobs                    map[uint]cron.EntryID
+	schedules               map[uint]string
 	cronRepository          repository.CronRepository
 	assetMaintenanceService assets.AssetMaintenanceService
 	assetImageService       assets.AssetImageService
+	attachmentService       assets.AssetMaintenanceAttachmentService
+	assetExpiryService      assets.AssetExpiryService
+	auditLogService         assets.AssetAuditLogService
+	assetTrashService       assets.AssetTrashService
+	redis                   redis.RedisService
+	instanceID              string
 }
 
-// NewCronService initializes and returns a CronService instance
-func NewCronService(db gorm.DB, cronRepository repository.CronRepository, assetMaintenanceService assets.AssetMaintenanceService, image assets.AssetImageService) CronService {
+// NewCronService initializes and returns a CronService instance, instanceID identifies this replica
+// in lock ownership and defaults to hostname-pid
+func NewCronService(db gorm.DB, cronRepository repository.CronRepository, assetMaintenanceService assets.AssetMaintenanceService, image assets.AssetImageService, attachment assets.AssetMaintenanceAttachmentService, expiry assets.AssetExpiryService, auditLog assets.AssetAuditLogService, trash assets.AssetTrashService, redis redis.RedisService, instanceID string) CronService {
+	if instanceID == "" {
+		hostname, _ := os.Hostname()
+		instanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
 	return &cronService{
 		db:                      db,
 		scheduler:               cron.New(), // Enables second-level precision
 		jobs:                    make(map[uint]cron.EntryID),
+		schedules:               make(map[uint]string),
 		mu:                      sync.Mutex{},
 		cronRepository:          cronRepository,
 		assetMaintenanceService: assetMaintenanceService,
 		assetImageService:       image,
+		attachmentService:       attachment,
+		assetExpiryService:      expiry,
+		auditLogService:         auditLog,
+		assetTrashService:       trash,
+		redis:                   redis,
+		instanceID:              instanceID,
 	}
 }
 
 func (cs *cronService) Start() {
 	cs.scheduler.Start()
 	cs.loadJobsFromDB()
+	if _, err := cs.scheduler.AddFunc(syncSchedule, cs.syncJobs); err != nil {
+		log.Println("Error scheduling cron job sync:", err)
+	}
 }
 
 func (cs *cronService) Stop() {
@@ -66,16 +115,54 @@ func (cs *cronService) loadJobsFromDB() {
 	}
 }
 
+// syncJobs reschedules the jobs whose schedule or state was changed through another replica
+func (cs *cronService) syncJobs() {
+	cronJobs, err := cs.cronRepository.GetCronJobs()
+	if err != nil {
+		log.Println("Error syncing cron jobs from DB:", err)
+		return
+	}
+
+	stored := make(map[uint]bool, len(cronJobs))
+	for _, job := range cronJobs {
+		stored[job.ID] = true
+		cs.mu.Lock()
+		schedule, scheduled := cs.schedules[job.ID]
+		cs.mu.Unlock()
+		if scheduled != job.IsActive || (scheduled && schedule != job.Schedule) {
+			cs.scheduleJob(job)
+		}
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for jobID, entryID := range cs.jobs {
+		if !stored[jobID] {
+			cs.scheduler.Remove(entryID)
+			delete(cs.jobs, jobID)
+			delete(cs.schedules, jobID)
+		}
+	}
+}
+
+// scheduleJob (re)registers the job with the scheduler, inactive jobs are only removed
 func (cs *cronService) scheduleJob(job model.CronJob) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
 	if entryID, exists := cs.jobs[job.ID]; exists {
 		cs.scheduler.Remove(entryID)
+		delete(cs.jobs, job.ID)
+		delete(cs.schedules, job.ID)
+	}
+
+	if !job.IsActive {
+		return
 	}
 
-	entryID, err := cs.scheduler.AddFunc(job.Schedule, func() {
-		cs.executeJob(job)
+	jobID, schedule := job.ID, job.Schedule
+	entryID, err := cs.scheduler.AddFunc(schedule, func() {
+		cs.executeJob(jobID, schedule, time.Now().Truncate(time.Minute))
 	})
 	if err != nil {
 		log.Println("Error scheduling job:", err)
@@ -83,50 +170,179 @@ func (cs *cronService) scheduleJob(job model.CronJob) {
 	}
 
 	cs.jobs[job.ID] = entryID
+	cs.schedules[job.ID] = schedule
 }
 
-func (cs *cronService) executeJob(job model.CronJob) {
-	now := time.Now()
-
-	// Check for missed executions
-	if !job.LastExecutedAt.IsZero() {
-		expectedNextRun := job.LastExecutedAt.Add(cs.getJobInterval(job.Schedule))
-		if now.After(expectedNextRun) {
-			log.Printf("Job %s missed its scheduled run. Executing catch-up.\n", job.Name)
-			// Handle missed execution as needed
+// executeJob runs a scheduled tick of the given schedule. Every replica fires the same tick, the tick guard
+// lets only the first one through and the job lock keeps it from overlapping a run that is still in progress.
+// The job is reloaded so schedule changes and deactivation made through any replica are respected, a tick of
+// an outdated schedule only runs when the current schedule is due at the same minute.
+func (cs *cronService) executeJob(jobID uint, schedule string, tick time.Time) {
+	job, err := cs.cronRepository.GetCronJobByID(jobID)
+	if err != nil {
+		log.Println("Error loading cron job before execution:", err)
+		return
+	}
+	if !job.IsActive {
+		cs.scheduleJob(job)
+		return
+	}
+	if job.Schedule != schedule {
+		cs.scheduleJob(job)
+		if !isDue(job.Schedule, tick) {
+			return
 		}
 	}
 
-	// Update the last executed time
-	job.LastExecutedAt = now
-	if err := cs.db.Save(&job).Error; err != nil {
+	tickKey := fmt.Sprintf("cron:tick:%s:%s", job.Name, tick.UTC().Format("200601021504"))
+	claimed, err := cs.redis.AcquireLock(tickKey, cs.instanceID, tickGuardTTL)
+	if err != nil {
+		log.Printf("Error claiming tick for job %s, skipping: %v\n", job.Name, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	lockKey, acquired, err := cs.acquireJobLock(job)
+	if err != nil || !acquired {
+		log.Printf("Job %s is still running elsewhere or the lock failed, skipping tick: %v\n", job.Name, err)
+		return
+	}
+
+	run, err := cs.startRun(job, model.RunTriggerSchedule, utils.SystemUser, lockKey)
+	if err != nil {
+		log.Println("Error recording cron job run:", err)
+		cs.releaseJobLock(lockKey)
+		return
+	}
+	cs.runJob(job, run)
+}
+
+func (cs *cronService) acquireJobLock(job model.CronJob) (string, bool, error) {
+	lockKey := "cron:lock:" + job.Name
+	acquired, err := cs.redis.AcquireLock(lockKey, cs.instanceID, lockLease)
+	return lockKey, acquired, err
+}
+
+func (cs *cronService) releaseJobLock(lockKey string) {
+	if err := cs.redis.ReleaseLock(lockKey, cs.instanceID); err != nil {
+		log.Println("Error releasing cron job lock:", err)
+	}
+}
+
+func (cs *cronService) startRun(job model.CronJob, trigger, triggeredBy, lockKey string) (*model.CronJobRun, error) {
+	run := &model.CronJobRun{
+		CronJobID: job.ID,
+		JobName:   job.Name,
+		Trigger:   trigger,
+		Status:    model.RunStatusRunning,
+		StartedAt: time.Now(),
+		CreatedBy: &triggeredBy,
+		LockKey:   &lockKey,
+		LockOwner: &cs.instanceID,
+	}
+	if err := cs.cronRepository.CreateCronJobRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// runJob performs the task while renewing the job lock, then records the outcome and releases the lock
+func (cs *cronService) runJob(job model.CronJob, run *model.CronJobRun) {
+	defer cs.releaseJobLock(*run.LockKey)
+
+	if err := cs.cronRepository.UpdateLastExecutedAt(job.ID, run.StartedAt); err != nil {
 		log.Println("Error updating job last executed time:", err)
 	}
 
-	// Perform the actual job task
-	switch job.Name {
-	case "asset_maintenance":
-		err := cs.assetMaintenanceService.PerformMaintenanceCheck()
-		if err != nil {
-			log.Println("Error performing asset maintenance check:", err)
-		}
-	case "asset_image_cleanup":
-		err := cs.assetImageService.Cleanup()
-		if err != nil {
-			log.Println("Error performing image cleanup:", err)
-		}
-	case "image_cleanup_unused":
-		err := cs.assetImageService.CleanupUnusedImages()
-		if err != nil {
-			log.Println("Error performing image cleanup:", err)
+	done := make(chan struct{})
+	renewals := make(chan int, 1)
+	go cs.renewJobLock(*run.LockKey, done, renewals)
+
+	err := cs.performTask(job.Name)
+	close(done)
+	run.LockRenewals = <-renewals
+
+	finishedAt := time.Now()
+	duration := finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = &duration
+	run.Status = model.RunStatusSuccess
+	if err != nil {
+		message := err.Error()
+		run.Status = model.RunStatusFailed
+		run.Error = &message
+		log.Printf("Error performing job %s: %v\n", job.Name, err)
+	}
+
+	if err := cs.cronRepository.UpdateCronJobRun(run); err != nil {
+		log.Println("Error updating cron job run:", err)
+	}
+}
+
+// renewJobLock extends the lease until done is closed and reports how many renewals succeeded
+func (cs *cronService) renewJobLock(lockKey string, done <-chan struct{}, renewals chan<- int) {
+	ticker := time.NewTicker(lockRenewInterval)
+	defer ticker.Stop()
+
+	count := 0
+	for {
+		select {
+		case <-done:
+			renewals <- count
+			return
+		case <-ticker.C:
+			renewed, err := cs.redis.RenewLock(lockKey, cs.instanceID, lockLease)
+			if err != nil || !renewed {
+				log.Printf("Lost cron job lock %s: %v\n", lockKey, err)
+				continue
+			}
+			count++
 		}
-	default:
-		log.Printf("Unknown job: %s\n", job.Name)
 	}
 }
 
-func (cs *cronService) getJobInterval(schedule string) time.Duration {
-	return time.Minute // Assuming a default interval of 1 minute
+// tasks maps every supported job name to the work it performs, the writes made with ctx are credited to the
+// system user in the audit log
+func (cs *cronService) tasks() map[string]func(ctx context.Context) error {
+	return map[string]func(ctx context.Context) error{
+		"asset_maintenance": func(ctx context.Context) error {
+			return cs.assetMaintenanceService.PerformMaintenanceCheck()
+		},
+		"asset_trash_purge": func(ctx context.Context) error {
+			return cs.assetTrashService.PurgeExpiredAssets(ctx)
+		},
+		"maintenance_attachment_cleanup": func(ctx context.Context) error {
+			return cs.attachmentService.Cleanup(ctx)
+		},
+		"image_cleanup_unused": func(ctx context.Context) error {
+			return cs.assetImageService.CleanupUnusedImages()
+		},
+		"asset_expiry_alert": func(ctx context.Context) error {
+			return cs.assetExpiryService.PerformExpiryCheck()
+		},
+		"audit_checkpoint": func(ctx context.Context) error {
+			return cs.auditLogService.CreateAuditCheckpoints()
+		},
+	}
+}
+
+func (cs *cronService) performTask(name string) error {
+	task, ok := cs.tasks()[name]
+	if !ok {
+		return fmt.Errorf("unknown job: %s", name)
+	}
+	return task(audit.WithActor(context.Background(), utils.SystemUser))
+}
+
+func (cs *cronService) GetSupportedJobs() []string {
+	var names []string
+	for name := range cs.tasks() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func (cs *cronService) AddCronJob(job model.CronJob) {
@@ -134,4 +350,139 @@ func (cs *cronService) AddCronJob(job model.CronJob) {
 		log.Println("Error creating cron job:", err)
 		return
 	}
+	cs.scheduleJob(job)
+}
+
+func (cs *cronService) GetCronJobs() ([]model.CronJob, error) {
+	return cs.cronRepository.GetCronJobs()
+}
+
+func (cs *cronService) GetCronJob(id uint) (*model.CronJob, error) {
+	job, err := cs.cronRepository.GetCronJobByID(id)
+	if err != nil {
+		return nil, errors.New("cron job not found")
+	}
+	return &job, nil
+}
+
+func (cs *cronService) CreateCronJob(req model.CronJobRequest, createdBy string) (*model.CronJob, error) {
+	if _, ok := cs.tasks()[req.Name]; !ok {
+		return nil, fmt.Errorf("unsupported job name: %s", req.Name)
+	}
+	if err := validateSchedule(req.Schedule); err != nil {
+		return nil, err
+	}
+	if _, err := cs.cronRepository.GetCronJobByJobName(req.Name); err == nil {
+		return nil, errors.New("cron job already exists")
+	}
+
+	job := model.CronJob{
+		Name:        req.Name,
+		Schedule:    req.Schedule,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		Description: req.Description,
+		CreatedBy:   &createdBy,
+		UpdatedBy:   &createdBy,
+	}
+	if err := cs.cronRepository.CreateCronJob(&job); err != nil {
+		return nil, err
+	}
+
+	cs.scheduleJob(job)
+	return &job, nil
+}
+
+func (cs *cronService) UpdateCronJob(id uint, req model.UpdateCronJobRequest, updatedBy string) (*model.CronJob, error) {
+	job, err := cs.GetCronJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Schedule != nil {
+		if err := validateSchedule(*req.Schedule); err != nil {
+			return nil, err
+		}
+		job.Schedule = *req.Schedule
+	}
+	if req.Description != nil {
+		job.Description = *req.Description
+	}
+	if req.IsActive != nil {
+		job.IsActive = *req.IsActive
+	}
+	job.UpdatedBy = &updatedBy
+
+	if err := cs.cronRepository.UpdateCronJob(job); err != nil {
+		return nil, err
+	}
+
+	cs.scheduleJob(*job)
+	return job, nil
+}
+
+func (cs *cronService) SetCronJobActive(id uint, active bool, updatedBy string) (*model.CronJob, error) {
+	return cs.UpdateCronJob(id, model.UpdateCronJobRequest{IsActive: &active}, updatedBy)
+}
+
+// TriggerCronJob records a manual run and executes it in the background, the returned run
+// can be followed through the run history
+func (cs *cronService) TriggerCronJob(id uint, triggeredBy string) (*model.CronJobRun, error) {
+	job, err := cs.GetCronJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cs.tasks()[job.Name]; !ok {
+		return nil, fmt.Errorf("unsupported job name: %s", job.Name)
+	}
+
+	lockKey, acquired, err := cs.acquireJobLock(*job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	if !acquired {
+		return nil, errors.New("cron job is already running")
+	}
+
+	run, err := cs.startRun(*job, model.RunTriggerManual, triggeredBy, lockKey)
+	if err != nil {
+		cs.releaseJobLock(lockKey)
+		return nil, err
+	}
+
+	started := *run
+	go cs.runJob(*job, run)
+	return &started, nil
+}
+
+func (cs *cronService) GetCronJobRuns(id uint, pageIndex, pageSize int) ([]model.CronJobRun, int64, error) {
+	if _, err := cs.GetCronJob(id); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := cs.cronRepository.GetCountCronJobRuns(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	runs, err := cs.cronRepository.GetListCronJobRuns(id, pageIndex, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, count, nil
+}
+
+// isDue reports whether the schedule fires at the given minute
+func isDue(schedule string, tick time.Time) bool {
+	parsed, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return false
+	}
+	return parsed.Next(tick.Add(-time.Second)).Equal(tick)
+}
+
+func validateSchedule(schedule string) error {
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	return nil
 }
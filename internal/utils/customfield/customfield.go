@@ -0,0 +1,228 @@
+package customfield
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TypeString = "string"
+	TypeNumber = "number"
+	TypeDate   = "date"
+	TypeEnum   = "enum"
+)
+
+const dateLayout = "2006-01-02"
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Field declares one custom field of an asset category
+type Field struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label,omitempty"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required,omitempty"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// Schema is the list of custom fields of an asset category, stored as JSONB
+type Schema []Field
+
+// Values holds the custom field values of an asset keyed by field key, stored as JSONB
+type Values map[string]interface{}
+
+// ValidKey reports whether key can be used as a custom field key
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// NewSchema normalizes the fields and checks that keys are unique and types are known
+func NewSchema(fields []Field) (Schema, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	schema := make(Schema, 0, len(fields))
+	keys := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		field.Key = strings.TrimSpace(field.Key)
+		field.Label = strings.TrimSpace(field.Label)
+		field.Type = strings.ToLower(strings.TrimSpace(field.Type))
+
+		if !ValidKey(field.Key) {
+			return nil, fmt.Errorf("custom field key %q must start with a letter and contain only lowercase letters, digits and underscores", field.Key)
+		}
+		if keys[field.Key] {
+			return nil, fmt.Errorf("custom field key %q is declared twice", field.Key)
+		}
+		keys[field.Key] = true
+
+		switch field.Type {
+		case TypeString, TypeNumber, TypeDate:
+			field.Options = nil
+		case TypeEnum:
+			if len(field.Options) == 0 {
+				return nil, fmt.Errorf("custom field %q of type enum needs options", field.Key)
+			}
+		default:
+			return nil, fmt.Errorf("custom field %q must be of type string, number, date or enum", field.Key)
+		}
+		schema = append(schema, field)
+	}
+	return schema, nil
+}
+
+// Check validates the values against the schema and returns them normalized: numbers as float64,
+// dates as YYYY-MM-DD and empty values left out. Unknown keys are rejected.
+func (s Schema) Check(values map[string]interface{}) (Values, error) {
+	fields := make(map[string]Field, len(s))
+	for _, field := range s {
+		fields[field.Key] = field
+	}
+	for key := range values {
+		if _, ok := fields[key]; !ok {
+			return nil, fmt.Errorf("unknown custom field %q", key)
+		}
+	}
+
+	checked := make(Values, len(values))
+	for _, field := range s {
+		value, ok := values[field.Key]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("custom field %q is required", field.Key)
+			}
+			continue
+		}
+
+		normalized, err := field.normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		checked[field.Key] = normalized
+	}
+	return checked, nil
+}
+
+// Prune drops the values that are not declared by the schema, used when an asset changes category
+func (s Schema) Prune(values Values) map[string]interface{} {
+	pruned := make(map[string]interface{}, len(values))
+	for _, field := range s {
+		if value, ok := values[field.Key]; ok {
+			pruned[field.Key] = value
+		}
+	}
+	return pruned
+}
+
+// FilterDocuments returns the JSON documents an asset's values must contain to match key=value,
+// a numeric value also matches the stored number
+func FilterDocuments(key, value string) []string {
+	documents := []string{mustMarshal(map[string]interface{}{key: value})}
+	if number, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+		documents = append(documents, mustMarshal(map[string]interface{}{key: number}))
+	}
+	return documents
+}
+
+func mustMarshal(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func (f Field) normalize(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case TypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err == nil {
+				return number, nil
+			}
+		}
+		return nil, fmt.Errorf("custom field %q must be a number", f.Key)
+	case TypeDate:
+		if v, ok := value.(string); ok {
+			date, err := time.Parse(dateLayout, strings.TrimSpace(v))
+			if err == nil {
+				return date.Format(dateLayout), nil
+			}
+		}
+		return nil, fmt.Errorf("custom field %q must be a date (YYYY-MM-DD)", f.Key)
+	case TypeEnum:
+		if v, ok := value.(string); ok {
+			for _, option := range f.Options {
+				if option == v {
+					return v, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("custom field %q must be one of %s", f.Key, strings.Join(f.Options, ", "))
+	default:
+		if v, ok := value.(string); ok {
+			return strings.TrimSpace(v), nil
+		}
+		return nil, fmt.Errorf("custom field %q must be a string", f.Key)
+	}
+}
+
+func (s Schema) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *Schema) Scan(src interface{}) error {
+	data, err := jsonBytes(src)
+	if err != nil || data == nil {
+		*s = nil
+		return err
+	}
+	return json.Unmarshal(data, s)
+}
+
+func (v Values) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (v *Values) Scan(src interface{}) error {
+	data, err := jsonBytes(src)
+	if err != nil || data == nil {
+		*v = nil
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func jsonBytes(src interface{}) ([]byte, error) {
+	switch data := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return data, nil
+	case string:
+		return []byte(data), nil
+	default:
+		return nil, errors.New("custom fields must be stored as JSON")
+	}
+}
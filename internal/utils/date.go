@@ -3,6 +3,8 @@ package utils
 import (
 	response "asset-service/internal/dto/out/assets"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,14 @@ func GetToday() time.Time {
 	return time.Date(year, month, day, 0, 0, 0, 0, location)
 }
 
+// DaysBetween returns the number of calendar days from one date to another, ignoring the time of day
+// so that a daylight saving change does not shorten a day to 23 hours
+func DaysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
 func ParseOptionalDate(str *string) (*time.Time, error) {
 	if str == nil {
 		return nil, nil
@@ -46,3 +56,22 @@ func ToDateOnly(t *time.Time) *response.DateOnly {
 	}
 	return (*response.DateOnly)(t)
 }
+
+// ParseDayWindow parses a look-ahead window such as "30d", "2w" or "30" into a number of days
+func ParseDayWindow(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "d"):
+		value = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		value = strings.TrimSuffix(value, "w")
+		multiplier = 7
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, errors.New("window must be a positive number of days such as 30d or 2w")
+	}
+	return days * multiplier, nil
+}
@@ -0,0 +1,147 @@
+package depreciation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	MethodStraightLine     = "straight_line"
+	MethodDecliningBalance = "declining_balance"
+)
+
+// decliningBalanceFactor is the multiplier of the straight-line rate, 2 gives double-declining balance
+const decliningBalanceFactor = 2.0
+
+// MaxUsefulLifeMonths caps the useful life at 100 years, the asset_category table checks the same bound
+const MaxUsefulLifeMonths = 1200
+
+// Settings is the depreciation configuration of an asset category
+type Settings struct {
+	Method           string
+	UsefulLifeMonths int
+	SalvageValue     float64
+}
+
+// Period is one year of the depreciation schedule, the last period may be shorter
+type Period struct {
+	Period                  int
+	StartDate               time.Time
+	EndDate                 time.Time
+	OpeningValue            float64
+	Depreciation            float64
+	ClosingValue            float64
+	AccumulatedDepreciation float64
+}
+
+// NewSettings builds the settings from the nullable category columns
+func NewSettings(method *string, usefulLifeMonths *int, salvageValue float64) Settings {
+	settings := Settings{SalvageValue: salvageValue}
+	if method != nil {
+		settings.Method = *method
+	}
+	if usefulLifeMonths != nil {
+		settings.UsefulLifeMonths = *usefulLifeMonths
+	}
+	return settings
+}
+
+// NormalizeMethod lower-cases the method and accepts "-" or " " as separator
+func NormalizeMethod(method string) string {
+	method = strings.ToLower(strings.TrimSpace(method))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(method)
+}
+
+// Validate checks the settings of a category, an empty method means the category does not depreciate
+func (s Settings) Validate() error {
+	if s.Method == "" {
+		return nil
+	}
+	if s.Method != MethodStraightLine && s.Method != MethodDecliningBalance {
+		return errors.New("depreciation_method must be straight_line or declining_balance")
+	}
+	if s.UsefulLifeMonths <= 0 || s.UsefulLifeMonths > MaxUsefulLifeMonths {
+		return fmt.Errorf("useful_life_months must be between 1 and %d", MaxUsefulLifeMonths)
+	}
+	if s.SalvageValue < 0 {
+		return errors.New("salvage_value must not be negative")
+	}
+	return nil
+}
+
+// Enabled reports whether the settings describe a depreciating category
+func (s Settings) Enabled() bool {
+	return s.Method != "" && s.UsefulLifeMonths > 0 && s.UsefulLifeMonths <= MaxUsefulLifeMonths
+}
+
+// valueAfter returns the book value after the given number of full months of the useful life
+func (s Settings) valueAfter(cost float64, months int) float64 {
+	salvage := math.Min(s.SalvageValue, cost)
+	// declining balance never reaches the salvage value by itself, write off the rest in the last month
+	if months >= s.UsefulLifeMonths {
+		return salvage
+	}
+
+	switch s.Method {
+	case MethodDecliningBalance:
+		rate := decliningBalanceFactor / float64(s.UsefulLifeMonths)
+		return math.Max(salvage, cost*math.Pow(1-rate, float64(months)))
+	default:
+		return cost - (cost-salvage)*float64(months)/float64(s.UsefulLifeMonths)
+	}
+}
+
+// BookValue returns the value of an asset bought at purchaseDate for cost as of the given date
+func (s Settings) BookValue(cost float64, purchaseDate, asOf time.Time) float64 {
+	if !s.Enabled() {
+		return round(cost)
+	}
+
+	months := monthsBetween(purchaseDate, asOf)
+	if months <= 0 {
+		return round(cost)
+	}
+	return round(s.valueAfter(cost, months))
+}
+
+// Schedule splits the useful life into yearly periods starting at purchaseDate
+func (s Settings) Schedule(cost float64, purchaseDate time.Time) []Period {
+	if !s.Enabled() {
+		return nil
+	}
+
+	var periods []Period
+	for start := 0; start < s.UsefulLifeMonths; start += 12 {
+		end := start + 12
+		if end > s.UsefulLifeMonths {
+			end = s.UsefulLifeMonths
+		}
+
+		periods = append(periods, Period{
+			Period:                  len(periods) + 1,
+			StartDate:               purchaseDate.AddDate(0, start, 0),
+			EndDate:                 purchaseDate.AddDate(0, end, -1),
+			OpeningValue:            round(s.valueAfter(cost, start)),
+			Depreciation:            round(s.valueAfter(cost, start) - s.valueAfter(cost, end)),
+			ClosingValue:            round(s.valueAfter(cost, end)),
+			AccumulatedDepreciation: round(cost - s.valueAfter(cost, end)),
+		})
+	}
+	return periods
+}
+
+// monthsBetween counts the full months elapsed from start to end
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	return months
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
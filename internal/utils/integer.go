@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"strconv"
+	"strings"
 )
 
 func ParseFormInt(context *gin.Context, field string) int {
@@ -33,6 +34,23 @@ func ConvertToUint(input string) (uint, error) {
 	return uint(parsed), nil
 }
 
+// ParseUintList parses a comma separated list of ids, e.g. "1,2,3"
+func ParseUintList(input string) ([]uint, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	var result []uint
+	for _, part := range strings.Split(input, ",") {
+		parsed, err := ConvertToUint(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
 func ParseFormFloat(context *gin.Context, field string) float64 {
 	val := context.PostForm(field)
 	if val == "" {
@@ -0,0 +1,216 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	TypeQR      = "qr"
+	TypeBarcode = "barcode"
+
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	ContentTypePNG = "image/png"
+	ContentTypeSVG = "image/svg+xml"
+	ContentTypePDF = "application/pdf"
+)
+
+// Label is a single printable asset label
+type Label struct {
+	AssetID      uint
+	Name         string
+	SerialNumber *string
+	Barcode      *string
+	URL          string
+}
+
+// Render encodes content as a QR code or Code128 barcode and returns the image bytes and content type
+func Render(content, labelType, format string, size int) ([]byte, string, error) {
+	code, err := encode(content, labelType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case FormatPNG:
+		width, height := size, size
+		if labelType == TypeBarcode {
+			height = size / 3
+		}
+		data, err := toPNG(code, width, height)
+		return data, ContentTypePNG, err
+	case FormatSVG:
+		return toSVG(code, size), ContentTypeSVG, nil
+	default:
+		return nil, "", errors.New("unsupported label format: " + format)
+	}
+}
+
+// Sheet lays the labels out on A4 pages (3 x 8 per page) and returns the PDF bytes
+func Sheet(labels []Label) ([]byte, error) {
+	const (
+		columns     = 3
+		rows        = 8
+		labelWidth  = 70.0
+		labelHeight = 37.0
+		marginTop   = 0.5
+		qrSize      = 26.0
+		padding     = 3.0
+	)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+
+	for i, item := range labels {
+		position := i % (columns * rows)
+		if position == 0 {
+			pdf.AddPage()
+		}
+		x := float64(position%columns) * labelWidth
+		y := marginTop + float64(position/columns)*labelHeight
+
+		qrCode, err := encode(item.URL, TypeQR)
+		if err != nil {
+			return nil, err
+		}
+		qrPNG, err := toPNG(qrCode, 256, 256)
+		if err != nil {
+			return nil, err
+		}
+		qrName := fmt.Sprintf("qr-%d-%d", item.AssetID, i)
+		pdf.RegisterImageOptionsReader(qrName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+		pdf.ImageOptions(qrName, x+padding, y+padding, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		textX := x + padding*2 + qrSize
+		textWidth := labelWidth - qrSize - padding*3
+
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetXY(textX, y+padding)
+		pdf.CellFormat(textWidth, 5, truncate(pdf, item.Name, textWidth), "", 0, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetXY(textX, y+padding+5)
+		pdf.CellFormat(textWidth, 4, fmt.Sprintf("ID: %d", item.AssetID), "", 0, "L", false, 0, "")
+		if item.SerialNumber != nil && *item.SerialNumber != "" {
+			pdf.SetXY(textX, y+padding+9)
+			pdf.CellFormat(textWidth, 4, truncate(pdf, "SN: "+*item.SerialNumber, textWidth), "", 0, "L", false, 0, "")
+		}
+
+		if item.Barcode != nil && *item.Barcode != "" {
+			// a barcode Code128 cannot encode or too long to draw is printed as text only
+			if barcodePNG, err := sheetBarcode(*item.Barcode); err == nil {
+				barcodeName := fmt.Sprintf("barcode-%d-%d", item.AssetID, i)
+				pdf.RegisterImageOptionsReader(barcodeName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(barcodePNG))
+				pdf.ImageOptions(barcodeName, textX, y+padding+14, textWidth, 9, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			}
+			pdf.SetXY(textX, y+padding+23)
+			pdf.CellFormat(textWidth, 4, truncate(pdf, *item.Barcode, textWidth), "", 0, "C", false, 0, "")
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// sheetBarcode draws the Code128 barcode of a sheet label
+func sheetBarcode(content string) ([]byte, error) {
+	code, err := encode(content, TypeBarcode)
+	if err != nil {
+		return nil, err
+	}
+	return toPNG(code, 400, 100)
+}
+
+func encode(content, labelType string) (barcode.Barcode, error) {
+	if content == "" {
+		return nil, errors.New("label content is empty")
+	}
+
+	switch labelType {
+	case TypeQR:
+		return qr.Encode(content, qr.M, qr.Auto)
+	case TypeBarcode:
+		return code128.Encode(content)
+	default:
+		return nil, errors.New("unsupported label type: " + labelType)
+	}
+}
+
+func toPNG(code barcode.Barcode, width, height int) ([]byte, error) {
+	scaled, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, scaled); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// toSVG draws one rect per dark module, the viewBox keeps the output crisp at any size
+func toSVG(code barcode.Barcode, size int) []byte {
+	bounds := code.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	linear := height == 1
+	renderHeight := size
+	if linear {
+		// linear barcodes are one module high, stretch the bars to a third of the width
+		height = width / 3
+		renderHeight = size / 3
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, renderHeight, width, height)
+	fmt.Fprintf(&builder, `<rect width="%d" height="%d" fill="#ffffff"/>`, width, height)
+	for x := 0; x < width; x++ {
+		if linear {
+			if isDark(code.At(bounds.Min.X+x, bounds.Min.Y)) {
+				fmt.Fprintf(&builder, `<rect x="%d" y="0" width="1" height="%d" fill="#000000"/>`, x, height)
+			}
+			continue
+		}
+		for y := 0; y < height; y++ {
+			if isDark(code.At(bounds.Min.X+x, bounds.Min.Y+y)) {
+				fmt.Fprintf(&builder, `<rect x="%d" y="%d" width="1" height="1" fill="#000000"/>`, x, y)
+			}
+		}
+	}
+	builder.WriteString(`</svg>`)
+	return []byte(builder.String())
+}
+
+func isDark(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 128
+}
+
+func truncate(pdf *fpdf.Fpdf, value string, width float64) string {
+	if pdf.GetStringWidth(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -10,6 +10,8 @@ import (
 type Service interface {
 	RequestImageDeletion(clientID string, images []string) error
 	RequestImageUsage(images []assets.ImageDeleteRequest) error
+	PublishMaintenanceDue(event assets.MaintenanceDueEvent) error
+	PublishAssetExpiring(event assets.AssetExpiringEvent) error
 }
 
 type natsService struct {
@@ -45,3 +47,33 @@ func (cs natsService) RequestImageUsage(images []assets.ImageDeleteRequest) erro
 
 	return nc.Publish(utils.NatsAssetImageUsage, data)
 }
+
+func (cs natsService) PublishMaintenanceDue(event assets.MaintenanceDueEvent) error {
+	nc, err := nats.Connect(cs.nats)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return nc.Publish(utils.NatsMaintenanceDue, data)
+}
+
+func (cs natsService) PublishAssetExpiring(event assets.AssetExpiringEvent) error {
+	nc, err := nats.Connect(cs.nats)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return nc.Publish(utils.NatsAssetExpiring, data)
+}
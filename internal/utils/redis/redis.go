@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"time"
 )
 
 // RedisService defines the contract for Redis operations
@@ -16,6 +17,9 @@ type RedisService interface {
 	DeleteData(key, clientID string) error
 	GetToken(clientID string) (string, error)
 	DeleteToken(clientID string) error
+	AcquireLock(key, owner string, ttl time.Duration) (bool, error)
+	RenewLock(key, owner string, ttl time.Duration) (bool, error)
+	ReleaseLock(key, owner string) error
 }
 
 // redisService implements RedisService
@@ -73,6 +77,39 @@ func (r redisService) DeleteToken(clientID string) error {
 	return r.Client.Del(r.Ctx, generateRedisKey(clientID)).Err()
 }
 
+// renewLockScript extends the lease only while the lock is still held by the same owner
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseLockScript deletes the lock only while it is still held by the same owner
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// AcquireLock sets key to owner with SET NX and reports whether this owner got the lock
+func (r redisService) AcquireLock(key, owner string, ttl time.Duration) (bool, error) {
+	return r.Client.SetNX(r.Ctx, key, owner, ttl).Result()
+}
+
+// RenewLock extends the lease of a lock held by owner, false means the lock was lost
+func (r redisService) RenewLock(key, owner string, ttl time.Duration) (bool, error) {
+	result, err := renewLockScript.Run(r.Ctx, &r.Client, []string{key}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+// ReleaseLock removes a lock held by owner, a lock taken over by someone else is left alone
+func (r redisService) ReleaseLock(key, owner string) error {
+	return releaseLockScript.Run(r.Ctx, &r.Client, []string{key}, owner).Err()
+}
+
 // GetUserRedis retrieves a user from Redis
 func GetUserRedis(redis RedisService, key, clientID string) (*user.UserRedis, error) {
 	var u user.UserRedis
@@ -0,0 +1,215 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatJSON = "json"
+
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	ContentTypeJSON = "application/json"
+)
+
+// FormatFromFilename returns csv or xlsx based on the file extension
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", errors.New("unsupported file type, expected .csv or .xlsx")
+	}
+}
+
+// ReadRows reads every row of a CSV file or of the first XLSX worksheet
+func ReadRows(reader io.Reader, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		csvReader := csv.NewReader(reader)
+		csvReader.FieldsPerRecord = -1
+		csvReader.TrimLeadingSpace = true
+		return csvReader.ReadAll()
+	case FormatXLSX:
+		file, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("workbook has no worksheets")
+		}
+		return file.GetRows(sheets[0])
+	default:
+		return nil, errors.New("unsupported sheet format: " + format)
+	}
+}
+
+// Header maps normalized column names to their index
+func Header(row []string) map[string]int {
+	header := make(map[string]int, len(row))
+	for i, column := range row {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		name = strings.ReplaceAll(name, " ", "_")
+		if name != "" {
+			header[name] = i
+		}
+	}
+	return header
+}
+
+// Value returns the trimmed cell of the named column, or an empty string when missing
+func Value(row []string, header map[string]int, column string) string {
+	index, ok := header[column]
+	if !ok || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// ContentType returns the MIME type of an export format
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return ContentTypeXLSX
+	case FormatJSON:
+		return ContentTypeJSON
+	default:
+		return ContentTypeCSV
+	}
+}
+
+// Writer streams rows of an export. CSV and XLSX use the string values,
+// JSON encodes the record itself so numbers and nulls keep their type.
+type Writer interface {
+	Write(values []string, record interface{}) error
+	Close() error
+}
+
+// NewWriter returns a Writer for the format, the header is written immediately for CSV and XLSX
+func NewWriter(w io.Writer, format string, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		writer := &csvWriter{writer: csv.NewWriter(w)}
+		return writer, writer.Write(header, nil)
+	case FormatXLSX:
+		return newXLSXWriter(w, header)
+	case FormatJSON:
+		return &jsonWriter{writer: w, encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, errors.New("unsupported export format: " + format)
+	}
+}
+
+// csvFlushRows controls how often buffered CSV rows are pushed to the client
+const csvFlushRows = 500
+
+type csvWriter struct {
+	writer *csv.Writer
+	rows   int
+}
+
+func (c *csvWriter) Write(values []string, _ interface{}) error {
+	if err := c.writer.Write(values); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%csvFlushRows == 0 {
+		c.writer.Flush()
+		return c.writer.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxWriter keeps rows in the excelize stream writer, which spills to a temp file,
+// and writes the workbook out on Close
+type xlsxWriter struct {
+	writer io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, header []string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	writer := &xlsxWriter{writer: w, file: file, stream: stream}
+	if err := writer.Write(header, nil); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (x *xlsxWriter) Write(values []string, _ interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.writer)
+}
+
+// jsonWriter writes a JSON array one element at a time
+type jsonWriter struct {
+	writer  io.Writer
+	encoder *json.Encoder
+	rows    int
+}
+
+func (j *jsonWriter) Write(_ []string, record interface{}) error {
+	separator := ","
+	if j.rows == 0 {
+		separator = "["
+	}
+	if _, err := io.WriteString(j.writer, separator); err != nil {
+		return err
+	}
+	j.rows++
+	return j.encoder.Encode(record)
+}
+
+func (j *jsonWriter) Close() error {
+	closing := "]\n"
+	if j.rows == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(j.writer, closing)
+	return err
+}
@@ -0,0 +1,31 @@
+-- Loans of shared assets to members of an asset group, an asset has at most one open loan per group
+CREATE TABLE asset_group_asset_loan
+(
+    loan_id            SERIAL PRIMARY KEY,
+    asset_group_id     INT       NOT NULL,
+    asset_id           INT       NOT NULL,
+    borrower_user_id   INT       NOT NULL,
+    lender_user_id     INT       NOT NULL,
+    checked_out_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    due_at             TIMESTAMP NOT NULL,
+    returned_at        TIMESTAMP,
+    checkout_condition TEXT,
+    return_condition   TEXT,
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by         VARCHAR(255),
+    updated_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by         VARCHAR(255),
+    deleted_at         TIMESTAMP,
+    deleted_by         VARCHAR(255),
+    FOREIGN KEY (asset_id) REFERENCES asset (asset_id),
+    FOREIGN KEY (asset_group_id) REFERENCES asset_group (asset_group_id)
+);
+
+CREATE UNIQUE INDEX idx_asset_group_asset_loan_open ON asset_group_asset_loan (asset_group_id, asset_id)
+    WHERE returned_at IS NULL AND deleted_at IS NULL;
+CREATE INDEX idx_asset_group_asset_loan_group ON asset_group_asset_loan (asset_group_id);
+CREATE INDEX idx_asset_group_asset_loan_borrower ON asset_group_asset_loan (borrower_user_id);
+CREATE INDEX idx_asset_group_asset_loan_due ON asset_group_asset_loan (due_at) WHERE returned_at IS NULL;
+
+INSERT INTO asset_group_permission (permission_name, description, created_by)
+VALUES ('Lend', 'Check out and return shared assets of the group', 'system');
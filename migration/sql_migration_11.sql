@@ -0,0 +1,31 @@
+-- Ownership transfers of assets to another user or into another asset group, accepted or declined by the recipient
+CREATE TABLE asset_transfer
+(
+    transfer_id         SERIAL PRIMARY KEY,
+    asset_id            INT         NOT NULL,
+    from_user_client_id VARCHAR(50) NOT NULL,
+    to_user_client_id   VARCHAR(50) NOT NULL,
+    from_asset_group_id INT,
+    to_asset_group_id   INT,
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending',
+    message             TEXT,
+    responded_at        TIMESTAMP,
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by          VARCHAR(255),
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by          VARCHAR(255),
+    deleted_at          TIMESTAMP,
+    deleted_by          VARCHAR(255),
+    FOREIGN KEY (asset_id) REFERENCES asset (asset_id),
+    FOREIGN KEY (from_asset_group_id) REFERENCES asset_group (asset_group_id),
+    FOREIGN KEY (to_asset_group_id) REFERENCES asset_group (asset_group_id)
+);
+
+CREATE UNIQUE INDEX idx_asset_transfer_pending ON asset_transfer (asset_id) WHERE status = 'pending' AND deleted_at IS NULL;
+CREATE INDEX idx_asset_transfer_from ON asset_transfer (from_user_client_id);
+CREATE INDEX idx_asset_transfer_to ON asset_transfer (to_user_client_id);
+CREATE INDEX idx_asset_transfer_status ON asset_transfer (status);
+
+ALTER TABLE asset_transfer
+    ADD CONSTRAINT chk_asset_transfer_status
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled'));
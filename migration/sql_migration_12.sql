@@ -0,0 +1,54 @@
+-- Locations form a tree (site > building > room > shelf) owned by a user or by an asset group
+CREATE TABLE asset_location
+(
+    location_id        SERIAL PRIMARY KEY,
+    user_client_id     VARCHAR(50),
+    asset_group_id     INT,
+    parent_location_id INT,
+    name               VARCHAR(100) NOT NULL,
+    location_type      VARCHAR(20)  NOT NULL,
+    description        TEXT,
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by         VARCHAR(255),
+    updated_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by         VARCHAR(255),
+    deleted_at         TIMESTAMP,
+    deleted_by         VARCHAR(255),
+    FOREIGN KEY (asset_group_id) REFERENCES asset_group (asset_group_id),
+    FOREIGN KEY (parent_location_id) REFERENCES asset_location (location_id),
+    CONSTRAINT chk_asset_location_owner CHECK ((user_client_id IS NULL) <> (asset_group_id IS NULL)),
+    CONSTRAINT chk_asset_location_type CHECK (location_type IN ('site', 'building', 'room', 'shelf'))
+);
+
+CREATE INDEX idx_asset_location_user_client_id ON asset_location (user_client_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_asset_location_group ON asset_location (asset_group_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_asset_location_parent ON asset_location (parent_location_id) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER trigger_update_asset_location
+    BEFORE UPDATE
+    ON asset_location
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE asset
+    ADD COLUMN location_id INT REFERENCES asset_location (location_id);
+
+CREATE INDEX idx_asset_location_id ON asset (location_id);
+
+-- Every move of an asset between locations, a NULL location means the asset had no location
+CREATE TABLE asset_location_history
+(
+    history_id       SERIAL PRIMARY KEY,
+    asset_id         INT       NOT NULL,
+    from_location_id INT,
+    to_location_id   INT,
+    moved_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    notes            TEXT,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by       VARCHAR(255),
+    FOREIGN KEY (asset_id) REFERENCES asset (asset_id),
+    FOREIGN KEY (from_location_id) REFERENCES asset_location (location_id),
+    FOREIGN KEY (to_location_id) REFERENCES asset_location (location_id)
+);
+
+CREATE INDEX idx_asset_location_history_asset ON asset_location_history (asset_id, moved_at);
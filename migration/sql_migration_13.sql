@@ -0,0 +1,8 @@
+-- Asset categories declare a schema of typed custom fields, the values are stored on the asset keyed by field key
+ALTER TABLE asset_category
+    ADD COLUMN custom_fields JSONB;
+
+ALTER TABLE asset
+    ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_asset_custom_fields ON asset USING GIN (custom_fields jsonb_path_ops);
@@ -0,0 +1,6 @@
+-- Assets can be composed of other assets (a workstation of a monitor, dock and laptop), a component points to its parent
+ALTER TABLE asset
+    ADD COLUMN parent_asset_id INT REFERENCES asset (asset_id),
+    ADD CONSTRAINT chk_asset_parent_not_self CHECK (parent_asset_id <> asset_id);
+
+CREATE INDEX idx_asset_parent_asset_id ON asset (parent_asset_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- Maintenance type names are unique per user instead of globally, admins publish default types visible to every user
+ALTER TABLE asset_maintenance_type
+    DROP CONSTRAINT IF EXISTS asset_maintenance_type_maintenance_type_name_key;
+
+ALTER TABLE asset_maintenance_type
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX uq_maintenance_type_user_name ON asset_maintenance_type (user_client_id, LOWER(maintenance_type_name))
+    WHERE deleted_at IS NULL AND is_default = FALSE;
+CREATE UNIQUE INDEX uq_maintenance_type_default_name ON asset_maintenance_type (LOWER(maintenance_type_name))
+    WHERE deleted_at IS NULL AND is_default = TRUE;
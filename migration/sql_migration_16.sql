@@ -0,0 +1,27 @@
+-- Files attached to maintenance schedules and their records (invoices, photos, reports), stored on the CDN like asset images.
+-- Every attachment belongs to a schedule, maintenance_record_id narrows it down to one performed maintenance.
+-- Records are hard deleted, their attachments are soft deleted first and purged from the CDN by the cleanup job.
+CREATE TABLE asset_maintenance_attachment
+(
+    attachment_id         SERIAL PRIMARY KEY,
+    user_client_id        VARCHAR(50)  NOT NULL,
+    asset_id              INT          NOT NULL REFERENCES asset (asset_id),
+    maintenance_id        INT          NOT NULL REFERENCES asset_maintenance (id),
+    maintenance_record_id INT REFERENCES asset_maintenance_record (maintenance_record_id) ON DELETE SET NULL,
+    file_url              TEXT         NOT NULL,
+    file_name             VARCHAR(255) NOT NULL,
+    created_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by            VARCHAR(255),
+    updated_at            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_by            VARCHAR(255),
+    deleted_at            TIMESTAMP,
+    deleted_by            VARCHAR(255)
+);
+
+CREATE INDEX idx_maintenance_attachment_maintenance ON asset_maintenance_attachment (maintenance_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_maintenance_attachment_record ON asset_maintenance_attachment (maintenance_record_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_maintenance_attachment_asset ON asset_maintenance_attachment (asset_id);
+CREATE INDEX idx_maintenance_attachment_deleted ON asset_maintenance_attachment (deleted_at) WHERE deleted_at IS NOT NULL;
+
+INSERT INTO cron_jobs (name, schedule, is_active, description, created_by)
+VALUES ('maintenance_attachment_cleanup', '*/5 * * * *', true, 'Delete the files of removed maintenance attachments', 'system');
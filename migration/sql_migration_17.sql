@@ -0,0 +1,5 @@
+-- The audit log is read back by performer, time range and asset, the asset id is taken from the row after the
+-- change or from the row before it for deletes, the same expression as the audit log repository.
+CREATE INDEX idx_asset_audit_log_performed_by ON asset_audit_log (performed_by);
+CREATE INDEX idx_asset_audit_log_performed_at ON asset_audit_log (performed_at DESC);
+CREATE INDEX idx_asset_audit_log_asset ON asset_audit_log ((COALESCE(NULLIF(new_data, 'null'), old_data)::jsonb ->> 'asset_id'));
@@ -0,0 +1,6 @@
+-- Audit rows are written by the GORM callbacks for every audited model, record_id keeps the primary key of the
+-- changed row, composite keys joined with commas. Rows written before it are matched through their data.
+ALTER TABLE asset_audit_log
+    ADD COLUMN record_id VARCHAR(255);
+
+CREATE INDEX idx_asset_audit_log_record ON asset_audit_log (table_name, record_id);
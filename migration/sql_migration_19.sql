@@ -0,0 +1,23 @@
+-- Every audit row is chained to the previous row of its tenant: hash is the sha256 of the row and prev_hash,
+-- see audit.ChainHash. Rows written before this migration have no tenant and stay outside of the chains.
+ALTER TABLE asset_audit_log
+    ADD COLUMN tenant_id VARCHAR(255),
+    ADD COLUMN prev_hash VARCHAR(64),
+    ADD COLUMN hash      VARCHAR(64);
+
+CREATE INDEX idx_asset_audit_log_chain ON asset_audit_log (tenant_id, log_id) WHERE tenant_id IS NOT NULL;
+
+-- Signed heads of the chains, a chain cut off after its last checkpoint is still detected
+CREATE TABLE asset_audit_checkpoint
+(
+    checkpoint_id SERIAL PRIMARY KEY,
+    tenant_id     VARCHAR(255) NOT NULL,
+    log_id        INT          NOT NULL,
+    hash          VARCHAR(64)  NOT NULL,
+    signature     VARCHAR(64)  NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_asset_audit_checkpoint_tenant ON asset_audit_checkpoint (tenant_id, log_id);
+
+INSERT INTO cron_jobs (name, schedule, is_active, description, created_by)
+VALUES ('audit_checkpoint', '0 * * * *', true, 'Sign the head of every audit chain that moved since its last checkpoint', 'system');
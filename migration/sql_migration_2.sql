@@ -0,0 +1,27 @@
+-- Asset tags are owned per user, a tag name only has to be unique for its owner
+ALTER TABLE asset_tags
+    ADD COLUMN user_client_id VARCHAR(255),
+    ADD COLUMN updated_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_by     VARCHAR(255),
+    ADD COLUMN deleted_at     TIMESTAMP,
+    ADD COLUMN deleted_by     VARCHAR(255);
+
+ALTER TABLE asset_tags
+    DROP CONSTRAINT IF EXISTS asset_tags_tag_name_key;
+
+CREATE UNIQUE INDEX idx_asset_tags_user_name ON asset_tags (user_client_id, tag_name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_asset_tags_user_client_id ON asset_tags (user_client_id);
+
+ALTER TABLE asset_tag_map
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN created_by VARCHAR(255),
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_by VARCHAR(255),
+    ADD COLUMN deleted_at TIMESTAMP,
+    ADD COLUMN deleted_by VARCHAR(255);
+
+CREATE TRIGGER trigger_update_asset_tags
+    BEFORE UPDATE
+    ON asset_tags
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
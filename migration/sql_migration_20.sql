@@ -0,0 +1,9 @@
+-- Deleted assets stay in the trash for the retention period, the purge job replaces the image cleanup which
+-- removed the images of a deleted asset right away
+CREATE INDEX idx_asset_trash ON asset (user_client_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+UPDATE cron_jobs
+SET name        = 'asset_trash_purge',
+    schedule    = '0 2 * * *',
+    description = 'Permanently purge assets deleted longer ago than the trash retention, with their files'
+WHERE name = 'asset_image_cleanup';
@@ -0,0 +1,11 @@
+-- The API accepts a useful life of 1 to 1200 months, values outside of it were written before the bound
+UPDATE asset_category
+SET useful_life_months = 1200
+WHERE useful_life_months > 1200;
+
+UPDATE asset_category
+SET useful_life_months = NULL
+WHERE useful_life_months < 1;
+
+ALTER TABLE asset_category
+    ADD CONSTRAINT chk_asset_category_useful_life CHECK (useful_life_months BETWEEN 1 AND 1200);
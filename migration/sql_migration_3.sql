@@ -0,0 +1,14 @@
+-- Full-text search over asset name, description, notes and serial number
+ALTER TABLE asset
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple',
+                    coalesce(name, '') || ' ' ||
+                    coalesce(description, '') || ' ' ||
+                    coalesce(notes, '') || ' ' ||
+                    coalesce(serial_number, ''))
+        ) STORED;
+
+CREATE INDEX idx_asset_search_vector ON asset USING GIN (search_vector);
+CREATE INDEX idx_asset_price ON asset (price);
+CREATE INDEX idx_asset_purchase_date ON asset (purchase_date);
+CREATE INDEX idx_asset_warranty_expiry_date ON asset (warranty_expiry_date);
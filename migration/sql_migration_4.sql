@@ -0,0 +1,5 @@
+-- Barcode and serial number are unique per owner for assets that are not deleted
+CREATE UNIQUE INDEX idx_asset_user_barcode_unique ON asset (user_client_id, barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_asset_user_serial_unique ON asset (user_client_id, serial_number)
+    WHERE serial_number IS NOT NULL AND deleted_at IS NULL;
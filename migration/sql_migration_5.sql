@@ -0,0 +1,20 @@
+-- One notification per maintenance schedule, due date and state so the daily check only reports changes
+CREATE TABLE asset_maintenance_notification
+(
+    notification_id SERIAL PRIMARY KEY,
+    user_client_id  VARCHAR(50) NOT NULL,
+    maintenance_id  INT         NOT NULL,
+    asset_id        INT         NOT NULL,
+    due_date        DATE        NOT NULL,
+    due_status      VARCHAR(20) NOT NULL,
+    notified_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by      VARCHAR(255),
+    FOREIGN KEY (maintenance_id) REFERENCES asset_maintenance (id) ON DELETE CASCADE,
+    FOREIGN KEY (asset_id) REFERENCES asset (asset_id)
+);
+
+CREATE UNIQUE INDEX idx_asset_maintenance_notification_unique
+    ON asset_maintenance_notification (maintenance_id, due_date, due_status);
+CREATE INDEX idx_asset_maintenance_notification_user ON asset_maintenance_notification (user_client_id);
+CREATE INDEX idx_asset_maintenance_next_due_date ON asset_maintenance (next_due_date) WHERE deleted_at IS NULL;
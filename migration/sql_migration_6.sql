@@ -0,0 +1,21 @@
+-- Alerts sent for assets whose warranty or expiry date is approaching, one per asset, type and date
+CREATE TABLE asset_expiry_alert
+(
+    alert_id       SERIAL PRIMARY KEY,
+    user_client_id VARCHAR(50) NOT NULL,
+    asset_id       INT         NOT NULL,
+    expiry_type    VARCHAR(20) NOT NULL,
+    expiry_date    DATE        NOT NULL,
+    notified_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by     VARCHAR(255),
+    FOREIGN KEY (asset_id) REFERENCES asset (asset_id)
+);
+
+CREATE UNIQUE INDEX idx_asset_expiry_alert_unique ON asset_expiry_alert (asset_id, expiry_type, expiry_date);
+CREATE INDEX idx_asset_expiry_alert_user ON asset_expiry_alert (user_client_id);
+
+CREATE INDEX idx_asset_expiry_date ON asset (expiry_date) WHERE deleted_at IS NULL;
+
+INSERT INTO cron_jobs (name, schedule, is_active, description, created_by)
+VALUES ('asset_expiry_alert', '0 6 * * *', true, 'Alert owners about assets with an approaching warranty or expiry date', 'system');
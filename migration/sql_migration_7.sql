@@ -0,0 +1,17 @@
+-- History of every cron job execution, scheduled or triggered from the admin API
+CREATE TABLE cron_job_runs
+(
+    id          SERIAL PRIMARY KEY,
+    cron_job_id INT          NOT NULL,
+    job_name    VARCHAR(255) NOT NULL,
+    trigger_type VARCHAR(20) NOT NULL,
+    status      VARCHAR(20)  NOT NULL,
+    started_at  TIMESTAMP    NOT NULL,
+    finished_at TIMESTAMP,
+    duration_ms BIGINT,
+    error       TEXT,
+    created_by  VARCHAR(255),
+    FOREIGN KEY (cron_job_id) REFERENCES cron_jobs (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_cron_job_runs_job_started ON cron_job_runs (cron_job_id, started_at DESC);
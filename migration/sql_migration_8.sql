@@ -0,0 +1,5 @@
+-- Cron runs are serialized across replicas with a Redis lock, keep who held it for each run
+ALTER TABLE cron_job_runs
+    ADD COLUMN lock_key      VARCHAR(255),
+    ADD COLUMN lock_owner    VARCHAR(255),
+    ADD COLUMN lock_renewals INT DEFAULT 0;
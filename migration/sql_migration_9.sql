@@ -0,0 +1,5 @@
+-- Depreciation settings per asset category, a NULL method means assets of the category keep their price
+ALTER TABLE asset_category
+    ADD COLUMN depreciation_method VARCHAR(50),
+    ADD COLUMN useful_life_months  INT,
+    ADD COLUMN salvage_value       DECIMAL(40, 2) DEFAULT 0;